@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/0xPolygon/zkevm-ethtx-manager/ethtxmanager"
+	"github.com/BurntSushi/toml"
+	"github.com/mitchellh/mapstructure"
+)
+
+// envPrefix is the prefix of the environment variables overriding the config file values. The rest of the
+// name is the path of the overridden key, its sections (and list indexes) separated by underscores, e.g.
+// ZKEVM_ETHTXMANAGER_ETHERMAN_URL overrides URL in the Etherman section
+const envPrefix = "ZKEVM_ETHTXMANAGER_"
+
+// loadConfig reads the ethtxmanager configuration from a TOML file, overridden by the environment variables
+// starting with envPrefix. The file is decoded following the mapstructure tags of the config, so the signer
+// settings are kept along their Method
+func loadConfig(path string) (ethtxmanager.Config, error) {
+	var cfg ethtxmanager.Config
+
+	raw := make(map[string]interface{})
+	if _, err := toml.DecodeFile(path, &raw); err != nil {
+		return cfg, fmt.Errorf("failed to read the config file %s: %w", path, err)
+	}
+	if err := applyEnvOverrides(raw, os.Environ()); err != nil {
+		return cfg, err
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.TextUnmarshallerHookFunc(),
+			mapstructure.StringToSliceHookFunc(","),
+		),
+		Result:           &cfg,
+		WeaklyTypedInput: true,
+	})
+	if err != nil {
+		return cfg, err
+	}
+	if err := decoder.Decode(raw); err != nil {
+		return cfg, fmt.Errorf("failed to decode the config file %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// applyEnvOverrides sets the values of the environment variables starting with envPrefix into the raw config.
+// The keys are matched case insensitively and the missing sections are created, the list elements can only be
+// overridden if they are in the file. The values are strings, converted to the config types when decoding it
+func applyEnvOverrides(raw map[string]interface{}, environ []string) error {
+	for _, env := range environ {
+		name, value, found := strings.Cut(env, "=")
+		if !found || !strings.HasPrefix(name, envPrefix) {
+			continue
+		}
+
+		path := strings.Split(strings.TrimPrefix(name, envPrefix), "_")
+		if err := setRawValue(raw, path, value); err != nil {
+			return fmt.Errorf("failed to override the config with %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// setRawValue sets the value in the path of the raw config
+func setRawValue(node interface{}, path []string, value string) error {
+	switch current := node.(type) {
+	case map[string]interface{}:
+		key := rawKey(current, path[0])
+		if len(path) == 1 {
+			current[key] = value
+			return nil
+		}
+		child, found := current[key]
+		if !found {
+			child = make(map[string]interface{})
+			current[key] = child
+		}
+		return setRawValue(child, path[1:], value)
+
+	case []map[string]interface{}:
+		items := make([]interface{}, len(current))
+		for i, item := range current {
+			items[i] = item
+		}
+		return setRawValue(items, path, value)
+
+	case []interface{}:
+		index, err := strconv.Atoi(path[0])
+		if err != nil || index < 0 || index >= len(current) {
+			return fmt.Errorf("%s is not an index of the list", path[0])
+		}
+		if len(path) == 1 {
+			current[index] = value
+			return nil
+		}
+		return setRawValue(current[index], path[1:], value)
+
+	default:
+		return fmt.Errorf("%s is not a section", path[0])
+	}
+}
+
+// rawKey returns the key of the raw config matching the name case insensitively, or the name if there is none
+func rawKey(section map[string]interface{}, name string) string {
+	for key := range section {
+		if strings.EqualFold(key, name) {
+			return key
+		}
+	}
+	return name
+}
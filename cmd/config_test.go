@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `
+StoragePath = "/var/lib/ethtxmanager.sqlite"
+FrequencyToMonitorTxs = "1s"
+
+[Etherman]
+URL = "http://localhost:8545"
+L1ChainID = 1337
+
+[[PrivateKeys]]
+Method = "local"
+Path = "/pk/sequencer.keystore"
+Password = "testonly"
+`
+
+func TestLoadConfigEnvOverrides(t *testing.T) {
+	cfgPath := path.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte(testConfig), 0600))
+
+	cfg, err := loadConfig(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:8545", cfg.Etherman.URL)
+	require.Equal(t, "testonly", cfg.PrivateKeys[0].Config["Password"])
+
+	t.Setenv("ZKEVM_ETHTXMANAGER_ETHERMAN_URL", "http://l1-node:8545")
+	t.Setenv("ZKEVM_ETHTXMANAGER_STORAGEPATH", "/data/ethtxmanager.sqlite")
+	t.Setenv("ZKEVM_ETHTXMANAGER_FREQUENCYTOMONITORTXS", "5s")
+	t.Setenv("ZKEVM_ETHTXMANAGER_PRIVATEKEYS_0_PASSWORD", "secret")
+
+	cfg, err = loadConfig(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, "http://l1-node:8545", cfg.Etherman.URL)
+	require.Equal(t, "/data/ethtxmanager.sqlite", cfg.StoragePath)
+	require.Equal(t, 5*time.Second, cfg.FrequencyToMonitorTxs.Duration)
+	require.Equal(t, "secret", cfg.PrivateKeys[0].Config["Password"])
+	// the values not overridden are kept
+	require.Equal(t, uint64(1337), cfg.Etherman.L1ChainID)
+
+	t.Setenv("ZKEVM_ETHTXMANAGER_PRIVATEKEYS_1_PASSWORD", "secret")
+	_, err = loadConfig(cfgPath)
+	require.ErrorContains(t, err, "1 is not an index of the list")
+}
+
+func TestLoadConfigHardwareSigner(t *testing.T) {
+	// the hardware signer example of the README
+	const hardwareSignerConfig = `
+[Etherman]
+URL = "http://localhost:8545"
+SignTimeout = "30s"
+
+[[PrivateKeys]]
+Method = "remote"
+URL = "http://localhost:8550"
+Address = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+`
+	cfgPath := path.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte(hardwareSignerConfig), 0600))
+
+	cfg, err := loadConfig(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, 30*time.Second, cfg.Etherman.SignTimeout.Duration)
+	require.Len(t, cfg.PrivateKeys, 1)
+	require.Equal(t, signertypes.MethodRemoteSigner, cfg.PrivateKeys[0].Method)
+	require.Equal(t, "http://localhost:8550", cfg.PrivateKeys[0].Config["URL"])
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/0xPolygon/zkevm-ethtx-manager/ethtxmanager"
+)
+
+const (
+	// doctorCommand is the command that validates the configuration end to end
+	doctorCommand = "doctor"
+	// doctorTimeout bounds the checks run against the node and the storage
+	doctorTimeout = 30 * time.Second
+)
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != doctorCommand {
+		fmt.Fprintf(os.Stderr, "usage: %s %s -c <config file>\n", os.Args[0], doctorCommand)
+		os.Exit(2)
+	}
+
+	flags := flag.NewFlagSet(doctorCommand, flag.ExitOnError)
+	cfgPath := flags.String("c", "", "path to the TOML config file")
+	_ = flags.Parse(os.Args[2:])
+	if *cfgPath == "" {
+		fmt.Fprintf(os.Stderr, "usage: %s %s -c <config file>\n", os.Args[0], doctorCommand)
+		os.Exit(2)
+	}
+
+	if !doctor(*cfgPath, os.Stdout) {
+		os.Exit(1)
+	}
+}
+
+// doctor checks the configuration works end to end without sending any tx and prints a pass/fail
+// report of each step. It returns whether all of them passed
+func doctor(cfgPath string, out io.Writer) bool {
+	cfg, err := loadConfig(cfgPath)
+	report(out, "load config", err)
+	if err != nil {
+		return false
+	}
+
+	// building the client connects to the node, loads the signers and opens the storage
+	client, err := ethtxmanager.New(cfg)
+	report(out, "create client", err)
+	if err != nil {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+
+	err = client.Preflight(ctx)
+	if err == nil {
+		report(out, "preflight", nil)
+		return true
+	}
+	var joined interface{ Unwrap() []error }
+	if errors.As(err, &joined) {
+		for _, checkErr := range joined.Unwrap() {
+			report(out, "preflight", checkErr)
+		}
+	} else {
+		report(out, "preflight", err)
+	}
+
+	return false
+}
+
+// report prints the result of a doctor step
+func report(out io.Writer, step string, err error) {
+	if err != nil {
+		fmt.Fprintf(out, "[FAIL] %s: %v\n", step, err)
+		return
+	}
+	fmt.Fprintf(out, "[PASS] %s\n", step)
+}
@@ -1,6 +1,13 @@
 package etherman
 
-import "github.com/0xPolygon/zkevm-ethtx-manager/etherman/etherscan"
+import (
+	"net/http"
+
+	"github.com/0xPolygon/zkevm-ethtx-manager/config/types"
+	"github.com/0xPolygon/zkevm-ethtx-manager/etherman/etherscan"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
 
 // Config represents the configuration of the etherman
 type Config struct {
@@ -9,6 +16,13 @@ type Config struct {
 
 	// allow that L1 gas price calculation use multiples sources
 	MultiGasProvider bool `mapstructure:"MultiGasProvider"`
+	// RequireAllGasProviders makes the L1 gas price calculation fail when any of the gas providers fails,
+	// by default the failing providers are ignored as long as one of them succeeds
+	RequireAllGasProviders bool `mapstructure:"RequireAllGasProviders"`
+	// GasPriceCacheTTL is the time the L1 gas price got from the gas providers is reused by the successive
+	// calls instead of requesting it again, reducing the load on the node and on the rate limited providers
+	// like Etherscan. The failed requests aren't cached, 0 means no cache
+	GasPriceCacheTTL types.Duration `mapstructure:"GasPriceCacheTTL"`
 	// Configuration for use Etherscan as used as gas provider, basically it needs the API-KEY
 	Etherscan etherscan.Config
 	// L1ChainID specifies the chain ID of the network to which transactions will be sent
@@ -16,4 +30,56 @@ type Config struct {
 	L1ChainID uint64 `mapstructure:"L1ChainID"`
 	// HTTPHeaders are the headers to be used in the HTTP requests
 	HTTPHeaders map[string]string `mapstructure:"HTTPHeaders"`
+	// HTTPTimeout is the timeout of the HTTP requests to the Ethereum node, 0 means no timeout
+	HTTPTimeout types.Duration `mapstructure:"HTTPTimeout"`
+	// HTTPClient is the HTTP client used to send the requests to the Ethereum node, it allows
+	// to customize the transport (keep-alives, TLS, proxies...). If set, HTTPTimeout is ignored
+	HTTPClient *http.Client `mapstructure:"-"`
+	// RPCCallTimeout is the maximum time a single call to the Ethereum node can take, 0 means no timeout.
+	// It doesn't apply to waiting a tx to be mined, which has its own timeout
+	RPCCallTimeout types.Duration `mapstructure:"RPCCallTimeout"`
+	// SignTimeout is the maximum time signing a single tx can take, 0 means no timeout. The signers
+	// that block until the signature is provided, like a hardware wallet reached through a remote
+	// signer, must set it so a disconnected device fails the sign instead of stalling the monitoring
+	SignTimeout types.Duration `mapstructure:"SignTimeout"`
+	// RPCRateLimit is the maximum number of requests per second sent to the Ethereum node, shared by all the
+	// calls of the client (adding, monitoring and querying txs) so they respect the limits of the provider
+	// together. Bursts of up to one second worth of requests are allowed. It applies to the HTTP URLs only,
+	// 0 means no limit
+	RPCRateLimit float64 `mapstructure:"RPCRateLimit"`
+	// BlobSchedule are the blob parameters of the L1 network used to compute the blob fee
+	BlobSchedule BlobScheduleConfig `mapstructure:"BlobSchedule"`
+}
+
+// BlobScheduleConfig represents the blob parameters of the L1 network,
+// the Prague values are used for the fields set to 0
+type BlobScheduleConfig struct {
+	// Target is the target number of blobs per block
+	Target int `mapstructure:"Target"`
+	// Max is the maximum number of blobs per block
+	Max int `mapstructure:"Max"`
+	// UpdateFraction is the blob base fee update fraction
+	UpdateFraction uint64 `mapstructure:"UpdateFraction"`
+}
+
+// ChainConfig returns a chain config with the blob schedule active since genesis,
+// suitable to compute the excess blob gas and the blob fee of the L1 blocks
+func (c BlobScheduleConfig) ChainConfig() *params.ChainConfig {
+	blobConfig := *params.DefaultPragueBlobConfig
+	if c.Target > 0 {
+		blobConfig.Target = c.Target
+	}
+	if c.Max > 0 {
+		blobConfig.Max = c.Max
+	}
+	if c.UpdateFraction > 0 {
+		blobConfig.UpdateFraction = c.UpdateFraction
+	}
+
+	genesisTime := uint64(0)
+	return &params.ChainConfig{
+		LondonBlock:        common.Big0,
+		CancunTime:         &genesisTime,
+		BlobScheduleConfig: &params.BlobScheduleConfig{Cancun: &blobConfig},
+	}
 }
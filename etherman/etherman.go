@@ -5,6 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/0xPolygon/zkevm-ethtx-manager/etherman/etherscan"
@@ -12,21 +16,57 @@ import (
 	"github.com/0xPolygon/zkevm-ethtx-manager/log"
 	signertypes "github.com/agglayer/go_signer/signer/types"
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
+const (
+	// errMsgNonceTooLow is the error returned by the nodes when the nonce of a tx was already consumed
+	errMsgNonceTooLow = "nonce too low"
+
+	// errMsgExecutionReverted is the error returned by the nodes when the execution of a call is reverted
+	errMsgExecutionReverted = "execution reverted"
+)
+
+// alreadyKnownErrMsgs are the errors returned by the different node implementations
+// when a tx sent is already in their mempool
+var alreadyKnownErrMsgs = []string{
+	"already known",
+	"known transaction",
+	"already imported",
+}
+
 var (
 	// ErrNotFound is used when the object is not found
 	ErrNotFound = ethereum.NotFound
 	// ErrPrivateKeyNotFound used when the provided sender does not have a private key registered to be used
 	ErrPrivateKeyNotFound = errors.New("can't find sender private key to sign tx")
 	// ErrObjectIsNil used when the object is nil
-	ErrObjectIsNil       = errors.New("object is nil")
-	errGasPriceProviders = errors.New("failed to get gas price from all providers")
+	ErrObjectIsNil = errors.New("object is nil")
+	// ErrGasPriceProviderFailed used when a gas price provider fails and all the providers are required
+	ErrGasPriceProviderFailed = errors.New("failed to get gas price from a provider")
+	errGasPriceProviders      = errors.New("failed to get gas price from all providers")
+	// ErrStateOverridesNotSupported used when the node doesn't accept state overrides in the gas estimation
+	ErrStateOverridesNotSupported = errors.New("state overrides not supported by the node")
+	// ErrBatchCallsNotSupported used when the node doesn't accept JSON-RPC batch calls
+	ErrBatchCallsNotSupported = errors.New("batch calls not supported by the node")
+	// ErrSignTimeout used when the signer doesn't sign a tx within the configured SignTimeout
+	ErrSignTimeout = errors.New("timeout signing the tx")
+)
+
+const (
+	// rpcErrCodeMethodNotFound is the JSON-RPC error code of the unknown methods
+	rpcErrCodeMethodNotFound = -32601
+	// rpcErrCodeInvalidRequest is the JSON-RPC error code of the requests the node can't handle
+	rpcErrCodeInvalidRequest = -32600
+	// rpcErrCodeInvalidParams is the JSON-RPC error code of the invalid method parameters
+	rpcErrCodeInvalidParams = -32602
 )
 
 // EthereumClient is an interface that combines all the ethereum client interfaces
@@ -38,6 +78,7 @@ type EthereumClient interface {
 	ethereum.GasEstimator
 	ethereum.GasPricer
 	ethereum.GasPricer1559
+	ethereum.FeeHistoryReader
 	ethereum.PendingStateReader
 	ethereum.TransactionReader
 	ethereum.TransactionSender
@@ -57,6 +98,13 @@ type Client struct {
 	cfg          Config
 	GasProviders externalGasProviders
 	auth         EthermanSigner // empty in case of read-only client
+
+	// gasProviderFailures counts the failed requests to the gas price providers
+	gasProviderFailures atomic.Uint64
+	// gasPriceCache keeps the last L1 gas price for GasPriceCacheTTL
+	gasPriceCache gasPriceCache
+	// batchCallsNotSupported is set once the node rejects the JSON-RPC batch calls
+	batchCallsNotSupported atomic.Bool
 }
 
 type externalGasProviders struct {
@@ -65,8 +113,44 @@ type externalGasProviders struct {
 }
 
 // This var is for be able to test NewClient function that require to create a mock
-var ethclientFactoryFunc = func(rawurl string) (EthereumClient, error) {
-	return ethclient.Dial(rawurl)
+var ethclientFactoryFunc = dialEthClient
+
+// dialEthClient connects to the Ethereum node using the provided RPC client options
+func dialEthClient(rawurl string, options ...rpc.ClientOption) (EthereumClient, error) {
+	rpcClient, err := rpc.DialOptions(context.Background(), rawurl, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return ethclient.NewClient(rpcClient), nil
+}
+
+// rpcClientOptions returns the RPC client options according to the HTTP configuration
+func rpcClientOptions(cfg Config) []rpc.ClientOption {
+	httpClient := cfg.HTTPClient
+	if httpClient == nil && cfg.HTTPTimeout.Duration > 0 {
+		httpClient = &http.Client{Timeout: cfg.HTTPTimeout.Duration}
+	}
+
+	if cfg.RPCRateLimit > 0 {
+		// the client is copied so the rate limit doesn't apply to the other users of a provided one
+		limitedClient := &http.Client{}
+		if httpClient != nil {
+			*limitedClient = *httpClient
+		}
+		next := limitedClient.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		limitedClient.Transport = &rateLimitedTransport{limiter: newRateLimiter(cfg.RPCRateLimit), next: next}
+		httpClient = limitedClient
+	}
+
+	if httpClient == nil {
+		return nil
+	}
+
+	return []rpc.ClientOption{rpc.WithHTTPClient(httpClient)}
 }
 
 // NewClient creates a new etherman.
@@ -76,8 +160,7 @@ func NewClient(cfg Config, signersConfig []signertypes.SignerConfig) (*Client, e
 	}
 
 	// Connect to ethereum node
-	ethClient, err := ethclientFactoryFunc(cfg.URL)
-	//ethClient, err := ethclient.Dial(cfg.URL)
+	ethClient, err := ethclientFactoryFunc(cfg.URL, rpcClientOptions(cfg)...)
 	if err != nil {
 		log.Errorf("error connecting to %s: %+v", cfg.URL, err)
 		return nil, err
@@ -112,6 +195,7 @@ func NewClient(cfg Config, signersConfig []signertypes.SignerConfig) (*Client, e
 	if err != nil {
 		return nil, err
 	}
+	auth.signTimeout = cfg.SignTimeout.Duration
 
 	return &Client{EthClient: ethClient,
 		cfg: cfg,
@@ -125,22 +209,83 @@ func NewClient(cfg Config, signersConfig []signertypes.SignerConfig) (*Client, e
 
 // GetTx function get ethereum tx
 func (etherMan *Client) GetTx(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error) {
+	ctx, cancel := etherMan.withRPCTimeout(ctx)
+	defer cancel()
 	tx, isPending, err := etherMan.EthClient.TransactionByHash(ctx, txHash)
 	return tx, isPending, translateError(err)
 }
 
 // GetTxReceipt function gets ethereum tx receipt
 func (etherMan *Client) GetTxReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	ctx, cancel := etherMan.withRPCTimeout(ctx)
+	defer cancel()
 	recepit, err := etherMan.EthClient.TransactionReceipt(ctx, txHash)
 	return recepit, translateError(err)
 }
 
+// GetTxsAndReceipts gets the txs and the receipts of the provided hashes in a single JSON-RPC batch call,
+// in the same order as the hashes. The txs and receipts not found are nil. Once the node rejects the batch
+// calls, ErrBatchCallsNotSupported is returned without calling it again
+func (etherMan *Client) GetTxsAndReceipts(
+	ctx context.Context,
+	txHashes []common.Hash,
+) ([]*types.Transaction, []*types.Receipt, error) {
+	if etherMan.batchCallsNotSupported.Load() {
+		return nil, nil, ErrBatchCallsNotSupported
+	}
+	rpcClient := etherMan.EthClient.Client()
+	if rpcClient == nil {
+		return nil, nil, ErrBatchCallsNotSupported
+	}
+
+	ctx, cancel := etherMan.withRPCTimeout(ctx)
+	defer cancel()
+
+	txs := make([]*types.Transaction, len(txHashes))
+	receipts := make([]*types.Receipt, len(txHashes))
+	batch := make([]rpc.BatchElem, 0, 2*len(txHashes)) //nolint:mnd
+	for i, txHash := range txHashes {
+		batch = append(batch,
+			rpc.BatchElem{Method: "eth_getTransactionByHash", Args: []interface{}{txHash}, Result: &txs[i]},
+			rpc.BatchElem{Method: "eth_getTransactionReceipt", Args: []interface{}{txHash}, Result: &receipts[i]},
+		)
+	}
+
+	if err := rpcClient.BatchCallContext(ctx, batch); err != nil {
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) &&
+			(rpcErr.ErrorCode() == rpcErrCodeMethodNotFound || rpcErr.ErrorCode() == rpcErrCodeInvalidRequest) {
+			etherMan.batchCallsNotSupported.Store(true)
+			return nil, nil, fmt.Errorf("%w: %w", ErrBatchCallsNotSupported, err)
+		}
+		return nil, nil, translateError(err)
+	}
+	for _, elem := range batch {
+		if elem.Error != nil {
+			return nil, nil, fmt.Errorf("failed to call %s: %w", elem.Method, translateError(elem.Error))
+		}
+	}
+
+	return txs, receipts, nil
+}
+
 // GetLatestBlockNumber gets the latest block number from the ethereum
 func (etherMan *Client) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
 	number, err := etherMan.getBlockNumber(ctx, rpc.LatestBlockNumber)
 	return number, translateError(err)
 }
 
+// GetChainID gets the chain ID of the network the node is connected to
+func (etherMan *Client) GetChainID(ctx context.Context) (uint64, error) {
+	ctx, cancel := etherMan.withRPCTimeout(ctx)
+	defer cancel()
+	chainID, err := etherMan.EthClient.ChainID(ctx)
+	if err != nil {
+		return 0, translateError(err)
+	}
+	return chainID.Uint64(), nil
+}
+
 // WaitTxToBeMined waits for an L1 tx to be mined. It will return error if the tx is reverted or timeout is exceeded
 func (etherMan *Client) WaitTxToBeMined(
 	ctx context.Context,
@@ -158,15 +303,27 @@ func (etherMan *Client) WaitTxToBeMined(
 	return true, nil
 }
 
-// GetL1GasPrice gets the L1 gas price from available providers
+// GetL1GasPrice gets the L1 gas price from available providers, the last one is reused for GasPriceCacheTTL
 func (etherMan *Client) GetL1GasPrice(ctx context.Context) (*big.Int, error) {
+	cacheTTL := etherMan.cfg.GasPriceCacheTTL.Duration
+	if cacheTTL > 0 {
+		if gasPrice := etherMan.gasPriceCache.get(); gasPrice != nil {
+			log.Debug("cached gasPrice chosen: ", gasPrice)
+			return gasPrice, nil
+		}
+	}
+
 	gasPrice := big.NewInt(0)
 	success := false
 
 	for i, prov := range etherMan.GasProviders.Providers {
-		gp, err := prov.SuggestGasPrice(ctx)
+		gp, err := etherMan.suggestGasPrice(ctx, prov)
 		if err != nil {
+			etherMan.gasProviderFailures.Add(1)
 			log.Warnf("error getting gas price from provider %d. Error: %s", i+1, err.Error())
+			if etherMan.cfg.RequireAllGasProviders {
+				return nil, fmt.Errorf("%w %d: %w", ErrGasPriceProviderFailed, i+1, err)
+			}
 			continue
 		}
 		success = true
@@ -179,24 +336,90 @@ func (etherMan *Client) GetL1GasPrice(ctx context.Context) (*big.Int, error) {
 		return nil, errGasPriceProviders
 	}
 	log.Debug("gasPrice chosen: ", gasPrice)
+	if cacheTTL > 0 {
+		etherMan.gasPriceCache.set(gasPrice, cacheTTL)
+	}
 	return gasPrice, nil
 }
 
+// GasProviderFailures returns the number of failed requests to the gas price providers since the client was created
+func (etherMan *Client) GasProviderFailures() uint64 {
+	return etherMan.gasProviderFailures.Load()
+}
+
+// suggestGasPrice gets the gas price from the provider bounding the call with the RPC call timeout
+func (etherMan *Client) suggestGasPrice(ctx context.Context, provider ethereum.GasPricer) (*big.Int, error) {
+	ctx, cancel := etherMan.withRPCTimeout(ctx)
+	defer cancel()
+	return provider.SuggestGasPrice(ctx)
+}
+
 // SendTx sends a tx to L1
 func (etherMan *Client) SendTx(ctx context.Context, tx *types.Transaction) error {
+	ctx, cancel := etherMan.withRPCTimeout(ctx)
+	defer cancel()
 	return etherMan.EthClient.SendTransaction(ctx, tx)
 }
 
+// SendTxIdempotent sends a tx to L1 tolerating the tx being already known by the node
+// or already mined, so the caller can continue waiting for the tx receipt
+func (etherMan *Client) SendTxIdempotent(ctx context.Context, tx *types.Transaction) error {
+	err := etherMan.SendTx(ctx, tx)
+	if err == nil {
+		return nil
+	}
+
+	if isAlreadyKnownError(err) {
+		log.Debugf("tx %v already known by the node: %v", tx.Hash().String(), err)
+		return nil
+	}
+
+	// a tx already mined is rejected because its nonce was consumed, which is
+	// ambiguous, so it's considered sent only if its receipt is found
+	if strings.Contains(strings.ToLower(err.Error()), errMsgNonceTooLow) {
+		mined, _, checkErr := etherMan.CheckTxWasMined(ctx, tx.Hash())
+		if checkErr == nil && mined {
+			log.Debugf("tx %v already mined: %v", tx.Hash().String(), err)
+			return nil
+		}
+	}
+
+	return err
+}
+
+// isAlreadyKnownError checks if the error returned by the node when sending
+// a tx means the tx is already in its mempool
+func isAlreadyKnownError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, alreadyKnownMsg := range alreadyKnownErrMsgs {
+		if strings.Contains(msg, alreadyKnownMsg) {
+			return true
+		}
+	}
+	return false
+}
+
 // CurrentNonce returns the current nonce for the provided account
 func (etherMan *Client) CurrentNonce(ctx context.Context, account common.Address) (uint64, error) {
+	ctx, cancel := etherMan.withRPCTimeout(ctx)
+	defer cancel()
 	return etherMan.EthClient.NonceAt(ctx, account, nil)
 }
 
 // PendingNonce returns the pending nonce for the provided account
 func (etherMan *Client) PendingNonce(ctx context.Context, account common.Address) (uint64, error) {
+	ctx, cancel := etherMan.withRPCTimeout(ctx)
+	defer cancel()
 	return etherMan.EthClient.PendingNonceAt(ctx, account)
 }
 
+// BalanceAt returns the balance for the provided account at the latest block
+func (etherMan *Client) BalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	ctx, cancel := etherMan.withRPCTimeout(ctx)
+	defer cancel()
+	return etherMan.EthClient.BalanceAt(ctx, account, nil)
+}
+
 // SuggestedGasPrice returns the suggested gas price for the network at the moment
 // Allows zero as a valid gas price
 func (etherMan *Client) SuggestedGasPrice(ctx context.Context) (*big.Int, error) {
@@ -215,6 +438,8 @@ func (etherMan *Client) EstimateGas(
 	value *big.Int,
 	data []byte,
 ) (uint64, error) {
+	ctx, cancel := etherMan.withRPCTimeout(ctx)
+	defer cancel()
 	return etherMan.EthClient.EstimateGas(ctx, ethereum.CallMsg{
 		From:  from,
 		To:    to,
@@ -223,6 +448,51 @@ func (etherMan *Client) EstimateGas(
 	})
 }
 
+// CallContract executes a message call (eth_call) with the provided parameters against the latest state
+// without creating a tx, returning the output of the call. Use RevertReasonFromError to check if the
+// returned error means the execution was reverted
+func (etherMan *Client) CallContract(
+	ctx context.Context,
+	from common.Address,
+	to *common.Address,
+	value *big.Int,
+	data []byte,
+) ([]byte, error) {
+	ctx, cancel := etherMan.withRPCTimeout(ctx)
+	defer cancel()
+	return etherMan.EthClient.CallContract(ctx, ethereum.CallMsg{
+		From:  from,
+		To:    to,
+		Value: value,
+		Data:  data,
+	}, nil)
+}
+
+// RevertReasonFromError checks if the error returned by a call means its execution was reverted,
+// returning the decoded revert reason, which is empty if the node didn't reveal it
+func RevertReasonFromError(err error) (string, bool) {
+	if err == nil {
+		return "", false
+	}
+
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if revertData, decodeErr := hexutil.Decode(data); decodeErr == nil {
+				if reason, unpackErr := abi.UnpackRevert(revertData); unpackErr == nil {
+					return reason, true
+				}
+			}
+		}
+	}
+
+	if strings.Contains(err.Error(), errMsgExecutionReverted) {
+		return "", true
+	}
+
+	return "", false
+}
+
 // EstimateGasBlobTx returns the estimated gas for the blob tx
 func (etherMan *Client) EstimateGasBlobTx(
 	ctx context.Context,
@@ -233,6 +503,8 @@ func (etherMan *Client) EstimateGasBlobTx(
 	value *big.Int,
 	data []byte,
 ) (uint64, error) {
+	ctx, cancel := etherMan.withRPCTimeout(ctx)
+	defer cancel()
 	return etherMan.EthClient.EstimateGas(ctx, ethereum.CallMsg{
 		From:      from,
 		To:        to,
@@ -243,8 +515,50 @@ func (etherMan *Client) EstimateGasBlobTx(
 	})
 }
 
+// EstimateGasWithStateOverrides returns the estimated gas for the tx executed over the latest state modified
+// by the provided overrides, e.g. to estimate a tx depending on the effects of a previous tx not mined yet.
+// ErrStateOverridesNotSupported is returned when the node rejects the overrides. The rejection only affects
+// that call, since an invalid params error can also be caused by the overrides of the call themselves
+func (etherMan *Client) EstimateGasWithStateOverrides(
+	ctx context.Context,
+	from common.Address,
+	to *common.Address,
+	value *big.Int,
+	data []byte,
+	overrides map[common.Address]gethclient.OverrideAccount,
+) (uint64, error) {
+	ctx, cancel := etherMan.withRPCTimeout(ctx)
+	defer cancel()
+
+	arg := map[string]interface{}{
+		"from": from,
+		"to":   to,
+	}
+	if len(data) > 0 {
+		arg["input"] = hexutil.Bytes(data)
+	}
+	if value != nil {
+		arg["value"] = (*hexutil.Big)(value)
+	}
+
+	var gas hexutil.Uint64
+	err := etherMan.EthClient.Client().CallContext(ctx, &gas, "eth_estimateGas", arg, "latest", overrides)
+	if err != nil {
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) &&
+			(rpcErr.ErrorCode() == rpcErrCodeMethodNotFound || rpcErr.ErrorCode() == rpcErrCodeInvalidParams) {
+			return 0, fmt.Errorf("%w: %w", ErrStateOverridesNotSupported, err)
+		}
+		return 0, err
+	}
+
+	return uint64(gas), nil
+}
+
 // CheckTxWasMined check if a tx was already mined
 func (etherMan *Client) CheckTxWasMined(ctx context.Context, txHash common.Hash) (bool, *types.Receipt, error) {
+	ctx, cancel := etherMan.withRPCTimeout(ctx)
+	defer cancel()
 	receipt, err := etherMan.EthClient.TransactionReceipt(ctx, txHash)
 	err = translateError(err)
 	if errors.Is(err, ethereum.NotFound) {
@@ -271,6 +585,9 @@ func (etherMan *Client) GetRevertMessage(ctx context.Context, tx *types.Transact
 		return "", nil
 	}
 
+	ctx, cancel := etherMan.withRPCTimeout(ctx)
+	defer cancel()
+
 	receipt, err := etherMan.GetTxReceipt(ctx, tx.Hash())
 	err = translateError(err)
 	if err != nil {
@@ -287,8 +604,20 @@ func (etherMan *Client) GetRevertMessage(ctx context.Context, tx *types.Transact
 	return "", nil
 }
 
+// withRPCTimeout bounds the network calls made with the returned context with the RPC call timeout,
+// the context is returned as it is if the timeout is not configured
+func (etherMan *Client) withRPCTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if etherMan.cfg.RPCCallTimeout.Duration <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, etherMan.cfg.RPCCallTimeout.Duration)
+}
+
 // getBlockNumber gets the block header by the provided block number from the ethereum
 func (etherMan *Client) getBlockNumber(ctx context.Context, blockNumber rpc.BlockNumber) (uint64, error) {
+	ctx, cancel := etherMan.withRPCTimeout(ctx)
+	defer cancel()
 	header, err := etherMan.EthClient.HeaderByNumber(ctx, big.NewInt(int64(blockNumber)))
 	if err != nil || header == nil {
 		return 0, err
@@ -299,19 +628,70 @@ func (etherMan *Client) getBlockNumber(ctx context.Context, blockNumber rpc.Bloc
 // GetHeaderByNumber returns a block header from the current canonical chain.
 // If number is nil the latest header is returned
 func (etherMan *Client) GetHeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	ctx, cancel := etherMan.withRPCTimeout(ctx)
+	defer cancel()
 	header, err := etherMan.EthClient.HeaderByNumber(ctx, number)
 	return header, err
 }
 
 // GetSuggestGasTipCap retrieves the currently suggested gas tip cap after EIP-1559 for timely transaction execution.
 func (etherMan *Client) GetSuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	ctx, cancel := etherMan.withRPCTimeout(ctx)
+	defer cancel()
 	gasTipCap, err := etherMan.EthClient.SuggestGasTipCap(ctx)
 	return gasTipCap, err
 }
 
+// SuggestTipCapFromHistory suggests a gas tip cap from the eth_feeHistory of the latest blocks: the median
+// over the blocks of the tip paid at the given percentile (0-100) of each block. The empty blocks are
+// skipped, as they don't report any tip, and the eth_maxPriorityFeePerGas suggestion is returned when
+// all the blocks are empty. The median is less sensitive than the single suggestion of the node to a
+// block with unusual tips, so the estimates are more stable under volatile conditions
+func (etherMan *Client) SuggestTipCapFromHistory(ctx context.Context, blocks uint64,
+	percentile float64) (*big.Int, error) {
+	if blocks == 0 {
+		return nil, errors.New("the number of blocks of the fee history must be greater than 0")
+	}
+	if percentile < 0 || percentile > 100 {
+		return nil, fmt.Errorf("invalid fee history percentile %v, it must be between 0 and 100", percentile)
+	}
+
+	ctx, cancel := etherMan.withRPCTimeout(ctx)
+	defer cancel()
+	history, err := etherMan.EthClient.FeeHistory(ctx, blocks, nil, []float64{percentile})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee history: %w", err)
+	}
+
+	tips := make([]*big.Int, 0, len(history.Reward))
+	for i, rewards := range history.Reward {
+		if len(rewards) == 0 || rewards[0] == nil {
+			continue
+		}
+		if i < len(history.GasUsedRatio) && history.GasUsedRatio[i] == 0 {
+			continue
+		}
+		tips = append(tips, rewards[0])
+	}
+	if len(tips) == 0 {
+		log.Debugf("fee history of the last %d blocks has no tips, using the suggested gas tip cap", blocks)
+		return etherMan.EthClient.SuggestGasTipCap(ctx)
+	}
+
+	sort.Slice(tips, func(i, j int) bool { return tips[i].Cmp(tips[j]) < 0 })
+	middle := len(tips) / 2 //nolint:mnd
+	if len(tips)%2 == 1 {
+		return new(big.Int).Set(tips[middle]), nil
+	}
+	median := new(big.Int).Add(tips[middle-1], tips[middle])
+	return median.Div(median, big.NewInt(2)), nil //nolint:mnd
+}
+
 // HeaderByNumber returns a block header from the current canonical chain. If number is
 // nil, the latest known header is returned.
 func (etherMan *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	ctx, cancel := etherMan.withRPCTimeout(ctx)
+	defer cancel()
 	return etherMan.EthClient.HeaderByNumber(ctx, number)
 }
 
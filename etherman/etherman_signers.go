@@ -2,7 +2,12 @@ package etherman
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/0xPolygon/zkevm-ethtx-manager/log"
 	"github.com/agglayer/go_signer/signer"
@@ -11,10 +16,34 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
+const (
+	// fieldPasswordFile is the key of the local signer config with the path of the file
+	// containing the password of the key store
+	fieldPasswordFile = "passwordFile"
+	// fieldPasswordEnv is the key of the local signer config with the environment variable
+	// containing the password of the key store
+	fieldPasswordEnv = "passwordEnv"
+)
+
+// ErrInvalidPasswordSource when a local signer doesn't provide exactly one source for the password of
+// the key store among password, passwordFile and passwordEnv
+var ErrInvalidPasswordSource = errors.New("exactly one of password, passwordFile or passwordEnv must be provided")
+
 // EthermanSigners is a struct that holds the signers
 type EthermanSigners struct {
 	chainID uint64
 	signers map[common.Address]signertypes.Signer
+	// signTimeout is the maximum time signing a tx can take, 0 means no timeout
+	signTimeout time.Duration
+	// timedOutSigns keeps by sender the channel closed once its last sign that timed out returns, so
+	// a signer ignoring the context cancellation doesn't pile up a goroutine for each sign
+	timedOutSigns sync.Map
+}
+
+// signResult is the outcome of a sign running in the background
+type signResult struct {
+	tx  *types.Transaction
+	err error
 }
 
 // NewEthermanSigners creates a new instance of EthermanSigners
@@ -26,7 +55,12 @@ func NewEthermanSigners(ctx context.Context, chainID uint64,
 		signers: make(map[common.Address]signertypes.Signer),
 	}
 	for i, signerConfig := range config {
-		signer, err := signer.NewSigner(ctx, chainID, signerConfig, fmt.Sprintf("signer-%d", i), logger)
+		// the resolved config holds the password, so it's never logged
+		resolvedConfig, err := resolveSignerPassword(signerConfig)
+		if err != nil {
+			return nil, fmt.Errorf("signer-%d: %w", i, err)
+		}
+		signer, err := signer.NewSigner(ctx, chainID, resolvedConfig, fmt.Sprintf("signer-%d", i), logger)
 		if err != nil {
 			return nil, err
 		}
@@ -44,6 +78,72 @@ func NewEthermanSigners(ctx context.Context, chainID uint64,
 	return &res, nil
 }
 
+// resolveSignerPassword returns a copy of the config of a local signer with the password of the key store
+// read from the file (passwordFile) or the environment variable (passwordEnv) configured instead of
+// the plain text password, so the secrets don't need to be in the config files. Exactly one password
+// source must be provided. The configs of the other methods are returned as they are
+func resolveSignerPassword(cfg signertypes.SignerConfig) (signertypes.SignerConfig, error) {
+	if cfg.Method != "" && cfg.Method != signertypes.MethodLocal {
+		return cfg, nil
+	}
+	if len(cfg.Config) == 0 {
+		return cfg, nil
+	}
+
+	// the keys loaded through viper are lower case
+	password, hasPassword := configValue(cfg, signer.FieldPassword)
+	passwordFile, hasPasswordFile := configValue(cfg, fieldPasswordFile)
+	passwordEnv, hasPasswordEnv := configValue(cfg, fieldPasswordEnv)
+
+	sources := 0
+	for _, found := range []bool{hasPassword, hasPasswordFile, hasPasswordEnv} {
+		if found {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return cfg, fmt.Errorf("%w: %d provided", ErrInvalidPasswordSource, sources)
+	}
+
+	switch {
+	case hasPasswordFile:
+		content, err := os.ReadFile(passwordFile)
+		if err != nil {
+			return cfg, fmt.Errorf("failed to read the key store password file: %w", err)
+		}
+		password = strings.TrimRight(string(content), "\r\n")
+	case hasPasswordEnv:
+		var found bool
+		password, found = os.LookupEnv(passwordEnv)
+		if !found {
+			return cfg, fmt.Errorf("key store password environment variable %s is not set", passwordEnv)
+		}
+	}
+
+	resolved := signertypes.SignerConfig{Method: cfg.Method, Config: make(map[string]interface{}, len(cfg.Config))}
+	for key, value := range cfg.Config {
+		switch strings.ToLower(key) {
+		case strings.ToLower(fieldPasswordFile), strings.ToLower(fieldPasswordEnv), signer.FieldPassword:
+		default:
+			resolved.Config[key] = value
+		}
+	}
+	resolved.Config[signer.FieldPassword] = password
+
+	return resolved, nil
+}
+
+// configValue returns the string value of the key in the signer config, matching it case insensitively
+func configValue(cfg signertypes.SignerConfig, key string) (string, bool) {
+	for k, v := range cfg.Config {
+		if strings.EqualFold(k, key) {
+			value, ok := v.(string)
+			return value, ok
+		}
+	}
+	return "", false
+}
+
 // PublicAddress returns the public addresses of the signers
 func (s *EthermanSigners) PublicAddress() ([]common.Address, error) {
 	if s == nil {
@@ -64,7 +164,44 @@ func (s *EthermanSigners) SignTx(ctx context.Context, sender common.Address,
 	if err != nil {
 		return nil, err
 	}
-	return signer.SignTx(ctx, tx)
+	if s.signTimeout <= 0 {
+		return signer.SignTx(ctx, tx)
+	}
+
+	// a signer ignoring the context cancellation is still blocked by the sign that timed out,
+	// so no new sign is started until it returns
+	if value, found := s.timedOutSigns.Load(sender); found {
+		select {
+		case <-value.(chan struct{}): //nolint:forcetypeassert
+			s.timedOutSigns.CompareAndDelete(sender, value)
+		default:
+			return nil, fmt.Errorf("%w: signer of %s didn't return the previous sign yet", ErrSignTimeout, sender.Hex())
+		}
+	}
+
+	// the sign runs in the background so a signer that ignores the context cancellation,
+	// e.g. waiting for a hardware device, can't block the caller beyond the timeout. The result
+	// channel is buffered, so the goroutine ends once the signer returns even if nobody waits for it
+	ctx, cancel := context.WithTimeout(ctx, s.signTimeout)
+	defer cancel()
+	resultCh := make(chan signResult, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		signedTx, err := signer.SignTx(ctx, tx)
+		resultCh <- signResult{tx: signedTx, err: err}
+	}()
+
+	select {
+	case result := <-resultCh:
+		return result.tx, result.err
+	case <-ctx.Done():
+		s.timedOutSigns.Store(sender, done)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w: signer of %s didn't sign within %v", ErrSignTimeout, sender.Hex(), s.signTimeout)
+		}
+		return nil, ctx.Err()
+	}
 }
 
 // getAuthByAddress tries to get an authorization from the authorizations map
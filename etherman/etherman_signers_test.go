@@ -2,7 +2,11 @@ package etherman
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path"
 	"testing"
+	"time"
 
 	"github.com/0xPolygon/zkevm-ethtx-manager/mocks"
 	signertypes "github.com/agglayer/go_signer/signer/types"
@@ -81,6 +85,75 @@ func TestEthermanSignersSignTx(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestEthermanSignersSignTxTimeout(t *testing.T) {
+	senderAddr := common.HexToAddress("0x1")
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1})
+	newSut := func(signer signertypes.Signer) *EthermanSigners {
+		return &EthermanSigners{
+			chainID:     1,
+			signers:     map[common.Address]signertypes.Signer{senderAddr: signer},
+			signTimeout: 50 * time.Millisecond,
+		}
+	}
+
+	t.Run("signed within the timeout", func(t *testing.T) {
+		mockSigner := mocks.NewSigner(t)
+		mockSigner.EXPECT().SignTx(mock.Anything, tx).Return(tx, nil).Once()
+		signedTx, err := newSut(mockSigner).SignTx(context.TODO(), senderAddr, tx)
+		require.NoError(t, err)
+		require.Equal(t, tx, signedTx)
+	})
+
+	t.Run("hardware signer error", func(t *testing.T) {
+		signErr := errors.New("device disconnected")
+		mockSigner := mocks.NewSigner(t)
+		mockSigner.EXPECT().SignTx(mock.Anything, tx).Return(nil, signErr).Once()
+		_, err := newSut(mockSigner).SignTx(context.TODO(), senderAddr, tx)
+		require.ErrorIs(t, err, signErr)
+	})
+
+	t.Run("slow hardware signer ignoring the context", func(t *testing.T) {
+		release := make(chan struct{})
+		mockSigner := mocks.NewSigner(t)
+		mockSigner.EXPECT().SignTx(mock.Anything, tx).Run(func(context.Context, *types.Transaction) {
+			<-release
+		}).Return(tx, nil).Once()
+		sut := newSut(mockSigner)
+
+		start := time.Now()
+		_, err := sut.SignTx(context.TODO(), senderAddr, tx)
+		require.ErrorIs(t, err, ErrSignTimeout)
+		require.Less(t, time.Since(start), time.Second)
+
+		// the device is not asked again while it's still blocked by the sign that timed out
+		_, err = sut.SignTx(context.TODO(), senderAddr, tx)
+		require.ErrorIs(t, err, ErrSignTimeout)
+		require.ErrorContains(t, err, "didn't return the previous sign yet")
+
+		// once the device returns, the signs go through again
+		close(release)
+		mockSigner.EXPECT().SignTx(mock.Anything, tx).Return(tx, nil).Once()
+		require.Eventually(t, func() bool {
+			_, err := sut.SignTx(context.TODO(), senderAddr, tx)
+			return err == nil
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("caller context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		mockSigner := mocks.NewSigner(t)
+		mockSigner.EXPECT().SignTx(mock.Anything, tx).RunAndReturn(
+			func(ctx context.Context, _ *types.Transaction) (*types.Transaction, error) {
+				cancel()
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}).Once()
+		_, err := newSut(mockSigner).SignTx(ctx, senderAddr, tx)
+		require.ErrorIs(t, err, context.Canceled)
+		require.NotErrorIs(t, err, ErrSignTimeout)
+	})
+}
+
 func TestEthermanSignersPublicAddress(t *testing.T) {
 	mockSigner := mocks.NewSigner(t)
 	senderAddr := common.HexToAddress("0x1")
@@ -101,3 +174,58 @@ func TestEthermanSignersPublicAddress(t *testing.T) {
 	require.NoError(t, err)
 	require.Nil(t, addresses)
 }
+
+func TestNewEthermanSignersPasswordSources(t *testing.T) {
+	ctx := context.TODO()
+	chainID := uint64(1)
+	newConfig := func(fields map[string]interface{}) []signertypes.SignerConfig {
+		fields["path"] = fileKeystorePath
+		return []signertypes.SignerConfig{{Method: "local", Config: fields}}
+	}
+
+	passwordFile := path.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte(fileKeystorePassword+"\n"), 0600))
+
+	t.Run("password file", func(t *testing.T) {
+		signers, err := NewEthermanSigners(ctx, chainID, newConfig(map[string]interface{}{"passwordFile": passwordFile}))
+		require.NoError(t, err)
+		addresses, err := signers.PublicAddress()
+		require.NoError(t, err)
+		require.Len(t, addresses, 1)
+	})
+
+	t.Run("password file with lower case key", func(t *testing.T) {
+		_, err := NewEthermanSigners(ctx, chainID, newConfig(map[string]interface{}{"passwordfile": passwordFile}))
+		require.NoError(t, err)
+	})
+
+	t.Run("password file not found", func(t *testing.T) {
+		_, err := NewEthermanSigners(ctx, chainID,
+			newConfig(map[string]interface{}{"passwordFile": path.Join(t.TempDir(), "missing")}))
+		require.Error(t, err)
+	})
+
+	t.Run("password env", func(t *testing.T) {
+		t.Setenv("TEST_KEYSTORE_PASSWORD", fileKeystorePassword)
+		_, err := NewEthermanSigners(ctx, chainID, newConfig(map[string]interface{}{"passwordEnv": "TEST_KEYSTORE_PASSWORD"}))
+		require.NoError(t, err)
+	})
+
+	t.Run("password env not set", func(t *testing.T) {
+		_, err := NewEthermanSigners(ctx, chainID, newConfig(map[string]interface{}{"passwordEnv": "TEST_KEYSTORE_UNSET"}))
+		require.ErrorContains(t, err, "TEST_KEYSTORE_UNSET")
+	})
+
+	t.Run("several password sources", func(t *testing.T) {
+		_, err := NewEthermanSigners(ctx, chainID, newConfig(map[string]interface{}{
+			"password":     fileKeystorePassword,
+			"passwordFile": passwordFile,
+		}))
+		require.ErrorIs(t, err, ErrInvalidPasswordSource)
+	})
+
+	t.Run("no password source", func(t *testing.T) {
+		_, err := NewEthermanSigners(ctx, chainID, newConfig(map[string]interface{}{}))
+		require.ErrorIs(t, err, ErrInvalidPasswordSource)
+	})
+}
@@ -2,18 +2,27 @@ package etherman
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/zkevm-ethtx-manager/config/types"
 	"github.com/0xPolygon/zkevm-ethtx-manager/mocks"
 	signertypes "github.com/agglayer/go_signer/signer/types"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -140,9 +149,149 @@ func TestCheckTxWasMined(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRPCCallTimeout(t *testing.T) {
+	mockEth := mocks.NewEthereumClient(t)
+	sut := Client{
+		EthClient: mockEth,
+		cfg:       Config{RPCCallTimeout: types.NewDuration(50 * time.Millisecond)},
+	}
+
+	// the node hangs until the call is cancelled
+	mockEth.EXPECT().SendTransaction(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, _ *ethTypes.Transaction) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(5 * time.Second):
+				return nil
+			}
+		}).Once()
+
+	start := time.Now()
+	err := sut.SendTx(context.Background(), ethTypes.NewTx(&ethTypes.LegacyTx{}))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	mockEth.EXPECT().ChainID(mock.Anything).
+		RunAndReturn(func(ctx context.Context) (*big.Int, error) {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(5 * time.Second):
+				return big.NewInt(1), nil
+			}
+		}).Once()
+
+	start = time.Now()
+	_, err = sut.GetChainID(context.Background())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestSendTxIdempotent(t *testing.T) {
+	mockEth := mocks.NewEthereumClient(t)
+	sut := Client{
+		EthClient: mockEth,
+	}
+	tx := ethTypes.NewTx(&ethTypes.LegacyTx{Nonce: 1})
+
+	// the tx is already in the mempool
+	mockEth.EXPECT().SendTransaction(mock.Anything, tx).Return(errors.New("already known")).Once()
+	require.NoError(t, sut.SendTxIdempotent(context.TODO(), tx))
+
+	// the tx was already mined
+	mockEth.EXPECT().SendTransaction(mock.Anything, tx).Return(errors.New("nonce too low: next nonce 2, tx nonce 1")).Once()
+	mockEth.EXPECT().TransactionReceipt(mock.Anything, tx.Hash()).Return(&ethTypes.Receipt{}, nil).Once()
+	require.NoError(t, sut.SendTxIdempotent(context.TODO(), tx))
+
+	// the nonce was consumed by another tx
+	mockEth.EXPECT().SendTransaction(mock.Anything, tx).Return(errors.New("nonce too low: next nonce 2, tx nonce 1")).Once()
+	mockEth.EXPECT().TransactionReceipt(mock.Anything, tx.Hash()).Return(nil, errGenericNotFound).Once()
+	require.ErrorContains(t, sut.SendTxIdempotent(context.TODO(), tx), "nonce too low")
+
+	// any other error is returned
+	mockEth.EXPECT().SendTransaction(mock.Anything, tx).Return(errors.New("insufficient funds")).Once()
+	require.ErrorContains(t, sut.SendTxIdempotent(context.TODO(), tx), "insufficient funds")
+}
+
+func TestEstimateGasWithStateOverrides(t *testing.T) {
+	from := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+	overrides := map[common.Address]gethclient.OverrideAccount{
+		to: {Balance: big.NewInt(1000), StateDiff: map[common.Hash]common.Hash{{0x1}: {0x2}}},
+	}
+
+	newSut := func(t *testing.T, handler func(params []json.RawMessage) string) (*Client, *int) {
+		t.Helper()
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			var req struct {
+				ID     json.RawMessage   `json:"id"`
+				Method string            `json:"method"`
+				Params []json.RawMessage `json:"params"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "eth_estimateGas", req.Method)
+			_, err := fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,%s}`, req.ID, handler(req.Params))
+			require.NoError(t, err)
+		}))
+		t.Cleanup(server.Close)
+
+		rpcClient, err := rpc.DialHTTP(server.URL)
+		require.NoError(t, err)
+		t.Cleanup(rpcClient.Close)
+
+		mockEth := mocks.NewEthereumClient(t)
+		mockEth.EXPECT().Client().Return(rpcClient).Maybe()
+		return &Client{EthClient: mockEth}, &calls
+	}
+
+	t.Run("overrides sent to the node", func(t *testing.T) {
+		sut, calls := newSut(t, func(params []json.RawMessage) string {
+			require.Len(t, params, 3)
+			require.JSONEq(t, `"latest"`, string(params[1]))
+			var sent map[common.Address]map[string]interface{}
+			require.NoError(t, json.Unmarshal(params[2], &sent))
+			require.Equal(t, "0x3e8", sent[to]["balance"])
+			require.Contains(t, sent[to], "stateDiff")
+			return `"result":"0x5208"`
+		})
+
+		gas, err := sut.EstimateGasWithStateOverrides(context.Background(), from, &to, big.NewInt(1), []byte{0x1}, overrides)
+		require.NoError(t, err)
+		require.Equal(t, uint64(21000), gas)
+		require.Equal(t, 1, *calls)
+	})
+
+	t.Run("overrides not supported", func(t *testing.T) {
+		sut, calls := newSut(t, func(_ []json.RawMessage) string {
+			return `"error":{"code":-32602,"message":"too many arguments, want at most 2"}`
+		})
+
+		_, err := sut.EstimateGasWithStateOverrides(context.Background(), from, &to, big.NewInt(1), nil, overrides)
+		require.ErrorIs(t, err, ErrStateOverridesNotSupported)
+
+		// the rejection doesn't disable the overrides of the next calls
+		_, err = sut.EstimateGasWithStateOverrides(context.Background(), from, &to, big.NewInt(1), nil, overrides)
+		require.ErrorIs(t, err, ErrStateOverridesNotSupported)
+		require.Equal(t, 2, *calls)
+	})
+
+	t.Run("execution reverted", func(t *testing.T) {
+		sut, _ := newSut(t, func(_ []json.RawMessage) string {
+			return `"error":{"code":3,"message":"execution reverted"}`
+		})
+
+		_, err := sut.EstimateGasWithStateOverrides(context.Background(), from, &to, big.NewInt(1), nil, overrides)
+		require.ErrorContains(t, err, "execution reverted")
+		require.NotErrorIs(t, err, ErrStateOverridesNotSupported)
+	})
+}
+
 func TestNewClient(t *testing.T) {
 	mockEth := mocks.NewEthereumClient(t)
-	ethclientFactoryFunc = func(url string) (EthereumClient, error) {
+	ethclientFactoryFunc = func(url string, _ ...rpc.ClientOption) (EthereumClient, error) {
 		return mockEth, nil
 	}
 	mockEth.EXPECT().ChainID(mock.Anything).Return(big.NewInt(1), nil)
@@ -153,9 +302,102 @@ func TestNewClient(t *testing.T) {
 	require.NotNil(t, sut)
 }
 
+func TestNewClientHTTPTimeout(t *testing.T) {
+	ethclientFactoryFunc = dialEthClient
+	// the node never answers before the client timeout
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	sut, err := NewClient(Config{
+		URL:         server.URL,
+		L1ChainID:   1,
+		HTTPTimeout: types.NewDuration(100 * time.Millisecond),
+	}, nil)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = sut.GetLatestBlockNumber(context.Background())
+	require.Error(t, err)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestNewClientRPCRateLimit(t *testing.T) {
+	ethclientFactoryFunc = dialEthClient
+	header, err := json.Marshal(&ethTypes.Header{Number: big.NewInt(16), Difficulty: big.NewInt(0)})
+	require.NoError(t, err)
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		_, err := fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%s}`, req.ID, header)
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	sut, err := NewClient(Config{URL: server.URL, L1ChainID: 1, RPCRateLimit: 1}, nil)
+	require.NoError(t, err)
+
+	// the burst of one second worth of requests is sent at once
+	blockNumber, err := sut.GetLatestBlockNumber(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(16), blockNumber)
+	require.Equal(t, int32(1), requests.Load())
+
+	// the next request has to wait for the rate limit, longer than its deadline, so it's not sent
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = sut.GetLatestBlockNumber(ctx)
+	require.Error(t, err)
+	require.Equal(t, int32(1), requests.Load())
+
+	t.Run("burst of at least one request", func(t *testing.T) {
+		limiter := newRateLimiter(0.5)
+		require.Equal(t, 1, limiter.Burst())
+		require.True(t, limiter.Allow())
+		require.False(t, limiter.Allow())
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewClientCustomHTTPClient(t *testing.T) {
+	ethclientFactoryFunc = dialEthClient
+	errTransport := errors.New("custom transport")
+	requests := 0
+	httpClient := &http.Client{
+		Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			requests++
+			return nil, errTransport
+		}),
+	}
+
+	sut, err := NewClient(Config{
+		URL:        "http://localhost:8545",
+		L1ChainID:  1,
+		HTTPClient: httpClient,
+	}, nil)
+	require.NoError(t, err)
+
+	_, err = sut.GetLatestBlockNumber(context.Background())
+	require.ErrorIs(t, err, errTransport)
+	require.Equal(t, 1, requests)
+}
+
 func TestNewClientDefaultConfig(t *testing.T) {
 	mockEth := mocks.NewEthereumClient(t)
-	ethclientFactoryFunc = func(url string) (EthereumClient, error) {
+	ethclientFactoryFunc = func(url string, _ ...rpc.ClientOption) (EthereumClient, error) {
 		return mockEth, nil
 	}
 	mockEth.EXPECT().ChainID(mock.Anything).Return(big.NewInt(1), nil)
@@ -243,3 +485,219 @@ func TestGetL1GasPrice(t *testing.T) {
 		})
 	}
 }
+
+func TestGetL1GasPriceRequireAllGasProviders(t *testing.T) {
+	ctx := context.Background()
+	providerErr := errors.New("provider down")
+
+	tests := []struct {
+		name                   string
+		requireAllGasProviders bool
+		expectedPrice          *big.Int
+		expectedError          error
+	}{
+		{
+			name:                   "lenient - the failing provider is ignored",
+			requireAllGasProviders: false,
+			expectedPrice:          big.NewInt(100),
+		},
+		{
+			name:                   "strict - the failing provider fails the calculation",
+			requireAllGasProviders: true,
+			expectedError:          ErrGasPriceProviderFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failingProvider := mocks.NewEthereumClient(t)
+			failingProvider.EXPECT().SuggestGasPrice(mock.Anything).Return(nil, providerErr).Once()
+			workingProvider := mocks.NewEthereumClient(t)
+			if !tt.requireAllGasProviders {
+				workingProvider.EXPECT().SuggestGasPrice(mock.Anything).Return(big.NewInt(100), nil).Once()
+			}
+
+			client := &Client{
+				cfg: Config{RequireAllGasProviders: tt.requireAllGasProviders},
+				GasProviders: externalGasProviders{
+					Providers: []ethereum.GasPricer{failingProvider, workingProvider},
+				},
+			}
+
+			price, err := client.GetL1GasPrice(ctx)
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				require.ErrorIs(t, err, providerErr)
+				require.Nil(t, price)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedPrice, price)
+			}
+			require.Equal(t, uint64(1), client.GasProviderFailures())
+		})
+	}
+}
+
+func TestGetL1GasPriceCacheTTL(t *testing.T) {
+	ctx := context.Background()
+	// mockery fails the test if the providers are called more times than expected
+	etherscanProvider := mocks.NewEthereumClient(t)
+	etherscanProvider.EXPECT().SuggestGasPrice(mock.Anything).Return(big.NewInt(100), nil).Once()
+	nodeProvider := mocks.NewEthereumClient(t)
+	nodeProvider.EXPECT().SuggestGasPrice(mock.Anything).Return(big.NewInt(90), nil).Once()
+
+	client := &Client{
+		cfg: Config{MultiGasProvider: true, GasPriceCacheTTL: types.NewDuration(100 * time.Millisecond)},
+		GasProviders: externalGasProviders{
+			MultiGasProvider: true,
+			Providers:        []ethereum.GasPricer{etherscanProvider, nodeProvider},
+		},
+	}
+
+	for i := 0; i < 3; i++ {
+		price, err := client.GetL1GasPrice(ctx)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(100), price)
+		// the cached price can't be changed by the callers
+		price.SetInt64(1)
+	}
+
+	// once expired, the providers are requested again
+	time.Sleep(150 * time.Millisecond)
+	etherscanProvider.EXPECT().SuggestGasPrice(mock.Anything).Return(big.NewInt(120), nil).Once()
+	nodeProvider.EXPECT().SuggestGasPrice(mock.Anything).Return(big.NewInt(90), nil).Once()
+	price, err := client.GetL1GasPrice(ctx)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(120), price)
+}
+
+func TestCallContract(t *testing.T) {
+	from := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+	mockEth := mocks.NewEthereumClient(t)
+	sut := &Client{EthClient: mockEth}
+
+	msg := ethereum.CallMsg{From: from, To: &to, Value: big.NewInt(1), Data: []byte{0x1}}
+	mockEth.EXPECT().CallContract(mock.Anything, msg, (*big.Int)(nil)).Return([]byte{0x2}, nil).Once()
+
+	output, err := sut.CallContract(context.Background(), from, &to, big.NewInt(1), []byte{0x1})
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x2}, output)
+}
+
+func TestSuggestTipCapFromHistory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("median of the non empty blocks", func(t *testing.T) {
+		mockEth := mocks.NewEthereumClient(t)
+		sut := &Client{EthClient: mockEth}
+		mockEth.EXPECT().FeeHistory(mock.Anything, uint64(5), (*big.Int)(nil), []float64{60}).Return(&ethereum.FeeHistory{
+			OldestBlock: big.NewInt(100),
+			Reward: [][]*big.Int{
+				{big.NewInt(3)}, {big.NewInt(0)}, {big.NewInt(1000)}, {big.NewInt(2)}, {big.NewInt(5)},
+			},
+			GasUsedRatio: []float64{0.5, 0, 0.9, 0.3, 0.6},
+		}, nil).Once()
+
+		tip, err := sut.SuggestTipCapFromHistory(ctx, 5, 60)
+		require.NoError(t, err)
+		// the empty block is skipped and the spike doesn't move the median of 2, 3, 5 and 1000
+		require.Equal(t, big.NewInt(4), tip)
+	})
+
+	t.Run("odd number of blocks", func(t *testing.T) {
+		mockEth := mocks.NewEthereumClient(t)
+		sut := &Client{EthClient: mockEth}
+		mockEth.EXPECT().FeeHistory(mock.Anything, uint64(3), (*big.Int)(nil), []float64{50}).Return(&ethereum.FeeHistory{
+			Reward:       [][]*big.Int{{big.NewInt(7)}, {big.NewInt(1)}, {big.NewInt(9)}},
+			GasUsedRatio: []float64{0.5, 0.5, 0.5},
+		}, nil).Once()
+
+		tip, err := sut.SuggestTipCapFromHistory(ctx, 3, 50)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(7), tip)
+	})
+
+	t.Run("all blocks empty", func(t *testing.T) {
+		mockEth := mocks.NewEthereumClient(t)
+		sut := &Client{EthClient: mockEth}
+		mockEth.EXPECT().FeeHistory(mock.Anything, uint64(2), (*big.Int)(nil), []float64{50}).Return(&ethereum.FeeHistory{
+			Reward:       [][]*big.Int{{big.NewInt(0)}, {big.NewInt(0)}},
+			GasUsedRatio: []float64{0, 0},
+		}, nil).Once()
+		mockEth.EXPECT().SuggestGasTipCap(mock.Anything).Return(big.NewInt(11), nil).Once()
+
+		tip, err := sut.SuggestTipCapFromHistory(ctx, 2, 50)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(11), tip)
+	})
+
+	t.Run("fee history failure", func(t *testing.T) {
+		mockEth := mocks.NewEthereumClient(t)
+		sut := &Client{EthClient: mockEth}
+		mockEth.EXPECT().FeeHistory(mock.Anything, uint64(2), (*big.Int)(nil), []float64{50}).
+			Return(nil, errors.New("method not found")).Once()
+
+		_, err := sut.SuggestTipCapFromHistory(ctx, 2, 50)
+		require.ErrorContains(t, err, "method not found")
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		sut := &Client{EthClient: mocks.NewEthereumClient(t)}
+		_, err := sut.SuggestTipCapFromHistory(ctx, 0, 50)
+		require.Error(t, err)
+		_, err = sut.SuggestTipCapFromHistory(ctx, 2, 101)
+		require.Error(t, err)
+	})
+}
+
+func TestRevertReasonFromError(t *testing.T) {
+	// Error("boom") ABI encoded
+	revertData := "0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000004" +
+		"626f6f6d00000000000000000000000000000000000000000000000000000000"
+
+	callErr := func(t *testing.T, rpcErr string) error {
+		t.Helper()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				ID json.RawMessage `json:"id"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			_, err := fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":%s}`, req.ID, rpcErr)
+			require.NoError(t, err)
+		}))
+		t.Cleanup(server.Close)
+
+		rpcClient, err := rpc.DialHTTP(server.URL)
+		require.NoError(t, err)
+		t.Cleanup(rpcClient.Close)
+
+		var result hexutil.Bytes
+		return rpcClient.CallContext(context.Background(), &result, "eth_call")
+	}
+
+	t.Run("reverted with reason", func(t *testing.T) {
+		err := callErr(t, fmt.Sprintf(`{"code":3,"message":"execution reverted: boom","data":"%s"}`, revertData))
+		reason, reverted := RevertReasonFromError(err)
+		require.True(t, reverted)
+		require.Equal(t, "boom", reason)
+	})
+
+	t.Run("reverted without reason", func(t *testing.T) {
+		err := callErr(t, `{"code":-32000,"message":"execution reverted"}`)
+		reason, reverted := RevertReasonFromError(err)
+		require.True(t, reverted)
+		require.Empty(t, reason)
+	})
+
+	t.Run("not reverted", func(t *testing.T) {
+		err := callErr(t, `{"code":-32000,"message":"header not found"}`)
+		_, reverted := RevertReasonFromError(err)
+		require.False(t, reverted)
+
+		_, reverted = RevertReasonFromError(nil)
+		require.False(t, reverted)
+	})
+}
@@ -0,0 +1,33 @@
+package etherman
+
+import (
+	"math/big"
+	"sync"
+	"time"
+)
+
+// gasPriceCache keeps the last L1 gas price got from the gas providers until it expires, so the
+// successive calls reuse it instead of requesting it again. It's safe for concurrent use
+type gasPriceCache struct {
+	mu        sync.Mutex
+	gasPrice  *big.Int
+	expiresAt time.Time
+}
+
+// get returns a copy of the cached gas price, or nil if there is none or it expired
+func (c *gasPriceCache) get() *big.Int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.gasPrice == nil || !time.Now().Before(c.expiresAt) {
+		return nil
+	}
+	return new(big.Int).Set(c.gasPrice)
+}
+
+// set caches a copy of the gas price for the ttl
+func (c *gasPriceCache) set(gasPrice *big.Int, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gasPrice = new(big.Int).Set(gasPrice)
+	c.expiresAt = time.Now().Add(ttl)
+}
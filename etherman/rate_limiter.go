@@ -0,0 +1,31 @@
+package etherman
+
+import (
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// newRateLimiter creates a rate limiter allowing limit requests per second, with bursts of up to
+// one second worth of requests
+func newRateLimiter(limit float64) *rate.Limiter {
+	burst := int(limit)
+	if burst < 1 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(limit), burst)
+}
+
+// rateLimitedTransport sends the HTTP requests through the next transport once the rate limiter allows them
+type rateLimitedTransport struct {
+	limiter *rate.Limiter
+	next    http.RoundTripper
+}
+
+// RoundTrip waits for the rate limiter and sends the request
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
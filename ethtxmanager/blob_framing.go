@@ -0,0 +1,84 @@
+package ethtxmanager
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// blobFramingVersion is the version of the framing written by EncodeFramedBlobData
+	blobFramingVersion = 1
+
+	// blobFramingHeaderLen is the length of the framing header: magic (2 bytes) + version (1 byte) +
+	// big endian length of the payload (4 bytes)
+	blobFramingHeaderLen = len(blobFramingMagic) + 1 + 4
+
+	// blobDataCapacity is the amount of bytes that fit into a blob, as the first byte of each
+	// field element is left as zero to keep it below the BLS modulus
+	blobDataCapacity = params.BlobTxFieldElementsPerBlob * (params.BlobTxBytesPerFieldElement - 1)
+
+	// MaxFramedBlobDataLen is the maximum length of the data that can be encoded with EncodeFramedBlobData
+	MaxFramedBlobDataLen = blobDataCapacity - blobFramingHeaderLen
+)
+
+// blobFramingMagic identifies the blobs encoded with EncodeFramedBlobData
+var blobFramingMagic = [2]byte{0xb1, 0x0b}
+
+var (
+	// ErrBlobDataNotFramed when the blob wasn't encoded with EncodeFramedBlobData
+	ErrBlobDataNotFramed = errors.New("blob data is not framed")
+	// ErrUnsupportedBlobFramingVersion when the framing version of the blob is not known
+	ErrUnsupportedBlobFramingVersion = errors.New("unsupported blob framing version")
+	// ErrInvalidBlobFraming when the framing of the blob is corrupted
+	ErrInvalidBlobFraming = errors.New("invalid blob framing")
+)
+
+// EncodeFramedBlobData encodes data into blob data type, prefixing it with a versioned
+// header that contains its length, so it can be recovered exactly with DecodeBlobData
+func (c *Client) EncodeFramedBlobData(data []byte) (kzg4844.Blob, error) {
+	if len(data) > MaxFramedBlobDataLen {
+		return kzg4844.Blob{}, fmt.Errorf("blob data longer than allowed (length: %d, limit: %d)",
+			len(data), MaxFramedBlobDataLen)
+	}
+
+	framed := make([]byte, blobFramingHeaderLen, blobFramingHeaderLen+len(data))
+	copy(framed, blobFramingMagic[:])
+	framed[len(blobFramingMagic)] = blobFramingVersion
+	binary.BigEndian.PutUint32(framed[len(blobFramingMagic)+1:], uint32(len(data))) //nolint:gosec
+	framed = append(framed, data...)
+
+	return c.EncodeBlobData(framed)
+}
+
+// DecodeBlobData decodes the data of a blob encoded with EncodeFramedBlobData,
+// returning exactly the bytes that were encoded
+func (c *Client) DecodeBlobData(blob kzg4844.Blob) ([]byte, error) {
+	elemSize := params.BlobTxBytesPerFieldElement
+
+	payload := make([]byte, 0, blobDataCapacity)
+	for i := 0; i < params.BlobTxFieldElementsPerBlob; i++ {
+		elem := blob[i*elemSize : (i+1)*elemSize]
+		if elem[0] != 0 {
+			return nil, fmt.Errorf("%w: field element %d doesn't start with a zero byte", ErrInvalidBlobFraming, i)
+		}
+		payload = append(payload, elem[1:]...)
+	}
+
+	if [2]byte(payload[:len(blobFramingMagic)]) != blobFramingMagic {
+		return nil, ErrBlobDataNotFramed
+	}
+	if version := payload[len(blobFramingMagic)]; version != blobFramingVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedBlobFramingVersion, version)
+	}
+
+	dataLen := binary.BigEndian.Uint32(payload[len(blobFramingMagic)+1 : blobFramingHeaderLen])
+	if int(dataLen) > MaxFramedBlobDataLen {
+		return nil, fmt.Errorf("%w: length %d exceeds the limit %d", ErrInvalidBlobFraming, dataLen, MaxFramedBlobDataLen)
+	}
+
+	return payload[blobFramingHeaderLen : blobFramingHeaderLen+int(dataLen)], nil
+}
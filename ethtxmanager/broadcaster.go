@@ -0,0 +1,27 @@
+package ethtxmanager
+
+import (
+	"context"
+
+	"github.com/0xPolygon/zkevm-ethtx-manager/types"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+var _ types.TxBroadcaster = (*PublicMempoolBroadcaster)(nil)
+
+// PublicMempoolBroadcaster delivers the signed transactions to the public mempool
+// through the eth_sendRawTransaction endpoint of the Ethereum node
+type PublicMempoolBroadcaster struct {
+	etherman types.EthermanInterface
+}
+
+// NewPublicMempoolBroadcaster creates a broadcaster sending the transactions through the provided etherman
+func NewPublicMempoolBroadcaster(etherman types.EthermanInterface) *PublicMempoolBroadcaster {
+	return &PublicMempoolBroadcaster{etherman: etherman}
+}
+
+// Broadcast sends the signed transaction to the public mempool, the transaction
+// being already known by the node or already mined is not considered an error
+func (b *PublicMempoolBroadcaster) Broadcast(ctx context.Context, tx *ethTypes.Transaction) error {
+	return b.etherman.SendTxIdempotent(ctx, tx)
+}
@@ -0,0 +1,113 @@
+package ethtxmanager
+
+import (
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/zkevm-ethtx-manager/log"
+)
+
+// defaultCircuitBreakerCooldown is the time the circuit breaker stays open when
+// CircuitBreakerCooldown is not configured
+const defaultCircuitBreakerCooldown = 5 * time.Minute
+
+type circuitBreakerState int
+
+const (
+	// circuitBreakerClosed lets the monitoring cycles broadcast the txs
+	circuitBreakerClosed circuitBreakerState = iota
+	// circuitBreakerOpen stops the monitoring cycles broadcasting the txs until the cooldown expires
+	circuitBreakerOpen
+	// circuitBreakerHalfOpen lets a monitoring cycle broadcast the txs to probe the network
+	circuitBreakerHalfOpen
+)
+
+// circuitBreaker stops broadcasting the txs after a number of consecutive monitoring cycles in
+// which every broadcast failed, so a permanent failure (wrong chain, banned key, node down) doesn't
+// flood the node and the logs. Once the cooldown expires a single cycle probes the network,
+// closing the breaker if any broadcast succeeds or opening it again otherwise
+type circuitBreaker struct {
+	mu sync.Mutex
+
+	state               circuitBreakerState
+	openedAt            time.Time
+	consecutiveFailures uint64
+
+	// broadcasts and failedBroadcasts of the current cycle
+	broadcasts       uint64
+	failedBroadcasts uint64
+
+	// now returns the current time, it's replaced by the tests
+	now func() time.Time
+}
+
+func (b *circuitBreaker) currentTime() time.Time {
+	if b.now != nil {
+		return b.now()
+	}
+	return time.Now()
+}
+
+// recordBroadcast records the result of a broadcast in the current cycle
+func (b *circuitBreaker) recordBroadcast(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.broadcasts++
+	if err != nil {
+		b.failedBroadcasts++
+	}
+}
+
+// allow returns false while the breaker is open and the cooldown didn't expire,
+// moving it to half-open once the cooldown expires
+func (b *circuitBreaker) allow(cooldown time.Duration) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state != circuitBreakerOpen {
+		return true
+	}
+	if b.currentTime().Sub(b.openedAt) < cooldown {
+		return false
+	}
+	log.Warnf("circuit breaker half-open, probing the network after a cooldown of %v", cooldown)
+	b.state = circuitBreakerHalfOpen
+	return true
+}
+
+// endCycle evaluates the broadcasts of the finished cycle, opening the breaker when the cycle
+// was the threshold-th consecutive one with all the broadcasts failed
+func (b *circuitBreaker) endCycle(threshold uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	broadcasts, failedBroadcasts := b.broadcasts, b.failedBroadcasts
+	b.broadcasts, b.failedBroadcasts = 0, 0
+
+	// the cycles without broadcasts say nothing about the network
+	if broadcasts == 0 {
+		return
+	}
+
+	if failedBroadcasts < broadcasts {
+		if b.state != circuitBreakerClosed {
+			log.Infof("circuit breaker closed, the network accepted the txs again")
+		}
+		b.state = circuitBreakerClosed
+		b.consecutiveFailures = 0
+		return
+	}
+
+	b.consecutiveFailures++
+	if b.state == circuitBreakerHalfOpen || b.consecutiveFailures >= threshold {
+		log.Errorf("CIRCUIT BREAKER OPEN: all the %d txs broadcast failed in %d consecutive monitoring cycles, "+
+			"the txs won't be broadcast until the cooldown expires", broadcasts, b.consecutiveFailures)
+		b.state = circuitBreakerOpen
+		b.openedAt = b.currentTime()
+	}
+}
+
+// isOpen returns true if the breaker is open
+func (b *circuitBreaker) isOpen() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state == circuitBreakerOpen
+}
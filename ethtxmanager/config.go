@@ -3,8 +3,59 @@ package ethtxmanager
 import (
 	"github.com/0xPolygon/zkevm-ethtx-manager/config/types"
 	"github.com/0xPolygon/zkevm-ethtx-manager/etherman"
+	"github.com/0xPolygon/zkevm-ethtx-manager/ethtxmanager/sqlstorage"
 	"github.com/0xPolygon/zkevm-ethtx-manager/log"
 	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StuckTxPolicy defines how a sent tx whose history shows repeated failed receipts is handled
+type StuckTxPolicy string
+
+const (
+	// StuckTxPolicyBump sends the tx again with the next nonce after reviewing its gas and gas price
+	StuckTxPolicyBump StuckTxPolicy = "bump"
+
+	// StuckTxPolicyFail stops sending the tx and sets it as failed. The tx is neither canceled nor
+	// resubmitted, it's up to the caller to add it again with a fresh payload
+	StuckTxPolicyFail StuckTxPolicy = "fail"
+)
+
+// OrphanedTxPolicy defines how the pending txs whose sender has no configured signer are handled on start
+type OrphanedTxPolicy string
+
+const (
+	// OrphanedTxPolicyKeep reports the orphaned txs and keeps them, they can't be sent until
+	// the signer of their sender is configured again
+	OrphanedTxPolicyKeep OrphanedTxPolicy = "keep"
+
+	// OrphanedTxPolicyEvict reports the orphaned txs and evicts them, so they are not retried every cycle
+	OrphanedTxPolicyEvict OrphanedTxPolicy = "evict"
+)
+
+// SenderSelection defines how the sender of a new monitored tx is picked among the configured signers
+type SenderSelection string
+
+const (
+	// SenderSelectionFixed always uses the first signer
+	SenderSelectionFixed SenderSelection = "fixed"
+
+	// SenderSelectionRoundRobin uses the signers in turns
+	SenderSelectionRoundRobin SenderSelection = "round-robin"
+
+	// SenderSelectionLeastInFlight uses the signer with fewer created or sent monitored txs
+	SenderSelectionLeastInFlight SenderSelection = "least-in-flight"
+)
+
+// NonceSource defines which nonce of the sender the nonces of the monitored txs are assigned from
+type NonceSource string
+
+const (
+	// NonceSourcePending uses the pending nonce, which includes the txs in the pool of the node
+	NonceSourcePending NonceSource = "pending"
+
+	// NonceSourceLatest uses the nonce at the latest block, not building on unconfirmed txs
+	NonceSourceLatest NonceSource = "latest"
 )
 
 // Config is configuration for ethereum transaction manager
@@ -12,22 +63,98 @@ type Config struct {
 	// FrequencyToMonitorTxs frequency of the resending failed txs
 	FrequencyToMonitorTxs types.Duration `mapstructure:"FrequencyToMonitorTxs"`
 
+	// MonitorTxsCycleTimeout is the maximum time a monitoring cycle waits for the txs being processed,
+	// the txs not processed yet are reviewed in the next cycle. 0 means no timeout
+	MonitorTxsCycleTimeout types.Duration `mapstructure:"MonitorTxsCycleTimeout"`
+
+	// InitialBroadcastGrace is the time a sent tx that is not found in the network is considered not
+	// propagated yet instead of dropped, so it's not broadcast again until the grace expires. It's
+	// counted from the last broadcast of the same tx, so it doesn't delay the txs with bumped fees.
+	// 0 means that a sent tx not found is broadcast again right away
+	InitialBroadcastGrace types.Duration `mapstructure:"InitialBroadcastGrace"`
+
+	// CircuitBreakerFailedCycles is the number of consecutive monitoring cycles in which all the txs
+	// broadcast failed that opens the circuit breaker, so the monitoring cycles don't send txs until the
+	// CircuitBreakerCooldown expires while the txs already mined are still processed. The txs sent outside
+	// the monitoring cycles (on add or forced) are not counted. 0 means that the circuit breaker is disabled
+	CircuitBreakerFailedCycles uint64 `mapstructure:"CircuitBreakerFailedCycles"`
+
+	// CircuitBreakerCooldown is the time the circuit breaker stays open before a monitoring cycle probes
+	// the network again, 0 means the default of 5m
+	CircuitBreakerCooldown types.Duration `mapstructure:"CircuitBreakerCooldown"`
+
+	// StatusHookTimeout is the maximum time the monitoring waits for a hook registered with OnStatus,
+	// 0 means the default of 5s
+	StatusHookTimeout types.Duration `mapstructure:"StatusHookTimeout"`
+
 	// WaitTxToBeMined time to wait after transaction was sent to the ethereum
 	WaitTxToBeMined types.Duration `mapstructure:"WaitTxToBeMined"`
 
 	// GetReceiptMaxTime is the max time to wait to get the receipt of the mined transaction
 	GetReceiptMaxTime types.Duration `mapstructure:"WaitReceiptMaxTime"`
 
+	// GetReceiptErrorMaxTime is the max time to keep trying to get the receipt of the mined transaction while
+	// the node keeps failing (e.g. it's down), unlike a receipt not found yet that is polled up to
+	// GetReceiptMaxTime. 0 means GetReceiptMaxTime
+	GetReceiptErrorMaxTime types.Duration `mapstructure:"WaitReceiptErrorMaxTime"`
+
 	// GetReceiptWaitInterval is the time to sleep before trying to get the receipt of the mined transaction
 	GetReceiptWaitInterval types.Duration `mapstructure:"WaitReceiptCheckInterval"`
 
+	// PendingTxsPollInterval is the time ProcessPendingMonitoredTxs waits before refreshing the results
+	// of the pending monitored txs, so the blocking processor matches the chain cadence. 0 means the default of 1s
+	PendingTxsPollInterval types.Duration `mapstructure:"PendingTxsPollInterval"`
+
+	// NoReplaceTxTTL is the time a tx added with AddWithNoReplace is monitored waiting for it to be mined,
+	// counted from when it was added. Once it passes the tx is evicted, note it can still be mined by the
+	// network after its eviction. 0 means the default of 1h
+	NoReplaceTxTTL types.Duration `mapstructure:"NoReplaceTxTTL"`
+
 	// PrivateKeys defines all the key store files that are going
 	// to be read in order to provide the private keys to sign the L1 txs
 	PrivateKeys []signertypes.SignerConfig `mapstructure:"PrivateKeys"`
 
+	// SenderSelection defines how the sender of the new txs is picked among the configured private keys,
+	// either "fixed" (default), "round-robin" or "least-in-flight". Spreading independent txs across
+	// several senders avoids serializing all of them on the nonces of a single account
+	SenderSelection SenderSelection `mapstructure:"SenderSelection"`
+
+	// NonceSource defines the nonce the monitored txs are assigned from, either "pending" (default) or
+	// "latest". The latest nonce is safer when txs of the same sender are queued externally, as it
+	// doesn't build on unconfirmed state. It's ignored when a nonce provider is set with SetNonceProvider
+	NonceSource NonceSource `mapstructure:"NonceSource"`
+
+	// SimulateBeforeSend enables executing the txs with an eth_call against the latest state before sending
+	// them for the first time, the txs whose execution would be reverted are set as failed without being sent,
+	// so they don't waste gas and nonces. Blob txs are not simulated
+	SimulateBeforeSend bool `mapstructure:"SimulateBeforeSend"`
+
+	// RevertReasonsWindow is the time the failed txs are aggregated by revert reason, see RevertReasons.
+	// 0 means the default of 1h
+	RevertReasonsWindow types.Duration `mapstructure:"RevertReasonsWindow"`
+
+	// RevertReasonAlertThreshold is the number of txs failed with the same revert reason inside the
+	// RevertReasonsWindow that logs an alert, so a deterministic failure (e.g. a paused contract) is
+	// reported once instead of once per tx. 0 means no alert
+	RevertReasonAlertThreshold uint64 `mapstructure:"RevertReasonAlertThreshold"`
+
+	// LatencyBuckets are the upper bounds of the buckets of the histograms returned by Latencies, ascending.
+	// Empty means the default buckets from 15s to 1h
+	LatencyBuckets []types.Duration `mapstructure:"LatencyBuckets"`
+
 	// ForcedGas is the amount of gas to be forced in case of gas estimation error
 	ForcedGas uint64 `mapstructure:"ForcedGas"`
 
+	// GasEstimationMargin is the percentage added to the gas estimated for the non blob txs, so the
+	// borderline estimations don't run out of gas. It's applied before and independently of the
+	// absolute GasOffset of each tx, 0 means no margin. The blob txs keep their own margin of 20%
+	//
+	// i.e.
+	// estimation: 100000
+	// GasEstimationMargin: 10
+	// gas: 110000
+	GasEstimationMargin float64 `mapstructure:"GasEstimationMargin"`
+
 	// GasPriceMarginFactor is used to multiply the suggested gas price provided by the network
 	// in order to allow a different gas price to be set for all the transactions and making it
 	// easier to have the txs prioritized in the pool, default value is 1.
@@ -61,9 +188,134 @@ type Config struct {
 	// tx gas price = 110
 	MaxGasPriceLimit uint64 `mapstructure:"MaxGasPriceLimit"`
 
+	// GasPriceSanityMax is the maximum gas price in wei the network can suggest before applying the
+	// GasPriceMarginFactor, default value is 0, which means no maximum.
+	// Unlike MaxGasPriceLimit, a suggestion over this value is not clamped but rejected, since it means
+	// the node is misbehaving, so neither the tx is added nor its gas price is updated
+	GasPriceSanityMax uint64 `mapstructure:"GasPriceSanityMax"`
+
+	// MinGasTipCap is the minimum gas tip cap in wei used by the blob txs, both when they are
+	// created and when they are reviewed, default value is 0, which means no minimum
+	MinGasTipCap uint64 `mapstructure:"MinGasTipCap"`
+
+	// MaxGasTipCap is the maximum gas tip cap in wei used by the blob txs, both when they are
+	// created and when they are reviewed, default value is 0, which means no limit.
+	// The tip of a tx already over this limit is not decreased, since the replacement would be rejected
+	MaxGasTipCap uint64 `mapstructure:"MaxGasTipCap"`
+
+	// MaxBlobsPerTx is the maximum number of blobs a tx can carry, the blob txs with more blobs are rejected
+	// when they are added instead of failing when they are sent. 0 means the default of 6 (EIP-4844)
+	MaxBlobsPerTx uint64 `mapstructure:"MaxBlobsPerTx"`
+
+	// TipCapFeeHistoryBlocks is the number of latest blocks whose eth_feeHistory is used to suggest the
+	// gas tip cap of the txs, taking the median over the blocks of the tip paid at TipCapFeeHistoryPercentile.
+	// Default value is 0, which means the tip suggested by the node (eth_maxPriorityFeePerGas) is used
+	TipCapFeeHistoryBlocks uint64 `mapstructure:"TipCapFeeHistoryBlocks"`
+
+	// TipCapFeeHistoryPercentile is the percentile (0-100) of the tips paid in each block used when
+	// TipCapFeeHistoryBlocks is configured, 0 means the default of 50
+	TipCapFeeHistoryPercentile float64 `mapstructure:"TipCapFeeHistoryPercentile"`
+
+	// GetHeaderMaxRetries is the number of times a header is requested again when the request
+	// fails while adding or reviewing a blob tx, default value is 0, which means no retries
+	GetHeaderMaxRetries uint64 `mapstructure:"GetHeaderMaxRetries"`
+
+	// GetHeaderRetryBackoff is the time to wait before retrying a header request for the first
+	// time, it's doubled after each retry
+	GetHeaderRetryBackoff types.Duration `mapstructure:"GetHeaderRetryBackoff"`
+
+	// StorageUpdateMaxRetries is the number of times the update of a monitored tx is tried again when it
+	// fails while monitoring it, default value is 0, which means no retries. When the update keeps failing
+	// the monitored tx is not processed further until it's loaded again from the storage in the next cycle
+	StorageUpdateMaxRetries uint64 `mapstructure:"StorageUpdateMaxRetries"`
+
+	// StorageUpdateRetryBackoff is the time to wait before retrying the update of a monitored tx for the
+	// first time, it's doubled after each retry
+	StorageUpdateRetryBackoff types.Duration `mapstructure:"StorageUpdateRetryBackoff"`
+
+	// BumpOwnDynamicFees enables bumping the dynamic fee txs from their own fees when they are reviewed instead
+	// of from the suggested gas price: the tip and the fee cap are both bumped once by ReplacementBumpPercentage,
+	// as geth requires to accept a replacement, and the fee cap is raised to cover the base fee plus the new tip
+	BumpOwnDynamicFees bool `mapstructure:"BumpOwnDynamicFees"`
+
+	// ReplacementBumpPercentage is the minimum percentage the fees of a tx are increased when they are bumped
+	// to replace the tx, default value is 0, which means 10%
+	ReplacementBumpPercentage uint64 `mapstructure:"ReplacementBumpPercentage"`
+
+	// BumpScheduleBasePercentage enables an escalating bump of the fees of the sent txs when they are reviewed,
+	// the first review increases them by this percentage and each following review multiplies the increment
+	// by BumpScheduleGrowthFactor (e.g. 12.5%, 25%, 50%...), so the stuck txs escalate decisively during fee
+	// spikes. The bumped fees are limited by MaxGasPriceLimit, which is required by the schedule, and MaxGasTipCap.
+	// The schedule only escalates with the replacements actually sent. 0 means that the fees are only updated
+	// to the suggested ones. It's not used along with BumpOwnDynamicFees
+	BumpScheduleBasePercentage float64 `mapstructure:"BumpScheduleBasePercentage"`
+
+	// BumpScheduleGrowthFactor multiplies the bump percentage on each review, 0 means the default of 2
+	BumpScheduleGrowthFactor float64 `mapstructure:"BumpScheduleGrowthFactor"`
+
+	// BumpScheduleMaxPercentage is the maximum bump percentage reached by the schedule, 0 means the default of 100%
+	BumpScheduleMaxPercentage float64 `mapstructure:"BumpScheduleMaxPercentage"`
+
+	// ResultCacheTTL is the time the results built by Result and ResultsByStatus are cached, so the repeated
+	// requests don't request the txs and receipts to the network again. A cached result is discarded as soon
+	// as the status of its monitored tx changes or a new tx is sent. 0 means that the cache is disabled
+	ResultCacheTTL types.Duration `mapstructure:"ResultCacheTTL"`
+
+	// ResultCacheTerminalTTL is the time the results of the finalized, failed and evicted monitored txs are
+	// cached, 0 means that ResultCacheTTL is used
+	ResultCacheTerminalTTL types.Duration `mapstructure:"ResultCacheTerminalTTL"`
+
+	// BatchResultCalls makes Result and ResultsByStatus get the txs and receipts of the whole history of a
+	// monitored tx in a single JSON-RPC batch call instead of one call each. When the node doesn't support
+	// the batch calls, they are got one by one
+	BatchResultCalls bool `mapstructure:"BatchResultCalls"`
+
+	// IncludeConfirmations enables setting the number of confirmations of the mined txs in the results
+	// returned by Result and ResultsByStatus, requesting the latest block number once per call
+	IncludeConfirmations bool `mapstructure:"IncludeConfirmations"`
+
+	// MinConfirmationsForMined is the number of confirmations, counting the block the tx was mined in,
+	// a successful tx needs before its monitored tx is set as mined. Until then it stays sent and it's
+	// checked again in the next monitoring cycles, so a reorg right after the tx is mined doesn't make
+	// it flip-flop. 0 or 1 means the monitored tx is set as mined as soon as its receipt is found
+	MinConfirmationsForMined uint64 `mapstructure:"MinConfirmationsForMined"`
+
+	// SendOnAdd enables signing and sending the txs when they are added instead of waiting for the next
+	// monitoring cycle, the monitoring loop keeps following them once sent. If the tx can't be sent on add
+	// it's kept as created, so it's sent in the next monitoring cycle
+	SendOnAdd bool `mapstructure:"SendOnAdd"`
+
 	// StoragePath is the path of the internal storage
 	StoragePath string `mapstructure:"StoragePath"`
 
+	// Storage is the configuration of the internal storage
+	Storage sqlstorage.Config `mapstructure:"Storage"`
+
+	// StorageMaintenanceInterval is the interval to run the storage maintenance tasks
+	// (WAL checkpoint and VACUUM for sqlite) in background.
+	// 0 means that the maintenance is disabled
+	StorageMaintenanceInterval types.Duration `mapstructure:"StorageMaintenanceInterval"`
+
+	// RemoveAfterFinalized enables removing the monitored txs from the storage as soon as they are finalized,
+	// once the hooks registered with OnStatus for the finalized status ran, to keep the storage small
+	// without pruning it separately. The results of the removed txs can't be retrieved anymore
+	RemoveAfterFinalized bool `mapstructure:"RemoveAfterFinalized"`
+
+	// PersistCheckpoint enables persisting a checkpoint of the monitoring (last successful cycle time and
+	// last finalized block processed) in the storage. A restarted instance reports the gap since the last
+	// cycle, and the safe txs promoted to finalized are looked up only in the blocks finalized since the
+	// checkpoint and among the txs set as safe after it, instead of scanning all the safe txs every cycle
+	PersistCheckpoint bool `mapstructure:"PersistCheckpoint"`
+
+	// NonceReconciliationInterval is the interval to compare the nonces of the monitored txs with the
+	// confirmed and pending nonces of the chain in background, reporting the drift found.
+	// 0 means that the reconciliation is disabled
+	NonceReconciliationInterval types.Duration `mapstructure:"NonceReconciliationInterval"`
+
+	// ReassignConsumedNonces enables reassigning the nonce of the created txs found by the nonce
+	// reconciliation with a nonce below the confirmed nonce of the chain, since it was already consumed
+	ReassignConsumedNonces bool `mapstructure:"ReassignConsumedNonces"`
+
 	// ReadPendingL1Txs is a flag to enable the reading of pending L1 txs
 	// It can only be enabled if DBPath is empty
 	ReadPendingL1Txs bool `mapstructure:"ReadPendingL1Txs"`
@@ -84,7 +336,65 @@ type Config struct {
 	// 0 means that the default value will be used
 	FinalizedStatusL1NumberOfBlocks uint64 `mapstructure:"FinalizedStatusL1NumberOfBlocks"`
 
+	// ReestimateGasOnReview enables the gas estimation every time a sent tx is reviewed.
+	// When disabled, the gas of the last successful estimation is reused and the gas is only
+	// estimated again if the last tx mined ran out of gas
+	ReestimateGasOnReview bool `mapstructure:"ReestimateGasOnReview"`
+
+	// OutOfGasMultiplier is used to multiply the gas limit (gas plus gas offset) of a tx that ran out of gas
+	// when it's reviewed, the gas is set to the max between the new estimation and the multiplied gas limit
+	// minus the gas offset. 0 or 1 means that only the new estimation is considered
+	//
+	// ex:
+	// gas: 100000
+	// new estimation: 100000
+	// OutOfGasMultiplier: 1.2
+	// new gas: 120000
+	OutOfGasMultiplier float64 `mapstructure:"OutOfGasMultiplier"`
+
+	// RaiseGasOnIntrinsicGasTooLow enables estimating the gas again and resending the tx
+	// in the same cycle when a tx is rejected because its gas doesn't cover the intrinsic gas
+	RaiseGasOnIntrinsicGasTooLow bool `mapstructure:"RaiseGasOnIntrinsicGasTooLow"`
+
+	// AllowLegacyFallback enables converting the dynamic fee txs into legacy txs when the node rejects them
+	// because their type is not supported. The blob txs can't fall back, so they are evicted. The txs
+	// converted by UpgradeLegacyTxs don't fall back
+	AllowLegacyFallback bool `mapstructure:"AllowLegacyFallback"`
+
+	// UpgradeLegacyTxs enables converting the legacy monitored txs into dynamic fee txs when they are
+	// reviewed and the network reports a base fee, the legacy txs already sent are kept in the history.
+	// The txs converted by AllowLegacyFallback are not upgraded
+	UpgradeLegacyTxs bool `mapstructure:"UpgradeLegacyTxs"`
+
+	// CheckSenderBalance enables checking the sender balance covers the tx cost
+	// (gas * gas price + value + blob cost) before sending it, skipping the send while it doesn't
+	CheckSenderBalance bool `mapstructure:"CheckSenderBalance"`
+
+	// StuckTxPolicy defines how a sent tx whose history shows repeated failed receipts is handled,
+	// either "bump" (default) or "fail". The failed receipts already consumed the nonce of the
+	// reverted txs, so setting the tx as failed doesn't need to replace any pending tx
+	StuckTxPolicy StuckTxPolicy `mapstructure:"StuckTxPolicy"`
+
+	// OrphanedTxPolicy defines how the pending txs whose sender has no configured signer (e.g. its key was
+	// removed) are handled when the tx manager starts, either "keep" (default) or "evict". These txs can't
+	// be signed, so they fail every monitoring cycle until the signer is configured again
+	OrphanedTxPolicy OrphanedTxPolicy `mapstructure:"OrphanedTxPolicy"`
+
+	// AllowedDestinations restricts the addresses the txs can be sent to, the txs added with any other
+	// destination are rejected with ErrDestinationNotAllowed. It's a safety control when the signer is shared,
+	// so a compromised caller can't send funds to arbitrary addresses. Empty means any destination is allowed
+	AllowedDestinations []common.Address `mapstructure:"AllowedDestinations"`
+
+	// AllowContractCreation allows adding contract creation txs (without destination)
+	// when AllowedDestinations is not empty, otherwise they are rejected
+	AllowContractCreation bool `mapstructure:"AllowContractCreation"`
+
 	// EstimateGasMaxRetries is the maximum number of times a transaction will be retried before being evicted
 	// 0 means unlimited retries (default behavior)
 	EstimateGasMaxRetries uint64 `mapstructure:"EstimateGasMaxRetries"`
+
+	// MaxSendAttempts is the maximum number of times a transaction is broadcast, including the sends of the
+	// txs with bumped fees, before being evicted. It bounds the resending of a tx the network keeps rejecting
+	// or not mining. 0 means unlimited attempts (default behavior)
+	MaxSendAttempts uint64 `mapstructure:"MaxSendAttempts"`
 }
@@ -0,0 +1,65 @@
+package ethtxmanager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0xPolygon/zkevm-ethtx-manager/log"
+)
+
+// Drain quiesces the tx manager before handing off to a new instance: the new txs are rejected with
+// ErrDraining while the monitoring keeps driving the stored txs to a terminal status (finalized, failed
+// or evicted). The channel returned by Drained is closed once no stored tx is left in a non terminal status
+func (c *Client) Drain() {
+	if c.draining.Swap(true) {
+		return
+	}
+	log.Infof("draining, new txs are rejected until the stored txs reach a terminal status")
+}
+
+// Drained returns a channel closed once the tx manager is draining and all the stored txs reached a
+// terminal status, so the instance can be stopped without leaving txs in flight
+func (c *Client) Drained() <-chan struct{} {
+	c.drainMu.Lock()
+	defer c.drainMu.Unlock()
+	if c.drained == nil {
+		c.drained = make(chan struct{})
+	}
+	return c.drained
+}
+
+// checkDrained closes the channel returned by Drained when the tx manager is draining and
+// there are no stored txs left in a non terminal status
+func (c *Client) checkDrained(ctx context.Context) error {
+	if !c.draining.Load() {
+		return nil
+	}
+
+	counts, err := c.storage.CountByStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to count monitored txs by status: %w", translateError(err))
+	}
+	pending := 0
+	for status, count := range counts {
+		if !status.IsTerminal() {
+			pending += count
+		}
+	}
+	if pending > 0 {
+		log.Debugf("draining, %d monitored txs still in a non terminal status", pending)
+		return nil
+	}
+
+	c.drainMu.Lock()
+	defer c.drainMu.Unlock()
+	if c.drained == nil {
+		c.drained = make(chan struct{})
+	}
+	select {
+	case <-c.drained:
+	default:
+		log.Infof("drained, all the monitored txs reached a terminal status")
+		close(c.drained)
+	}
+	return nil
+}
@@ -9,8 +9,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"math/big"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	localCommon "github.com/0xPolygon/zkevm-ethtx-manager/common"
@@ -25,23 +31,143 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/holiman/uint256"
 )
 
-const failureIntervalInSeconds = 5
+const (
+	failureIntervalInSeconds = 5
+
+	// retryableFailureIntervalInSeconds is the time to wait before trying again after a retryable error
+	retryableFailureIntervalInSeconds = 1
+
+	// storageUnavailableIntervalInSeconds is the time to wait before trying again when the storage
+	// is unavailable, longer than the usual one to give it time to recover
+	storageUnavailableIntervalInSeconds = 30
+
+	// errMsgIntrinsicGasTooLow is the error returned by the nodes when the gas
+	// of a tx is lower than its intrinsic gas
+	errMsgIntrinsicGasTooLow = "intrinsic gas too low"
+
+	// errMsgTxTypeNotSupported is the error returned by the nodes when the type of a tx is not supported
+	errMsgTxTypeNotSupported = "transaction type not supported"
+
+	// errMsgBlobGasPriceTooLow and errMsgBlobFeeCapTooLow are the errors returned by the nodes when
+	// the blob fee cap of a blob tx doesn't cover the blob base fee
+	errMsgBlobGasPriceTooLow = "blob gas price too low"
+	errMsgBlobFeeCapTooLow   = "max fee per blob gas less than block blob gas fee"
+
+	// defaultReplacementBumpPercentage is the minimum percentage the fees of a tx must be increased to
+	// replace it in the pool of the nodes, used when ReplacementBumpPercentage is not configured
+	defaultReplacementBumpPercentage = 10
+
+	// defaultBumpScheduleGrowthFactor multiplies the bump percentage of the schedule on each review,
+	// used when BumpScheduleGrowthFactor is not configured
+	defaultBumpScheduleGrowthFactor = 2
+
+	// defaultBumpScheduleMaxPercentage is the maximum bump percentage of the schedule,
+	// used when BumpScheduleMaxPercentage is not configured
+	defaultBumpScheduleMaxPercentage = 100
+
+	// defaultTipCapFeeHistoryPercentile is the percentile of the tips paid in each block used to suggest
+	// the gas tip cap from the fee history when TipCapFeeHistoryPercentile is not configured
+	defaultTipCapFeeHistoryPercentile = 50
+
+	// defaultMaxBlobsPerTx is the maximum number of blobs of a tx allowed by EIP-4844,
+	// used when MaxBlobsPerTx is not configured
+	defaultMaxBlobsPerTx = 6
+
+	// defaultPendingTxsPollInterval is the time ProcessPendingMonitoredTxs waits before refreshing
+	// the pending results when PendingTxsPollInterval is not configured
+	defaultPendingTxsPollInterval = time.Second
+
+	// defaultNoReplaceTxTTL is the time a no replace tx waits to be mined before being evicted
+	// when NoReplaceTxTTL is not configured
+	defaultNoReplaceTxTTL = time.Hour
+
+	// percentageBase is the value representing the 100%
+	percentageBase = 100
+
+	// stuckTxFailedReceipts is the number of failed receipts in the history of a
+	// monitored tx to consider it stuck
+	stuckTxFailedReceipts = 2
+)
 
 var (
 	// ErrNotFound it's returned
 	ErrNotFound = types.ErrNotFound
 	// ErrAlreadyExists when the object already exists
 	ErrAlreadyExists = errors.New("already exists")
+	// ErrStorageUnavailable when the storage can't be used for now, the operation can be retried later
+	ErrStorageUnavailable = types.ErrStorageUnavailable
+	// ErrNonTerminalStatus when removing monitored txs with a status they can still move from without forcing it
+	ErrNonTerminalStatus = errors.New("monitored txs with a non terminal status can't be removed")
+	// ErrUnknownNonceSource when the configured NonceSource is not supported
+	ErrUnknownNonceSource = errors.New("unknown nonce source")
 
 	// ErrExecutionReverted returned when trying to get the revert message
 	// but the call fails without revealing the revert reason
 	ErrExecutionReverted = errors.New("execution reverted")
+
+	// ErrInsufficientFunds when the sender balance doesn't cover the cost of a tx
+	ErrInsufficientFunds = errors.New("insufficient funds")
+
+	// ErrNonceAlreadyAssigned when the nonce of a monitored tx is already
+	// assigned to another active monitored tx of the same sender
+	ErrNonceAlreadyAssigned = errors.New("nonce already assigned to another active monitored tx")
+
+	// ErrHistoryMismatch when the tx built from the stored fields of a
+	// monitored tx is not in its history
+	ErrHistoryMismatch = errors.New("monitored tx history mismatch")
+
+	// ErrNonceNotAssigned when the nonce of a monitored tx is needed before the monitoring assigns it
+	ErrNonceNotAssigned = errors.New("nonce not assigned yet")
+
+	// ErrInvalidStatus when the status of a monitored tx doesn't allow the requested operation
+	ErrInvalidStatus = errors.New("invalid monitored tx status")
+
+	// ErrNoReplace when an operation would replace a sent monitored tx added with AddWithNoReplace
+	ErrNoReplace = errors.New("monitored tx can't be replaced")
+
+	// ErrImplausibleBlockNumber when a block number provided by the caller can't be the one a tx was mined in
+	ErrImplausibleBlockNumber = errors.New("implausible block number")
+
+	// ErrTerminalMonitoredTx when an operation requires a pending monitored tx (created or sent)
+	// but the monitored tx already reached a terminal status
+	ErrTerminalMonitoredTx = errors.New("monitored tx is in a terminal status")
+
+	// ErrNoRelayBroadcaster when a tx flagged to use the private relay is added or sent
+	// but no relay broadcaster was provided
+	ErrNoRelayBroadcaster = errors.New("no relay broadcaster provided")
+
+	// ErrRPCTimeout when a request to the network doesn't finish in time, it's a retryable error
+	ErrRPCTimeout = errors.New("rpc timeout")
+
+	// ErrGasPriceAboveSanityMax when the gas price suggested by the network is over GasPriceSanityMax
+	ErrGasPriceAboveSanityMax = errors.New("suggested gas price above sanity max")
+
+	// ErrTooManyBlobs when a blob tx carries more blobs than the MaxBlobsPerTx allowed by the network
+	ErrTooManyBlobs = errors.New("too many blobs in the tx")
+
+	// ErrDraining when a tx is added while the tx manager is draining, see Drain
+	ErrDraining = errors.New("tx manager is draining, no new txs are accepted")
+
+	// ErrDestinationNotAllowed when a tx is added with a destination out of the AllowedDestinations
+	ErrDestinationNotAllowed = errors.New("destination not allowed")
+
+	// ErrUnrecoverableTx when a pending L1 tx can't be rebuilt from the fields provided by txpool_content
+	ErrUnrecoverableTx = errors.New("pending L1 tx can't be recovered")
+
+	// ErrMonitoredTxProcessing when an operation on a monitored tx is requested while it's being processed
+	ErrMonitoredTxProcessing = errors.New("monitored tx is being processed")
+
+	// ErrNonceNotConsumed when an evicted monitored tx is retried before its nonce is consumed
+	ErrNonceNotConsumed = errors.New("nonce of the monitored tx not consumed yet")
 )
 
 // Client for eth tx manager
@@ -53,20 +179,158 @@ type Client struct {
 	etherman types.EthermanInterface
 	storage  types.StorageInterface
 	from     common.Address
+
+	// relayBroadcaster delivers the txs flagged to use the private relay
+	relayBroadcaster types.TxBroadcaster
+
+	// nonceProvider provides the nonces of the senders instead of the network when it's set
+	nonceProvider types.NonceProvider
+
+	// processingTxs keeps the IDs of the monitored txs being processed, so the ones
+	// exceeding the deadline of a cycle are not processed again by the next one
+	processingTxs sync.Map
+
+	// insufficientFunds keeps the IDs of the monitored txs not sent because the
+	// sender can't afford them, so it's reported only once
+	insufficientFunds sync.Map
+
+	// lastBroadcasts keeps the last tx broadcast of each monitored tx being monitored, so a sent tx
+	// not found in the network is not broadcast again during the InitialBroadcastGrace
+	lastBroadcasts sync.Map
+
+	// blobFeeTooLow keeps the IDs of the blob txs rejected because their blob fee cap didn't cover
+	// the blob base fee, so the next review raises it before sending them again
+	blobFeeTooLow sync.Map
+
+	// nonceDrifts keeps the NonceDrift of each sender found by the last nonce reconciliation
+	nonceDrifts sync.Map
+
+	// checkpoint is the last checkpoint persisted when PersistCheckpoint is enabled, nil until it's
+	// loaded from the storage or when there is none. It's only used by the monitoring loop
+	checkpoint *types.Checkpoint
+
+	// statusHooks keeps the hooks registered with OnStatus
+	statusHooks statusHooks
+
+	// nextSender is the turn of the next sender when SenderSelection is round-robin
+	nextSender atomic.Uint64
+	// circuitBreaker stops the monitoring cycles after repeated cycles with all the broadcasts failed
+	circuitBreaker circuitBreaker
+	// resultCache keeps the results recently built when ResultCacheTTL is configured
+	resultCache resultCache
+	// revertReasons aggregates the revert reasons of the txs failed recently
+	revertReasons revertReasons
+	// nonceMu serializes the nonce assignments of the monitoring loop and the txs sent on add
+	nonceMu sync.Mutex
+
+	// draining rejects the new txs once Drain is called
+	draining atomic.Bool
+	// drained is closed once draining and all the stored txs reached a terminal status
+	drained chan struct{}
+	drainMu sync.Mutex
 }
 
 type pending struct {
-	Pending map[common.Address]map[uint64]l1Tx `json:"pending"`
+	Pending map[common.Address]map[uint64]json.RawMessage `json:"pending"`
 }
 
 type l1Tx struct {
-	From     string `json:"from"`
-	To       string `json:"to"`
-	Nonce    string `json:"nonce"`
-	GasPrice string `json:"gasPrice"`
-	Gas      string `json:"gas"`
-	Value    string `json:"value"`
-	Data     string `json:"input"`
+	Type                 string        `json:"type"`
+	Hash                 string        `json:"hash"`
+	From                 string        `json:"from"`
+	To                   string        `json:"to"`
+	Nonce                string        `json:"nonce"`
+	GasPrice             string        `json:"gasPrice"`
+	MaxFeePerGas         string        `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas string        `json:"maxPriorityFeePerGas"`
+	MaxFeePerBlobGas     string        `json:"maxFeePerBlobGas"`
+	BlobVersionedHashes  []common.Hash `json:"blobVersionedHashes"`
+	AccessList           []any         `json:"accessList"`
+	Gas                  string        `json:"gas"`
+	Value                string        `json:"value"`
+	Data                 string        `json:"input"`
+}
+
+// parseBigInt parses a hex or decimal number of a pending L1 tx
+func parseBigInt(field, value string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(value, 0)
+	if !ok {
+		return nil, fmt.Errorf("failed to convert %s %v to big.Int", field, value)
+	}
+	return n, nil
+}
+
+// toMonitoredTx builds a sent monitored tx from a pending L1 tx, keeping its fee fields according to
+// its type. The monitored txs can't hold the sidecar of the blob txs, which txpool_content doesn't provide
+// either, nor an access list, so the txs that would lose them when they are sent again are refused
+func (tx l1Tx) toMonitoredTx() (types.MonitoredTx, error) {
+	to := common.HexToAddress(tx.To)
+	nonce, err := parseBigInt("nonce", tx.Nonce)
+	if err != nil {
+		return types.MonitoredTx{}, err
+	}
+	value, err := parseBigInt("value", tx.Value)
+	if err != nil {
+		return types.MonitoredTx{}, err
+	}
+	gas, err := parseBigInt("gas", tx.Gas)
+	if err != nil {
+		return types.MonitoredTx{}, err
+	}
+	txType := uint64(ethTypes.LegacyTxType)
+	if tx.Type != "" {
+		parsedType, err := parseBigInt("type", tx.Type)
+		if err != nil {
+			return types.MonitoredTx{}, err
+		}
+		txType = parsedType.Uint64()
+	}
+
+	data := common.FromHex(tx.Data)
+	mTx := types.MonitoredTx{
+		ID:      ethTypes.NewTx(&ethTypes.LegacyTx{To: &to, Nonce: nonce.Uint64(), Value: value, Data: data}).Hash(),
+		From:    common.HexToAddress(tx.From),
+		To:      &to,
+		Nonce:   nonce.Uint64(),
+		Value:   value,
+		Data:    data,
+		Gas:     gas.Uint64(),
+		Status:  types.MonitoredTxStatusSent,
+		History: make(map[common.Hash]bool),
+	}
+	if tx.Hash != "" {
+		txHash := common.HexToHash(tx.Hash)
+		mTx.History[txHash] = true
+		mTx.LastTxHash = &txHash
+	}
+
+	if txType == ethTypes.BlobTxType {
+		return types.MonitoredTx{}, fmt.Errorf("%w: blob tx without its sidecar", ErrUnrecoverableTx)
+	}
+	if len(tx.AccessList) > 0 {
+		return types.MonitoredTx{}, fmt.Errorf("%w: tx with an access list", ErrUnrecoverableTx)
+	}
+
+	switch txType {
+	case ethTypes.LegacyTxType, ethTypes.AccessListTxType:
+		mTx.GasPrice, err = parseBigInt("gasPrice", tx.GasPrice)
+		if err != nil {
+			return types.MonitoredTx{}, err
+		}
+	case ethTypes.DynamicFeeTxType:
+		mTx.GasPrice, err = parseBigInt("maxFeePerGas", tx.MaxFeePerGas)
+		if err != nil {
+			return types.MonitoredTx{}, err
+		}
+		mTx.GasTipCap, err = parseBigInt("maxPriorityFeePerGas", tx.MaxPriorityFeePerGas)
+		if err != nil {
+			return types.MonitoredTx{}, err
+		}
+	default:
+		return types.MonitoredTx{}, fmt.Errorf("unsupported tx type %d", txType)
+	}
+
+	return mTx, nil
 }
 
 // This var is for be able to test New function that require to create a Mock of Etherman
@@ -82,11 +346,28 @@ func New(cfg Config) (*Client, error) {
 		return nil, err
 	}
 
-	storage, err := createStorage(cfg.StoragePath)
+	storage, err := createStorage(cfg.StoragePath, cfg.Storage)
 	if err != nil {
 		return nil, err
 	}
 
+	return NewWithStorage(cfg, storage, etherman)
+}
+
+// NewWithStorage creates new eth tx manager using the provided storage and etherman instead of the ones
+// built from the configuration, so the callers can provide their own implementations. The StoragePath,
+// Storage and PrivateKeys settings are only used to build them, so they are ignored
+func NewWithStorage(cfg Config, storage types.StorageInterface, etherman types.EthermanInterface) (*Client, error) {
+	if storage == nil {
+		return nil, errors.New("ethtxmanager storage cannot be nil")
+	}
+	if etherman == nil {
+		return nil, errors.New("ethtxmanager etherman cannot be nil")
+	}
+	if cfg.BumpScheduleBasePercentage > 0 && cfg.MaxGasPriceLimit == 0 {
+		return nil, errors.New("ethtxmanager BumpScheduleBasePercentage requires a MaxGasPriceLimit")
+	}
+
 	publicAddr, err := etherman.PublicAddress()
 	if err != nil {
 		return nil, fmt.Errorf("ethtxmanager error getting public address: %w", err)
@@ -109,88 +390,194 @@ func New(cfg Config) (*Client, error) {
 
 // createStorage instantiates either SQL storage or in memory storage.
 // In case dbPath parameter is a non-empty string, it creates SQL storage, otherwise in memory one.
-func createStorage(dbPath string) (types.StorageInterface, error) {
+func createStorage(dbPath string, storageCfg sqlstorage.Config) (types.StorageInterface, error) {
 	if dbPath == "" {
 		// if the provided path is empty, use the in memory sql lite storage
 		dbPath = ":memory:"
 	}
 
-	return sqlstorage.NewStorage(localCommon.SQLLiteDriverName, dbPath)
+	return sqlstorage.NewStorageWithConfig(localCommon.SQLLiteDriverName, dbPath, storageCfg)
 }
 
-func pendingL1Txs(URL string, from common.Address, httpHeaders map[string]string) ([]types.MonitoredTx, error) {
+func pendingL1Txs(URL string, from common.Address,
+	httpHeaders map[string]string) ([]types.MonitoredTx, int, error) {
 	response, err := JSONRPCCall(URL, "txpool_content", httpHeaders)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	var L1Txs pending
 	err = json.Unmarshal(response.Result, &L1Txs)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
+	// the malformed entries are skipped so a single bad entry doesn't abort the whole recovery
+	skipped := 0
 	mTxs := make([]types.MonitoredTx, 0, len(L1Txs.Pending[from]))
-	for _, tx := range L1Txs.Pending[from] {
-		if common.HexToAddress(tx.From) == from {
-			to := common.HexToAddress(tx.To)
-			nonce, ok := new(big.Int).SetString(tx.Nonce, 0)
-			if !ok {
-				return nil, fmt.Errorf("failed to convert nonce %v to big.Int", tx.Nonce)
-			}
-
-			value, ok := new(big.Int).SetString(tx.Value, 0)
-			if !ok {
-				return nil, fmt.Errorf("failed to convert value %v to big.Int", tx.Value)
-			}
-
-			gas, ok := new(big.Int).SetString(tx.Gas, 0)
-			if !ok {
-				return nil, fmt.Errorf("failed to convert gas %v to big.Int", tx.Gas)
-			}
-
-			gasPrice, ok := new(big.Int).SetString(tx.GasPrice, 0)
-			if !ok {
-				return nil, fmt.Errorf("failed to convert gasPrice %v to big.Int", tx.GasPrice)
-			}
-
-			data := common.Hex2Bytes(tx.Data)
-
-			// TODO: handle case of blob transaction
-
-			mTx := types.MonitoredTx{
-				ID:       ethTypes.NewTx(&ethTypes.LegacyTx{To: &to, Nonce: nonce.Uint64(), Value: value, Data: data}).Hash(),
-				From:     common.HexToAddress(tx.From),
-				To:       &to,
-				Nonce:    nonce.Uint64(),
-				Value:    value,
-				Data:     data,
-				Gas:      gas.Uint64(),
-				GasPrice: gasPrice,
-				Status:   types.MonitoredTxStatusSent,
-				History:  make(map[common.Hash]bool),
-			}
-			mTxs = append(mTxs, mTx)
+	for nonce, rawTx := range L1Txs.Pending[from] {
+		var tx l1Tx
+		if err := json.Unmarshal(rawTx, &tx); err != nil {
+			log.Warnf("skipping malformed pending L1 tx with nonce %d: %v", nonce, err)
+			skipped++
+			continue
+		}
+		if common.HexToAddress(tx.From) != from {
+			continue
+		}
+		mTx, err := tx.toMonitoredTx()
+		if err != nil {
+			log.Warnf("skipping pending L1 tx with nonce %d (hash %s): %v", nonce, tx.Hash, err)
+			skipped++
+			continue
 		}
+		mTxs = append(mTxs, mTx)
 	}
 
-	return mTxs, nil
+	return mTxs, skipped, nil
 }
 
 // Add a transaction to be sent and monitored
 func (c *Client) Add(ctx context.Context, to *common.Address, value *big.Int,
 	data []byte, gasOffset uint64, sidecar *ethTypes.BlobTxSidecar) (common.Hash, error) {
-	hash, err := c.add(ctx, to, value, data, gasOffset, sidecar, 0)
+	hash, err := c.add(ctx, to, value, data, gasOffset, sidecar, addOptions{})
 	return hash, translateError(err)
 }
 
 // AddWithGas adds a transaction to be sent and monitored with a defined gas to be used so it's not estimated
 func (c *Client) AddWithGas(ctx context.Context, to *common.Address,
 	value *big.Int, data []byte, gasOffset uint64, sidecar *ethTypes.BlobTxSidecar, gas uint64) (common.Hash, error) {
-	hash, err := c.add(ctx, to, value, data, gasOffset, sidecar, gas)
+	hash, err := c.add(ctx, to, value, data, gasOffset, sidecar, addOptions{gas: gas})
+	return hash, translateError(err)
+}
+
+// AddWithFees adds a transaction to be sent and monitored with the provided fee cap and tip cap,
+// these fees are pinned so they are not updated when the tx is reviewed. If gas is 0 it's estimated.
+// For non blob txs a nil tipCap means a legacy tx using feeCap as gas price, otherwise a dynamic fee tx is used.
+func (c *Client) AddWithFees(ctx context.Context, to *common.Address, value *big.Int, data []byte, gasOffset uint64,
+	sidecar *ethTypes.BlobTxSidecar, gas uint64, feeCap, tipCap *big.Int) (common.Hash, error) {
+	if feeCap == nil {
+		return common.Hash{}, errors.New("fee cap is required")
+	}
+	if sidecar != nil && tipCap == nil {
+		return common.Hash{}, errors.New("tip cap is required for blob txs")
+	}
+	hash, err := c.add(ctx, to, value, data, gasOffset, sidecar, addOptions{gas: gas, feeCap: feeCap, tipCap: tipCap})
+	return hash, translateError(err)
+}
+
+// AddWithKey adds a transaction to be sent and monitored using an ID calculated over the
+// sender, to, value, data and the provided caller key instead of the default ID.
+// Adding the same payload with the same key results in the same ID, so retries are deduplicated,
+// while distinct keys allow identical payloads to be monitored as different txs.
+func (c *Client) AddWithKey(ctx context.Context, to *common.Address, value *big.Int, data []byte,
+	gasOffset uint64, sidecar *ethTypes.BlobTxSidecar, key []byte) (common.Hash, error) {
+	if len(key) == 0 {
+		return common.Hash{}, errors.New("key is required")
+	}
+	hash, err := c.add(ctx, to, value, data, gasOffset, sidecar, addOptions{key: key})
+	return hash, translateError(err)
+}
+
+// AddWithPrivateRelay adds a transaction to be sent through the private relay broadcaster instead
+// of the public mempool, the tx is monitored by polling its receipt as any other tx
+func (c *Client) AddWithPrivateRelay(ctx context.Context, to *common.Address, value *big.Int, data []byte,
+	gasOffset uint64, sidecar *ethTypes.BlobTxSidecar) (common.Hash, error) {
+	if c.relayBroadcaster == nil {
+		return common.Hash{}, ErrNoRelayBroadcaster
+	}
+	hash, err := c.add(ctx, to, value, data, gasOffset, sidecar, addOptions{privateRelay: true})
+	return hash, translateError(err)
+}
+
+// AddWithNonce adds a transaction to be sent and monitored with the provided nonce for callers managing
+// the nonces externally, e.g. to fill a nonce gap. The nonce is never reassigned by the tx manager and the
+// tx is always sent by the default sender, regardless of the SenderSelection configuration
+func (c *Client) AddWithNonce(ctx context.Context, to *common.Address, value *big.Int, data []byte,
+	gasOffset uint64, sidecar *ethTypes.BlobTxSidecar, nonce uint64) (common.Hash, error) {
+	hash, err := c.add(ctx, to, value, data, gasOffset, sidecar, addOptions{nonce: &nonce})
+	return hash, translateError(err)
+}
+
+// AddWithStateOverrides adds a transaction to be sent and monitored estimating its gas over the latest
+// state modified by the provided overrides, e.g. when the tx depends on the effects of a previous tx
+// that will be mined before it. The overrides are only used by this estimation, so the gas estimated
+// again when the tx is reviewed uses the actual state. Blob txs are not supported
+func (c *Client) AddWithStateOverrides(ctx context.Context, to *common.Address, value *big.Int, data []byte,
+	gasOffset uint64, overrides map[common.Address]gethclient.OverrideAccount) (common.Hash, error) {
+	hash, err := c.add(ctx, to, value, data, gasOffset, nil, addOptions{stateOverrides: overrides})
+	return hash, translateError(err)
+}
+
+// AddWithDeadline adds a transaction to be sent and monitored that is only useful if it's mined up to
+// the validUntilBlock block, e.g. a proof only valid before a given block. Once the latest block passes
+// the deadline, the tx is not sent anymore and it's evicted if it's not mined yet. Note a tx already
+// broadcast can still be mined by the network after its eviction
+func (c *Client) AddWithDeadline(ctx context.Context, to *common.Address, value *big.Int, data []byte,
+	gasOffset uint64, sidecar *ethTypes.BlobTxSidecar, validUntilBlock uint64) (common.Hash, error) {
+	if validUntilBlock == 0 {
+		return common.Hash{}, errors.New("valid until block is required")
+	}
+	hash, err := c.add(ctx, to, value, data, gasOffset, sidecar, addOptions{validUntilBlock: validUntilBlock})
+	return hash, translateError(err)
+}
+
+// AddWithPriority adds a transaction to be sent and monitored with the provided priority. In each monitoring
+// cycle the txs with a higher priority are processed first and, for the same sender, get the lower nonces
+// when the nonces are assigned, so critical txs (e.g. a final proof) jump ahead of the bulk traffic.
+// The txs added with Add have priority 0, so a negative priority sends the tx after them
+func (c *Client) AddWithPriority(ctx context.Context, to *common.Address, value *big.Int, data []byte,
+	gasOffset uint64, sidecar *ethTypes.BlobTxSidecar, priority int) (common.Hash, error) {
+	hash, err := c.add(ctx, to, value, data, gasOffset, sidecar, addOptions{priority: priority})
 	return hash, translateError(err)
 }
 
+// AddWithNoReplace adds a transaction to be sent once and monitored without replacing it, e.g. when it
+// interacts with a one-shot contract and a replacement could execute twice if both land. Its fees are not
+// bumped and its nonce is not reviewed, so if it's not mined in NoReplaceTxTTL it's evicted
+func (c *Client) AddWithNoReplace(ctx context.Context, to *common.Address, value *big.Int, data []byte,
+	gasOffset uint64, sidecar *ethTypes.BlobTxSidecar) (common.Hash, error) {
+	hash, err := c.add(ctx, to, value, data, gasOffset, sidecar, addOptions{noReplace: true})
+	return hash, translateError(err)
+}
+
+// SetRelayBroadcaster sets the broadcaster used to deliver the txs added with AddWithPrivateRelay,
+// it must be set before starting the tx manager
+func (c *Client) SetRelayBroadcaster(broadcaster types.TxBroadcaster) {
+	c.relayBroadcaster = broadcaster
+}
+
+// SetNonceProvider sets the provider the nonces of the monitored txs are assigned from instead of the
+// network, e.g. a nonce manager shared across services. It must be set before starting the tx manager.
+// The provider is asked once for each monitored tx a nonce is assigned to, and the nonces provided are
+// still moved past the ones used by other active monitored txs of the sender
+func (c *Client) SetNonceProvider(provider types.NonceProvider) {
+	c.nonceProvider = provider
+}
+
+// addOptions holds the optional parameters accepted by the different Add flavours
+type addOptions struct {
+	// gas to be used, 0 means it must be estimated
+	gas uint64
+	// feeCap and tipCap pin the fees of the tx when feeCap is not nil
+	feeCap *big.Int
+	tipCap *big.Int
+	// key is used to calculate the ID from the tx content when it's not empty
+	key []byte
+	// privateRelay sends the tx through the relay broadcaster
+	privateRelay bool
+	// nonce pins the nonce of the tx when it's not nil
+	nonce *uint64
+	// stateOverrides modify the state the gas of the tx is estimated over when they are not nil
+	stateOverrides map[common.Address]gethclient.OverrideAccount
+	// validUntilBlock is the deadline of the tx, 0 means it has no deadline
+	validUntilBlock uint64
+	// priority of the tx in the monitoring cycles
+	priority int
+	// noReplace sends the tx once without replacing it
+	noReplace bool
+}
+
 func (c *Client) add(
 	ctx context.Context,
 	to *common.Address,
@@ -198,63 +585,93 @@ func (c *Client) add(
 	data []byte,
 	gasOffset uint64,
 	sidecar *ethTypes.BlobTxSidecar,
-	gas uint64,
+	opts addOptions,
 ) (common.Hash, error) {
-	var err error
+	var (
+		err       error
+		gasPrice  *big.Int
+		gas       = opts.gas
+		fixedFees = opts.feeCap != nil
+	)
 
-	// get gas price
-	gasPrice, err := c.suggestedGasPrice(ctx)
-	if err != nil {
-		err := fmt.Errorf("failed to get suggested gas price: %w", translateError(err))
-		log.Errorf(err.Error())
+	if c.draining.Load() {
+		return common.Hash{}, ErrDraining
+	}
+
+	if err := c.checkDestination(to); err != nil {
 		return common.Hash{}, err
 	}
 
+	if sidecar != nil {
+		if maxBlobs := c.maxBlobsPerTx(); uint64(len(sidecar.Blobs)) > maxBlobs {
+			return common.Hash{}, fmt.Errorf("%w: the blob sidecar has %d blobs and a tx can carry up to %d",
+				ErrTooManyBlobs, len(sidecar.Blobs), maxBlobs)
+		}
+	}
+
+	// the nonce provided by the caller belongs to the default sender
+	from := c.from
+	if opts.nonce == nil {
+		from, err = c.selectSender(ctx)
+		if err != nil {
+			log.Errorf("failed to select the sender: %v", err)
+			return common.Hash{}, err
+		}
+	}
+
+	// get gas price
+	if fixedFees {
+		gasPrice = new(big.Int).Set(opts.feeCap)
+	} else {
+		gasPrice, err = c.suggestedGasPrice(ctx)
+		if err != nil {
+			err := fmt.Errorf("failed to get suggested gas price: %w", translateError(err))
+			log.Errorf(err.Error())
+			return common.Hash{}, err
+		}
+	}
+
 	var (
 		blobFeeCap  *big.Int
 		gasTipCap   *big.Int
 		estimateGas bool
 	)
 
+	if opts.tipCap != nil {
+		gasTipCap = new(big.Int).Set(opts.tipCap)
+	}
+
 	if gas == 0 {
 		estimateGas = true
 	}
 
 	if sidecar != nil {
-		// blob gas price estimation
-		header, err := c.etherman.GetHeaderByNumber(ctx, nil)
+		// blob gas price estimation, the blob fee of the latest block is computed from its own
+		// excess blob gas, so the parent header is not needed
+		header, err := c.latestHeaderWithRetries(ctx)
 		if err != nil {
 			log.Errorf("failed to get header: %v", err)
 			return common.Hash{}, err
 		}
-		parentNumber := new(big.Int).Sub(header.Number, big.NewInt(1))
-		parentHeader, err := c.etherman.GetHeaderByNumber(ctx, parentNumber)
-		if err != nil {
-			log.Errorf("failed to get parent header: %v", err)
-			return common.Hash{}, err
-		}
 
-		if parentHeader.ExcessBlobGas != nil && parentHeader.BlobGasUsed != nil {
-			parentExcessBlobGas := eip4844.CalcExcessBlobGas(&params.ChainConfig{}, parentHeader, header.Time)
-			blobFeeCap = eip4844.CalcBlobFee(&params.ChainConfig{}, parentHeader)
-			if *header.ExcessBlobGas != parentExcessBlobGas {
-				return common.Hash{}, fmt.Errorf("invalid excessBlobGas: have %d, want %d",
-					*header.ExcessBlobGas, parentExcessBlobGas)
-			}
+		if header.ExcessBlobGas != nil {
+			blobFeeCap = eip4844.CalcBlobFee(c.blobChainConfig(), header)
 		} else {
-			log.Infof("legacy parent header no blob gas info")
+			log.Infof("legacy header no blob gas info")
 			blobFeeCap = big.NewInt(params.BlobTxMinBlobGasprice)
 		}
 
-		gasTipCap, err = c.etherman.GetSuggestGasTipCap(ctx)
-		if err != nil {
-			log.Errorf("failed to get gas tip cap: %v", err)
-			return common.Hash{}, err
+		if !fixedFees {
+			gasTipCap, err = c.suggestedGasTipCap(ctx)
+			if err != nil {
+				log.Errorf("failed to get gas tip cap: %v", err)
+				return common.Hash{}, err
+			}
 		}
 
 		// get gas
 		if estimateGas {
-			gas, err = c.etherman.EstimateGasBlobTx(ctx, c.from, to, gasPrice, gasTipCap, value, data)
+			gas, err = c.etherman.EstimateGasBlobTx(ctx, from, to, gasPrice, gasTipCap, value, data)
 			if err != nil {
 				if de, ok := err.(rpc.DataError); ok {
 					err = fmt.Errorf("%w (%v)", translateError(err), de.ErrorData())
@@ -263,7 +680,7 @@ func (c *Client) add(
 				log.Error(err.Error())
 				log.Debugf(
 					"failed to estimate gas for blob tx: from: %v, to: %v, value: %v",
-					c.from.String(),
+					from.String(),
 					to.String(),
 					value.String(),
 				)
@@ -273,13 +690,19 @@ func (c *Client) add(
 
 		// margin
 		const multiplier = 10
-		gasTipCap = gasTipCap.Mul(gasTipCap, big.NewInt(multiplier))
-		gasPrice = gasPrice.Mul(gasPrice, big.NewInt(multiplier))
+		if !fixedFees {
+			gasTipCap = c.clampGasTipCap(gasTipCap.Mul(gasTipCap, big.NewInt(multiplier)))
+			gasPrice = gasPrice.Mul(gasPrice, big.NewInt(multiplier))
+		}
 		blobFeeCap = blobFeeCap.Mul(blobFeeCap, big.NewInt(multiplier))
 		gas = gas * 12 / 10 //nolint:mnd
 	} else if estimateGas {
 		// get gas
-		gas, err = c.etherman.EstimateGas(ctx, c.from, to, value, data)
+		if opts.stateOverrides != nil {
+			gas, err = c.etherman.EstimateGasWithStateOverrides(ctx, from, to, value, data, opts.stateOverrides)
+		} else {
+			gas, err = c.etherman.EstimateGas(ctx, from, to, value, data)
+		}
 		if err != nil {
 			if de, ok := err.(rpc.DataError); ok {
 				err = fmt.Errorf("%w (%v)", translateError(err), de.ErrorData())
@@ -288,7 +711,7 @@ func (c *Client) add(
 			log.Error(err.Error())
 			log.Debugf(
 				"failed to estimate gas for tx: from: %v, to: %v, value: %v",
-				c.from.String(),
+				from.String(),
 				to.String(),
 				value.String(),
 			)
@@ -297,19 +720,27 @@ func (c *Client) add(
 			} else {
 				return common.Hash{}, err
 			}
+		} else {
+			gas = c.withGasEstimationMargin(gas)
 		}
 	}
 
-	// Calculate id
+	// Calculate id, the nonce provided by the caller is part of it so the same payload can fill several nonces
+	var nonce uint64
+	if opts.nonce != nil {
+		nonce = *opts.nonce
+	}
 	var tx *ethTypes.Transaction
 	if sidecar == nil {
 		tx = ethTypes.NewTx(&ethTypes.LegacyTx{
+			Nonce: nonce,
 			To:    to,
 			Value: value,
 			Data:  data,
 		})
 	} else {
 		tx = ethTypes.NewTx(&ethTypes.BlobTx{
+			Nonce:      nonce,
 			To:         *to,
 			Value:      uint256.MustFromBig(value),
 			Data:       data,
@@ -319,18 +750,31 @@ func (c *Client) add(
 	}
 
 	id := tx.Hash()
+	if len(opts.key) > 0 {
+		id, err = contentHashID(from, to, value, data, opts.key)
+		if err != nil {
+			return common.Hash{}, err
+		}
+	}
 
 	// create monitored tx
 	mTx := types.MonitoredTx{
-		ID: id, From: c.from, To: to,
+		ID: id, From: from, To: to, Nonce: nonce,
 		Value: value, Data: data,
 		Gas: gas, GasPrice: gasPrice, GasOffset: gasOffset,
 		BlobSidecar:  sidecar,
 		BlobGas:      tx.BlobGas(),
 		BlobGasPrice: blobFeeCap, GasTipCap: gasTipCap,
-		Status:      types.MonitoredTxStatusCreated,
-		History:     make(map[common.Hash]bool),
-		EstimateGas: estimateGas,
+		Status:       types.MonitoredTxStatusCreated,
+		History:      make(map[common.Hash]bool),
+		EstimateGas:  estimateGas,
+		FixedFees:    fixedFees,
+		PrivateRelay: opts.privateRelay,
+		FixedNonce:   opts.nonce != nil,
+
+		ValidUntilBlock: opts.validUntilBlock,
+		Priority:        opts.priority,
+		NoReplace:       opts.noReplace,
 	}
 
 	// add to storage
@@ -344,339 +788,1737 @@ func (c *Client) add(
 	mTxLog := log.WithFields("types.MonitoredTx", mTx.ID, "createdAt", mTx.CreatedAt)
 	mTxLog.Infof("created")
 
+	if c.cfg.SendOnAdd {
+		// the tx is already stored, so if it can't be sent now the monitoring loop sends it in the next cycle
+		if err := c.sendOnAdd(ctx, mTx.ID); err != nil {
+			mTxLog.Warnf("failed to send tx on add, it will be sent in the next monitoring cycle: %v", err)
+		}
+	}
+
 	return id, nil
 }
 
-// Remove a transaction from the monitored txs
-func (c *Client) Remove(ctx context.Context, id common.Hash) error {
-	return translateError(c.storage.Remove(ctx, id))
-}
+// checkDestination checks the destination of a tx being added is in the AllowedDestinations when they are
+// configured, the contract creations are only allowed with AllowContractCreation
+func (c *Client) checkDestination(to *common.Address) error {
+	if len(c.cfg.AllowedDestinations) == 0 {
+		return nil
+	}
 
-// RemoveAll removes all the monitored txs
-func (c *Client) RemoveAll(ctx context.Context) error {
-	return translateError(c.storage.Empty(ctx))
+	if to == nil {
+		if c.cfg.AllowContractCreation {
+			return nil
+		}
+		return fmt.Errorf("%w: contract creation", ErrDestinationNotAllowed)
+	}
+	if !slices.Contains(c.cfg.AllowedDestinations, *to) {
+		return fmt.Errorf("%w: %s", ErrDestinationNotAllowed, to.String())
+	}
+
+	return nil
 }
 
-// ResultsByStatus returns all the results for all the monitored txs matching the provided statuses
-// if the statuses are empty, all the statuses are considered.
-func (c *Client) ResultsByStatus(ctx context.Context,
-	statuses []types.MonitoredTxStatus) ([]types.MonitoredTxResult, error) {
-	mTxs, err := c.storage.GetByStatus(ctx, statuses)
+// sendOnAdd assigns the next nonce of the sender to the created monitored tx, signs it and sends it,
+// moving it to sent so the monitoring loop only needs to follow it
+func (c *Client) sendOnAdd(ctx context.Context, id common.Hash) error {
+	// the monitoring loop doesn't process the monitored tx while it's being sent
+	release, err := c.claimMonitoredTx(id)
 	if err != nil {
-		return nil, translateError(err)
+		return err
 	}
+	defer release()
 
-	results := make([]types.MonitoredTxResult, 0, len(mTxs))
+	mTx, err := c.storage.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get monitored tx: %w", translateError(err))
+	}
 
-	for _, mTx := range mTxs {
-		result, err := c.buildResult(ctx, mTx)
-		if err != nil {
-			return nil, translateError(err)
+	logger := createMonitoredTxLogger(mTx)
+	if c.failIfSimulationReverts(ctx, &mTx, logger) {
+		return nil
+	}
+	if !mTx.FixedNonce {
+		if err := c.reserveNonce(ctx, &mTx); err != nil {
+			return err
 		}
-		results = append(results, result)
 	}
 
-	return results, nil
-}
-
-// Result returns the current result of the transaction execution with all the details
-// if not found returns ErrNotFound
-func (c *Client) Result(ctx context.Context, id common.Hash) (types.MonitoredTxResult, error) {
-	mTx, err := c.storage.Get(ctx, id)
+	signedTx, err := c.etherman.SignTx(ctx, mTx.From, mTx.Tx())
 	if err != nil {
-		return types.MonitoredTxResult{}, translateError(err)
+		return fmt.Errorf("failed to sign tx: %w", err)
+	}
+	if _, err := mTx.AddHistory(signedTx); err != nil && !errors.Is(err, types.ErrAlreadyExists) {
+		return fmt.Errorf("failed to add signed tx %v to monitored tx history: %w", signedTx.Hash().String(), err)
+	}
+	if err := c.storage.Update(ctx, mTx); err != nil {
+		return fmt.Errorf("failed to update monitored tx: %w", translateError(err))
 	}
 
-	res, err := c.buildResult(ctx, mTx)
-	return res, translateError(err)
+	if err := c.broadcast(ctx, &monitoredTxnIteration{MonitoredTx: &mTx}, signedTx); err != nil {
+		return fmt.Errorf("failed to send tx %v: %w", signedTx.Hash().String(), translateError(err))
+	}
+	logger.Infof("signed tx sent to the network on add: %v", signedTx.Hash().String())
+
+	mTx.Status = types.MonitoredTxStatusSent
+	mTx.SentAt = time.Now()
+	if err := c.storage.Update(ctx, mTx); err != nil {
+		return fmt.Errorf("failed to update monitored tx: %w", translateError(err))
+	}
+	c.notifyStatus(ctx, mTx)
+
+	return nil
 }
 
-// setStatusSafe sets the status of a monitored tx to types.MonitoredTxStatusSafe.
-func (c *Client) setStatusSafe(ctx context.Context, id common.Hash) error {
-	mTx, err := c.storage.Get(ctx, id)
+// reserveNonce assigns the next nonce of the sender to the monitored tx and stores it, so the other nonce
+// assignments see it as used. The lock is only held while the nonce is assigned, not while the tx is sent
+func (c *Client) reserveNonce(ctx context.Context, mTx *types.MonitoredTx) error {
+	c.nonceMu.Lock()
+	defer c.nonceMu.Unlock()
+
+	nonce, err := c.nextNonce(ctx, *mTx)
 	if err != nil {
 		return err
 	}
-	mTx.Status = types.MonitoredTxStatusSafe
-	return c.storage.Update(ctx, mTx)
+	mTx.Nonce = nonce
+	if err := c.storage.Update(ctx, *mTx); err != nil {
+		return fmt.Errorf("failed to reserve nonce %d: %w", nonce, translateError(err))
+	}
+	return nil
 }
 
-func (c *Client) buildResult(ctx context.Context, mTx types.MonitoredTx) (types.MonitoredTxResult, error) {
-	history := mTx.HistoryHashSlice()
-	txs := make(map[common.Hash]types.TxResult, len(history))
+// claimMonitoredTx marks the monitored tx as being processed, so neither the monitoring loop nor the other
+// operations process it at the same time, returning ErrMonitoredTxProcessing if it's already being processed.
+// The returned function releases the monitored tx
+func (c *Client) claimMonitoredTx(id common.Hash) (func(), error) {
+	if _, processing := c.processingTxs.LoadOrStore(id, struct{}{}); processing {
+		return nil, fmt.Errorf("%w: %s", ErrMonitoredTxProcessing, id.String())
+	}
+	return func() { c.processingTxs.Delete(id) }, nil
+}
 
-	// Skip blockchain calls for evicted transactions - they were never successfully sent
-	// For evicted transactions, txs map remains empty
-	if mTx.Status != types.MonitoredTxStatusEvicted {
-		for _, txHash := range history {
-			tx, _, err := c.etherman.GetTx(ctx, txHash)
-			if !errors.Is(err, ethereum.NotFound) && err != nil {
-				return types.MonitoredTxResult{}, err
-			}
+// nextNonce returns the next nonce of the sender of the monitored tx, which is the nonce of the network
+// given by the NonceSource unless another active monitored tx of the sender already uses it or a later one
+func (c *Client) nextNonce(ctx context.Context, mTx types.MonitoredTx) (uint64, error) {
+	nonce, err := c.sourceNonce(ctx, mTx.From)
+	if err != nil {
+		return 0, err
+	}
 
-			receipt, err := c.etherman.GetTxReceipt(ctx, txHash)
-			if !errors.Is(err, ethereum.NotFound) && err != nil {
-				return types.MonitoredTxResult{}, err
-			}
+	activeTxs, err := c.storage.Query(ctx, types.MonitoredTxFilter{
+		Statuses: []types.MonitoredTxStatus{types.MonitoredTxStatusCreated, types.MonitoredTxStatusSent},
+		From:     &mTx.From,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get active txs of sender %s: %w", mTx.From, translateError(err))
+	}
+	for _, activeTx := range activeTxs {
+		if activeTx.ID != mTx.ID && activeTx.Nonce >= nonce {
+			nonce = activeTx.Nonce + 1
+		}
+	}
 
-			revertMessage, err := c.etherman.GetRevertMessage(ctx, tx)
-			if !errors.Is(err, ethereum.NotFound) && err != nil && err.Error() != ErrExecutionReverted.Error() {
-				return types.MonitoredTxResult{}, err
-			}
+	return nonce, nil
+}
 
-			txs[txHash] = types.TxResult{
-				Tx:            tx,
-				Receipt:       receipt,
-				RevertMessage: revertMessage,
-			}
-		}
+// sourceNonce returns the nonce of the sender new nonces are assigned from, given by the nonce
+// provider if it's set or by the network according to the NonceSource configuration otherwise
+func (c *Client) sourceNonce(ctx context.Context, sender common.Address) (uint64, error) {
+	if c.nonceProvider == nil {
+		return NewNetworkNonceProvider(c.etherman, c.cfg.NonceSource).Nonce(ctx, sender)
 	}
 
-	result := types.MonitoredTxResult{
-		ID:                 mTx.ID,
-		To:                 mTx.To,
-		Nonce:              mTx.Nonce,
-		Value:              mTx.Value,
-		Data:               mTx.Data,
-		MinedAtBlockNumber: mTx.BlockNumber,
-		Status:             mTx.Status,
-		Txs:                txs,
+	nonce, err := c.nonceProvider.Nonce(ctx, sender)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get nonce for sender %s from the nonce provider: %w", sender, err)
 	}
+	return nonce, nil
+}
 
-	return result, nil
+// contentHashID calculates a monitored tx ID over the sender, to, value, data and the caller key
+func contentHashID(from common.Address, to *common.Address, value *big.Int,
+	data []byte, key []byte) (common.Hash, error) {
+	encoded, err := rlp.EncodeToBytes([]interface{}{from, to, value, data, key})
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to encode tx content to calculate the id: %w", err)
+	}
+	return crypto.Keccak256Hash(encoded), nil
 }
 
-// Start will start the tx management, reading txs from storage,
-// send then to the blockchain and keep monitoring them until they
-// get mined
-func (c *Client) Start() {
-	// If no persistence file is uses check L1 for pending txs
-	if c.cfg.StoragePath == "" && c.cfg.ReadPendingL1Txs {
-		pendingTxs, err := pendingL1Txs(c.cfg.Etherman.URL, c.from, c.cfg.Etherman.HTTPHeaders)
-		if err != nil {
-			log.Errorf("failed to get pending txs from L1: %v", err)
-		}
+// Remove a transaction from the monitored txs
+func (c *Client) Remove(ctx context.Context, id common.Hash) error {
+	return translateError(c.storage.Remove(ctx, id))
+}
 
-		log.Infof("%d L1 pending Txs found", len(pendingTxs))
+// RemoveAll removes all the monitored txs
+func (c *Client) RemoveAll(ctx context.Context) error {
+	return translateError(c.storage.Empty(ctx))
+}
 
-		for _, mTx := range pendingTxs {
-			err := c.storage.Add(context.Background(), mTx)
-			if err != nil {
-				log.Errorf("failed to add pending tx to storage: %v", err)
+// RemoveByStatus removes the monitored txs with any of the provided statuses, returning how many were removed.
+// Removing txs still in flight (created, sent, mined or safe) loses track of them, so these statuses are
+// refused with ErrNonTerminalStatus unless force is true
+func (c *Client) RemoveByStatus(ctx context.Context, statuses []types.MonitoredTxStatus, force bool) (int, error) {
+	if !force {
+		for _, status := range statuses {
+			if !status.IsTerminal() {
+				return 0, fmt.Errorf("%w: %s", ErrNonTerminalStatus, status)
 			}
 		}
 	}
 
-	// infinite loop to manage txs as they arrive
-	c.ctx, c.cancel = context.WithCancel(context.Background())
-
-	for {
-		select {
-		case <-c.ctx.Done():
-			return
-		case <-time.After(c.cfg.FrequencyToMonitorTxs.Duration):
-			err := c.monitorTxs(context.Background())
-			if err != nil {
-				c.logErrorAndWait("failed to monitor txs: %v", err)
-			}
-			err = c.waitMinedTxToBeSafe(context.Background())
-			if err != nil {
-				c.logErrorAndWait("failed to wait safe tx to be finalized: %v", err)
-			}
-			err = c.waitSafeTxToBeFinalized(context.Background())
-			if err != nil {
-				c.logErrorAndWait("failed to wait safe tx to be finalized: %v", err)
-			}
-		}
+	removed, err := c.storage.RemoveByStatus(ctx, statuses)
+	if err != nil {
+		return 0, translateError(err)
 	}
-}
+	log.Infof("%d monitored txs removed with statuses %v", removed, statuses)
 
-// Stop stops the monitored tx management
-func (c *Client) Stop() {
-	c.cancel()
+	return removed, nil
 }
 
-// monitorTxs processes all pending monitored txs
-func (c *Client) monitorTxs(ctx context.Context) error {
-	iterations, err := c.getMonitoredTxnIteration(ctx)
+// Export writes all the monitored txs, including blob sidecars and history, to the provided
+// writer as a stream of JSON objects, one per line, so they can be imported later
+func (c *Client) Export(ctx context.Context, w io.Writer) error {
+	mTxs, err := c.storage.GetByStatus(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to get monitored txs: %w", translateError(err))
+		return fmt.Errorf("failed to get monitored txs to export: %w", translateError(err))
 	}
 
-	log.Debugf("found %v monitored tx to process", len(iterations))
+	encoder := json.NewEncoder(w)
+	for _, mTx := range mTxs {
+		if err := encoder.Encode(mTx); err != nil {
+			return fmt.Errorf("failed to export monitored tx %v: %w", mTx.ID.String(), err)
+		}
+	}
 
-	wg := sync.WaitGroup{}
-	wg.Add(len(iterations))
-	for _, mTx := range iterations {
-		mTx := mTx // force variable shadowing to avoid pointer conflicts
-		go func(c *Client, mTx *monitoredTxnIteration) {
-			mTxLogger := createMonitoredTxLogger(*mTx.MonitoredTx)
-			defer func(mTxLogger *log.Logger) {
-				if err := recover(); err != nil {
-					mTxLogger.Errorf("monitoring recovered from this err: %v", err)
-				}
-				wg.Done()
-			}(mTxLogger)
-			c.monitorTx(ctx, mTx, mTxLogger)
-		}(c, mTx)
+	log.Infof("%d monitored txs exported", len(mTxs))
+
+	return nil
+}
+
+// Import reads a stream of monitored txs written by Export and stores them,
+// existing monitored txs with the same ID are replaced. IDs and timestamps are preserved.
+func (c *Client) Import(ctx context.Context, r io.Reader) error {
+	decoder := json.NewDecoder(r)
+	imported := 0
+	for {
+		var mTx types.MonitoredTx
+		err := decoder.Decode(&mTx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to decode monitored tx to import: %w", err)
+		}
+
+		if mTx.History == nil {
+			mTx.History = make(map[common.Hash]bool)
+		}
+
+		// the existing monitored tx is replaced, removing it first to keep the imported timestamps.
+		// Both are done atomically, so a failure can't lose the existing monitored tx
+		err = c.storage.WithTx(ctx, func(storage types.StorageInterface) error {
+			if err := storage.Remove(ctx, mTx.ID); err != nil && !errors.Is(err, types.ErrNotFound) {
+				return err
+			}
+			return storage.Add(ctx, mTx)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to import monitored tx %v: %w", mTx.ID.String(), translateError(err))
+		}
+		imported++
 	}
-	wg.Wait()
+
+	log.Infof("%d monitored txs imported", imported)
 
 	return nil
 }
 
-// waitMinedTxToBeSafe checks all mined monitored txs and wait to set the tx as safe
-func (c *Client) waitMinedTxToBeSafe(ctx context.Context) error {
-	statusesFilter := []types.MonitoredTxStatus{types.MonitoredTxStatusMined}
-	mTxs, err := c.storage.GetByStatus(ctx, statusesFilter)
+// ResultsByStatus returns all the results for all the monitored txs matching the provided statuses
+// if the statuses are empty, all the statuses are considered.
+func (c *Client) ResultsByStatus(ctx context.Context,
+	statuses []types.MonitoredTxStatus) ([]types.MonitoredTxResult, error) {
+	mTxs, err := c.resultsStorage().GetByStatus(ctx, statuses)
 	if err != nil {
-		return fmt.Errorf("failed to get mined monitored txs: %w", translateError(err))
+		return nil, translateError(err)
 	}
 
-	log.Debugf("found %v mined monitored tx to process", len(mTxs))
+	results := make([]types.MonitoredTxResult, 0, len(mTxs))
 
-	var safeBlockNumber uint64
-	if c.cfg.SafeStatusL1NumberOfBlocks > 0 {
-		// Overwrite the number of blocks to consider a tx as safe
-		currentBlockNumber, err := c.etherman.GetLatestBlockNumber(ctx)
+	for _, mTx := range mTxs {
+		result, err := c.buildResult(ctx, mTx)
 		if err != nil {
-			return fmt.Errorf("failed to get latest block number: %w", translateError(err))
+			return nil, translateError(err)
 		}
+		results = append(results, result)
+	}
 
-		safeBlockNumber = currentBlockNumber - c.cfg.SafeStatusL1NumberOfBlocks
-	} else {
-		// Get Safe block Number
-		safeBlockNumber, err = l1_check_block.L1SafeFetch.BlockNumber(ctx, c.etherman)
+	if err := c.setConfirmations(ctx, results); err != nil {
+		return nil, translateError(err)
+	}
+
+	return results, nil
+}
+
+// StreamResultsByStatus streams the results of the monitored txs matching the provided statuses as they are
+// built, so the callers can process large sets incrementally instead of waiting for all of them. If the statuses
+// are empty, all the statuses are considered. The channel is closed once all the results are sent, after the
+// item carrying the error that stopped the stream, or when the context is done
+func (c *Client) StreamResultsByStatus(ctx context.Context,
+	statuses []types.MonitoredTxStatus) <-chan types.MonitoredTxResultOrError {
+	stream := make(chan types.MonitoredTxResultOrError)
+
+	go func() {
+		defer close(stream)
+
+		send := func(item types.MonitoredTxResultOrError) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case stream <- item:
+				return item.Err == nil
+			}
+		}
+
+		mTxs, err := c.resultsStorage().GetByStatus(ctx, statuses)
 		if err != nil {
-			return fmt.Errorf("failed to get safe block number: %v", err)
+			send(types.MonitoredTxResultOrError{Err: translateError(err)})
+			return
 		}
-	}
 
-	for _, mTx := range mTxs {
-		if mTx.BlockNumber.Uint64() <= safeBlockNumber {
-			mTxLogger := createMonitoredTxLogger(mTx)
-			mTxLogger.Infof("safe")
-			mTx.Status = types.MonitoredTxStatusSafe
-			err := c.storage.Update(ctx, mTx)
+		for _, mTx := range mTxs {
+			if ctx.Err() != nil {
+				return
+			}
+
+			result, err := c.buildResult(ctx, mTx)
 			if err != nil {
-				return fmt.Errorf("failed to update mined monitored tx: %w", translateError(err))
+				send(types.MonitoredTxResultOrError{Err: translateError(err)})
+				return
+			}
+			results := []types.MonitoredTxResult{result}
+			if err := c.setConfirmations(ctx, results); err != nil {
+				send(types.MonitoredTxResultOrError{Err: translateError(err)})
+				return
+			}
+
+			if !send(types.MonitoredTxResultOrError{Result: results[0]}) {
+				return
 			}
 		}
-	}
+	}()
 
-	return nil
+	return stream
 }
 
-// waitSafeTxToBeFinalized checks all safe monitored txs and wait the number of
-// l1 blocks configured to finalize the tx
-func (c *Client) waitSafeTxToBeFinalized(ctx context.Context) error {
-	statusesFilter := []types.MonitoredTxStatus{types.MonitoredTxStatusSafe}
-	mTxs, err := c.storage.GetByStatus(ctx, statusesFilter)
+// Result returns the current result of the transaction execution with all the details
+// if not found returns ErrNotFound
+func (c *Client) Result(ctx context.Context, id common.Hash) (types.MonitoredTxResult, error) {
+	mTx, err := c.resultsStorage().Get(ctx, id)
 	if err != nil {
-		return fmt.Errorf("failed to get safe monitored txs: %w", translateError(err))
+		return types.MonitoredTxResult{}, translateError(err)
 	}
 
-	log.Debugf("found %v safe monitored tx to process", len(mTxs))
+	res, err := c.buildResult(ctx, mTx)
+	if err != nil {
+		return types.MonitoredTxResult{}, translateError(err)
+	}
 
-	var finaLizedBlockNumber uint64
-	if c.cfg.SafeStatusL1NumberOfBlocks > 0 {
-		// Overwrite the number of blocks to consider a tx as finalized
-		currentBlockNumber, err := c.etherman.GetLatestBlockNumber(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to get latest block number: %w", translateError(err))
-		}
+	results := []types.MonitoredTxResult{res}
+	if err := c.setConfirmations(ctx, results); err != nil {
+		return types.MonitoredTxResult{}, translateError(err)
+	}
 
-		finaLizedBlockNumber = currentBlockNumber - c.cfg.FinalizedStatusL1NumberOfBlocks
-	} else {
-		// Get Network Default value
-		finaLizedBlockNumber, err = l1_check_block.L1FinalizedFetch.BlockNumber(ctx, c.etherman)
-		if err != nil {
-			return fmt.Errorf("failed to get finalized block number: %w", translateError(err))
-		}
+	return results[0], nil
+}
+
+// setConfirmations sets the confirmations of the mined results when IncludeConfirmations is enabled,
+// requesting the latest block number once for all of them
+func (c *Client) setConfirmations(ctx context.Context, results []types.MonitoredTxResult) error {
+	if !c.cfg.IncludeConfirmations {
+		return nil
 	}
 
-	for _, mTx := range mTxs {
-		if mTx.BlockNumber.Uint64() <= finaLizedBlockNumber {
-			mTxLogger := createMonitoredTxLogger(mTx)
-			mTxLogger.Infof("finalized")
-			mTx.Status = types.MonitoredTxStatusFinalized
-			err := c.storage.Update(ctx, mTx)
+	var latestBlockNumber *uint64
+	for i := range results {
+		minedAt := results[i].MinedAtBlockNumber
+		if minedAt == nil {
+			continue
+		}
+		if latestBlockNumber == nil {
+			blockNumber, err := c.etherman.GetLatestBlockNumber(ctx)
 			if err != nil {
-				return fmt.Errorf("failed to update safe monitored tx: %w", translateError(err))
+				return fmt.Errorf("failed to get latest block number: %w", err)
 			}
+			latestBlockNumber = &blockNumber
+		}
+		if minedAt.IsUint64() && *latestBlockNumber >= minedAt.Uint64() {
+			results[i].Confirmations = *latestBlockNumber - minedAt.Uint64() + 1
 		}
 	}
 
 	return nil
 }
 
-func curlCommandForTx(signedTx *ethTypes.Transaction) string {
-	data, err := signedTx.MarshalBinary()
+// hasMinConfirmationsForMined checks the tx of the successful receipt has the MinConfirmationsForMined
+// required to set its monitored tx as mined, counting the block it was mined in as the first confirmation
+func (c *Client) hasMinConfirmationsForMined(ctx context.Context, receipt *ethTypes.Receipt) (bool, error) {
+	if c.cfg.MinConfirmationsForMined <= 1 {
+		return true, nil
+	}
+	if receipt.BlockNumber == nil || !receipt.BlockNumber.IsUint64() {
+		return false, nil
+	}
+
+	latestBlockNumber, err := c.etherman.GetLatestBlockNumber(ctx)
 	if err != nil {
-		return "err: fails signedTx.MarshalBinary: " + err.Error()
+		return false, fmt.Errorf("failed to get latest block number: %w", translateError(err))
 	}
-	return fmt.Sprintf(`curl -X POST --data '{"jsonrpc":"2.0","method":"eth_sendRawTransaction","params":["%s"],"id":1}'
-		 -H "Content-Type: application/json" <YOUR_ETHEREUM_NODE_URL>`,
-		hexutil.Encode(data))
+	minedAt := receipt.BlockNumber.Uint64()
+	if latestBlockNumber < minedAt {
+		return false, nil
+	}
+
+	return latestBlockNumber-minedAt+1 >= c.cfg.MinConfirmationsForMined, nil
 }
 
-// monitorTx does all the monitoring steps to the monitored tx
-func (c *Client) monitorTx(ctx context.Context, mTx *monitoredTxnIteration, logger *log.Logger) {
-	var err error
-	logger.Info("processing")
+// VerifyHistory rebuilds the tx from the stored fields of the monitored tx, signs it and checks
+// the resulting hash is in its history, returning ErrHistoryMismatch if it isn't. The last tx
+// sent is always built from the stored fields, so a mismatch means they were changed without
+// sending the tx again. Monitored txs without history are not verified.
+func (c *Client) VerifyHistory(ctx context.Context, id common.Hash) error {
+	mTx, err := c.storage.Get(ctx, id)
+	if err != nil {
+		return translateError(err)
+	}
 
-	// Check if max retries is configured and if this transaction has exceeded the limit
-	if c.cfg.EstimateGasMaxRetries > 0 && mTx.RetryCount >= c.cfg.EstimateGasMaxRetries {
-		logger.Debugf("transaction exceeded max retries (%d), evicting from tx manager", c.cfg.EstimateGasMaxRetries)
-		mTx.Status = types.MonitoredTxStatusEvicted
-		err = c.storage.Update(ctx, *mTx.MonitoredTx)
-		if err != nil {
-			logger.Errorf("failed to update monitored tx to evicted status: %v", err)
-		}
-		return
+	if len(mTx.History) == 0 {
+		return nil
 	}
 
-	var signedTx *ethTypes.Transaction
-	if !mTx.confirmed {
-		// review tx and increase gas and gas price if needed
-		if mTx.Status == types.MonitoredTxStatusSent {
-			err := c.reviewMonitoredTxGas(ctx, mTx, logger)
-			if err != nil {
-				logger.Errorf("failed to review monitored tx: %v", err)
-				// Increment retry count when gas review fails
-				mTx.RetryCount++
-				logger.Debugf("incremented retry count to %d after gas review failure", mTx.RetryCount)
-				updateErr := c.storage.Update(ctx, *mTx.MonitoredTx)
-				if updateErr != nil {
-					logger.Errorf("failed to update retry count after gas review failure: %v", updateErr)
-				}
-				return
-			}
-		}
+	_, err = c.lastSentTx(ctx, mTx)
+	return err
+}
 
-		// rebuild transaction
-		tx := mTx.Tx()
-		logger.Debugf("unsigned tx %v created", tx.Hash().String())
+// lastSentTx signs the tx built from the stored fields of the monitored tx, which is the last tx sent,
+// returning ErrHistoryMismatch if the resulting tx is not in its history
+func (c *Client) lastSentTx(ctx context.Context, mTx types.MonitoredTx) (*ethTypes.Transaction, error) {
+	signedTx, err := c.etherman.SignTx(ctx, mTx.From, mTx.Tx())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign tx: %w", err)
+	}
 
-		// sign tx
-		signedTx, err = c.etherman.SignTx(ctx, mTx.From, tx)
-		if err != nil {
-			logger.Errorf("failed to sign tx %v: %v", tx.Hash().String(), err)
-			return
-		}
-		logger.Debugf("signed tx %v created", signedTx.Hash().String())
+	if _, found := mTx.History[signedTx.Hash()]; !found {
+		return nil, fmt.Errorf("%w: tx %s built from the stored fields of monitored tx %s is not in its history",
+			ErrHistoryMismatch, signedTx.Hash().String(), mTx.ID.String())
+	}
 
-		// add tx to monitored tx history
-		found, err := mTx.AddHistory(signedTx)
-		if found {
-			logger.Infof("signed tx already existed in the history")
-		} else if err != nil {
-			logger.Errorf("failed to add signed tx %v to monitored tx history: %v", signedTx.Hash().String(), err)
-			return
-		} else {
-			// update monitored tx changes into storage
-			err = c.storage.Update(ctx, *mTx.MonitoredTx)
-			if err != nil {
+	return signedTx, nil
+}
+
+// PredictedTxHash returns the hash of the signed tx built from the current fields of the monitored tx, the
+// hash expected on-chain unlike its ID, which is the hash of the unsigned tx. Once sent, it's the hash of the
+// last tx sent as stored in its LastTxHash, so the signers whose signatures are not deterministic don't
+// report a hash never broadcast. ErrNonceNotAssigned is returned while the monitoring didn't assign the
+// nonce of the tx yet, since the hash depends on it
+func (c *Client) PredictedTxHash(ctx context.Context, id common.Hash) (common.Hash, error) {
+	mTx, err := c.storage.Get(ctx, id)
+	if err != nil {
+		return common.Hash{}, translateError(err)
+	}
+	if mTx.Status == types.MonitoredTxStatusCreated && !mTx.FixedNonce {
+		return common.Hash{}, fmt.Errorf("%w: monitored tx %s", ErrNonceNotAssigned, id.String())
+	}
+	// the txs sent before the last tx hash was stored are signed again
+	if mTx.Status != types.MonitoredTxStatusCreated && mTx.LastTxHash != nil {
+		return *mTx.LastTxHash, nil
+	}
+
+	signedTx, err := c.etherman.SignTx(ctx, mTx.From, mTx.Tx())
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to sign tx: %w", err)
+	}
+
+	return signedTx.Hash(), nil
+}
+
+// PendingInfo checks whether the last tx sent for the monitored tx, the one built from its stored fields,
+// is known by the network and still pending in the pool of the node. A tx sent but not found was
+// dropped by the node or not propagated yet. Monitored txs without history report no tx
+func (c *Client) PendingInfo(ctx context.Context, id common.Hash) (types.PendingInfo, error) {
+	mTx, err := c.storage.Get(ctx, id)
+	if err != nil {
+		return types.PendingInfo{}, translateError(err)
+	}
+
+	info := types.PendingInfo{ID: id}
+	if len(mTx.History) == 0 {
+		return info, nil
+	}
+
+	signedTx, err := c.lastSentTx(ctx, mTx)
+	if err != nil {
+		return types.PendingInfo{}, err
+	}
+	info.TxHash = signedTx.Hash()
+
+	_, isPending, err := c.etherman.GetTx(ctx, info.TxHash)
+	if errors.Is(err, ethereum.NotFound) {
+		return info, nil
+	}
+	if err != nil {
+		return types.PendingInfo{}, fmt.Errorf("failed to get tx %s: %w", info.TxHash.String(), translateError(err))
+	}
+	info.Found = true
+	info.IsPending = isPending
+
+	return info, nil
+}
+
+// FailureReason returns the revert message of the failed monitored tx, fetching only the receipts of its
+// history to find the canonical failed one and the revert message of that tx, instead of the tx, receipt
+// and revert message of every tx of the history required by Result. An empty message means the failed tx
+// didn't reveal its revert reason. ErrInvalidStatus is returned when the monitored tx is not failed
+func (c *Client) FailureReason(ctx context.Context, id common.Hash) (string, error) {
+	mTx, err := c.storage.Get(ctx, id)
+	if err != nil {
+		return "", translateError(err)
+	}
+	if mTx.Status != types.MonitoredTxStatusFailed {
+		return "", fmt.Errorf("%w: monitored tx %s is %s, not failed", ErrInvalidStatus, id.String(), mTx.Status)
+	}
+
+	var failedReceipt *ethTypes.Receipt
+	for _, txHash := range mTx.HistoryHashSlice() {
+		receipt, err := c.etherman.GetTxReceipt(ctx, txHash)
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to get receipt of tx %s: %w", txHash.String(), translateError(err))
+		}
+		if receipt.Status == ethTypes.ReceiptStatusFailed && isCanonicalReceipt(receipt, failedReceipt) {
+			failedReceipt = receipt
+		}
+	}
+	if failedReceipt == nil {
+		return "", fmt.Errorf("%w: no failed receipt in the history of monitored tx %s", ErrNotFound, id.String())
+	}
+
+	tx, _, err := c.etherman.GetTx(ctx, failedReceipt.TxHash)
+	if err != nil {
+		return "", fmt.Errorf("failed to get tx %s: %w", failedReceipt.TxHash.String(), translateError(err))
+	}
+
+	revertMessage, err := c.etherman.GetRevertMessage(ctx, tx)
+	if err != nil && err.Error() != ErrExecutionReverted.Error() {
+		return "", fmt.Errorf("failed to get revert message of tx %s: %w", failedReceipt.TxHash.String(), translateError(err))
+	}
+
+	return revertMessage, nil
+}
+
+// ForceResend signs the pending monitored tx again with the provided gas price, ignoring the suggested
+// gas price and the MaxGasPriceLimit, sends it and records the new tx in the history.
+// The gas price is used as the fee cap of the dynamic fee and blob txs. A created tx is assigned the next nonce
+// of its sender first, unless it was provided by the caller. ErrMonitoredTxProcessing is returned
+// while the monitoring loop is processing the monitored tx, so the request can be retried later
+func (c *Client) ForceResend(ctx context.Context, id common.Hash, gasPrice *big.Int) error {
+	if gasPrice == nil {
+		return errors.New("the gas price to force the resend must be provided")
+	}
+	release, err := c.claimMonitoredTx(id)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	mTx, err := c.storage.Get(ctx, id)
+	if err != nil {
+		return translateError(err)
+	}
+	if mTx.Status != types.MonitoredTxStatusCreated && mTx.Status != types.MonitoredTxStatusSent {
+		return fmt.Errorf("%w: %s", ErrTerminalMonitoredTx, mTx.Status)
+	}
+	if mTx.NoReplace && len(mTx.History) > 0 {
+		return fmt.Errorf("%w: it was already sent", ErrNoReplace)
+	}
+
+	logger := createMonitoredTxLogger(mTx)
+	if c.cfg.MaxGasPriceLimit > 0 && gasPrice.Cmp(new(big.Int).SetUint64(c.cfg.MaxGasPriceLimit)) == 1 {
+		logger.Warnf("forced gas price %v is above the max gas price limit %d", gasPrice, c.cfg.MaxGasPriceLimit)
+	}
+
+	logger.Infof("forcing resend, GasPrice changed from %v to %v", mTx.GasPrice, gasPrice)
+	mTx.GasPrice = new(big.Int).Set(gasPrice)
+	if mTx.GasTipCap != nil && mTx.GasTipCap.Cmp(gasPrice) == 1 {
+		mTx.GasTipCap = new(big.Int).Set(gasPrice)
+	}
+
+	// the nonce of a created tx is assigned by each monitoring cycle, so the stored one can be stale
+	if mTx.Status == types.MonitoredTxStatusCreated && !mTx.FixedNonce {
+		if err := c.reserveNonce(ctx, &mTx); err != nil {
+			return err
+		}
+	}
+
+	signedTx, err := c.etherman.SignTx(ctx, mTx.From, mTx.Tx())
+	if err != nil {
+		return fmt.Errorf("failed to sign tx: %w", err)
+	}
+	if _, err := mTx.AddHistory(signedTx); err != nil && !errors.Is(err, types.ErrAlreadyExists) {
+		return fmt.Errorf("failed to add signed tx %v to monitored tx history: %w", signedTx.Hash().String(), err)
+	}
+	if err := c.storage.Update(ctx, mTx); err != nil {
+		return fmt.Errorf("failed to update monitored tx: %w", translateError(err))
+	}
+
+	if err := c.broadcast(ctx, &monitoredTxnIteration{MonitoredTx: &mTx}, signedTx); err != nil {
+		return fmt.Errorf("failed to send tx %v: %w", signedTx.Hash().String(), translateError(err))
+	}
+	logger.Infof("forced tx sent to the network: %v", signedTx.Hash().String())
+
+	if mTx.Status == types.MonitoredTxStatusCreated {
+		mTx.Status = types.MonitoredTxStatusSent
+		mTx.SentAt = time.Now()
+		if err := c.storage.Update(ctx, mTx); err != nil {
+			return fmt.Errorf("failed to update monitored tx: %w", translateError(err))
+		}
+		c.notifyStatus(ctx, mTx)
+	}
+
+	return nil
+}
+
+// Retry moves a failed or evicted monitored tx back to created, keeping its ID and payload, so it's sent
+// again with a new nonce in the next monitoring cycle. The history, the retry count and the send attempts are
+// cleared and the gas is estimated again in that cycle, unless it was provided by the caller. The txs with a
+// nonce provided by the caller are refused with ErrInvalidStatus, as they can't be sent with a new one.
+// The txs of the history of an evicted tx could still be mined, so it's refused with ErrNonceNotConsumed until
+// its nonce is consumed by another tx, and with ErrInvalidStatus if a tx of its history was mined.
+// ErrMonitoredTxProcessing is returned while the monitoring loop is processing the monitored tx
+func (c *Client) Retry(ctx context.Context, id common.Hash) error {
+	release, err := c.claimMonitoredTx(id)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	var mTx types.MonitoredTx
+	err = c.storage.WithTx(ctx, func(storage types.StorageInterface) error {
+		mTx, err = storage.Get(ctx, id)
+		if err != nil {
+			return translateError(err)
+		}
+		if mTx.Status != types.MonitoredTxStatusFailed && mTx.Status != types.MonitoredTxStatusEvicted {
+			return fmt.Errorf("%w: only failed or evicted txs can be retried, status %s", ErrInvalidStatus, mTx.Status)
+		}
+		if mTx.FixedNonce {
+			return fmt.Errorf("%w: txs with a fixed nonce can't be retried", ErrInvalidStatus)
+		}
+		if mTx.Status == types.MonitoredTxStatusEvicted && len(mTx.History) > 0 {
+			if err := c.checkEvictedHistorySettled(ctx, mTx); err != nil {
+				return err
+			}
+		}
+
+		// the gas is estimated by the next monitoring cycle
+		if mTx.EstimateGas {
+			mTx.Gas = 0
+		}
+		mTx.Status = types.MonitoredTxStatusCreated
+		mTx.BlockNumber = nil
+		mTx.BlockHash = nil
+		mTx.RetryCount = 0
+		mTx.SendAttempts = 0
+		mTx.History = make(map[common.Hash]bool)
+		mTx.LastTxHash = nil
+		mTx.SentAt = time.Time{}
+		mTx.MinedAt = time.Time{}
+		mTx.FinalizedAt = time.Time{}
+		if err := storage.Update(ctx, mTx); err != nil {
+			return fmt.Errorf("failed to update monitored tx: %w", translateError(err))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	createMonitoredTxLogger(mTx).Infof("retried, status changed to %v", mTx.Status)
+	return nil
+}
+
+// checkEvictedHistorySettled checks the nonce of an evicted monitored tx was consumed by another tx, so
+// none of the txs of its history can be mined after it's retried with a new nonce executing it twice
+func (c *Client) checkEvictedHistorySettled(ctx context.Context, mTx types.MonitoredTx) error {
+	nonce, err := c.etherman.CurrentNonce(ctx, mTx.From)
+	if err != nil {
+		return fmt.Errorf("failed to get the nonce of sender %s: %w", mTx.From.String(), translateError(err))
+	}
+	if nonce <= mTx.Nonce {
+		return fmt.Errorf("%w: the txs of the history with nonce %d can still be mined", ErrNonceNotConsumed, mTx.Nonce)
+	}
+
+	for txHash := range mTx.History {
+		mined, _, err := c.etherman.CheckTxWasMined(ctx, txHash)
+		if err != nil {
+			return fmt.Errorf("failed to check if tx %s was mined: %w", txHash.String(), translateError(err))
+		}
+		if mined {
+			return fmt.Errorf("%w: tx %s of the history was mined", ErrInvalidStatus, txHash.String())
+		}
+	}
+	return nil
+}
+
+// MarkFinalized sets a mined or safe monitored tx as finalized in the provided block without waiting for
+// the safe and finalized blocks to reach it. It's meant for the operators, to reconcile the txs an external
+// system already confirmed as final. The block can't be ahead of the latest one, otherwise
+// ErrImplausibleBlockNumber is returned, and ErrInvalidStatus is returned when the monitored tx is not mined.
+// ErrMonitoredTxProcessing is returned while the monitoring loop is processing the monitored tx
+func (c *Client) MarkFinalized(ctx context.Context, id common.Hash, blockNumber uint64) error {
+	release, err := c.claimMonitoredTx(id)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	mTx, err := c.storage.Get(ctx, id)
+	if err != nil {
+		return translateError(err)
+	}
+	if mTx.Status != types.MonitoredTxStatusMined && mTx.Status != types.MonitoredTxStatusSafe {
+		return fmt.Errorf("%w: only mined or safe txs can be marked as finalized, status %s",
+			ErrInvalidStatus, mTx.Status)
+	}
+
+	if blockNumber == 0 {
+		return fmt.Errorf("%w: the genesis block can't include txs", ErrImplausibleBlockNumber)
+	}
+	latestBlockNumber, err := c.etherman.GetLatestBlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block number: %w", translateError(err))
+	}
+	if blockNumber > latestBlockNumber {
+		return fmt.Errorf("%w: block %d is ahead of the latest block %d",
+			ErrImplausibleBlockNumber, blockNumber, latestBlockNumber)
+	}
+
+	mTxLogger := createMonitoredTxLogger(mTx)
+	if mTx.BlockNumber != nil && mTx.BlockNumber.Uint64() != blockNumber {
+		mTxLogger.Warnf("marked as finalized in block %d, but it was mined in block %d",
+			blockNumber, mTx.BlockNumber.Uint64())
+		mTx.BlockHash = nil
+	}
+	mTx.Status = types.MonitoredTxStatusFinalized
+	mTx.BlockNumber = new(big.Int).SetUint64(blockNumber)
+	mTx.FinalizedAt = time.Now()
+	if err := c.updateWithRetries(ctx, mTx); err != nil {
+		return fmt.Errorf("failed to update monitored tx: %w", translateError(err))
+	}
+
+	mTxLogger.Infof("marked as finalized in block %d", blockNumber)
+	c.notifyStatus(ctx, mTx)
+	c.removeFinalized(ctx, mTx, mTxLogger)
+	return nil
+}
+
+// setStatusSafe sets the status of a monitored tx to types.MonitoredTxStatusSafe.
+func (c *Client) setStatusSafe(ctx context.Context, id common.Hash) error {
+	mTx, err := c.storage.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	mTx.Status = types.MonitoredTxStatusSafe
+	if err := c.storage.Update(ctx, mTx); err != nil {
+		return err
+	}
+	c.notifyStatus(ctx, mTx)
+	return nil
+}
+
+// revertStatusSafe sets the status of a safe monitored tx back to types.MonitoredTxStatusMined, so its
+// result is processed again
+func (c *Client) revertStatusSafe(ctx context.Context, id common.Hash) error {
+	mTx, err := c.storage.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if mTx.Status != types.MonitoredTxStatusSafe {
+		return nil
+	}
+	mTx.Status = types.MonitoredTxStatusMined
+	return c.storage.Update(ctx, mTx)
+}
+
+func (c *Client) buildResult(ctx context.Context, mTx types.MonitoredTx) (types.MonitoredTxResult, error) {
+	if result, found := c.resultCache.get(mTx); found {
+		return result, nil
+	}
+
+	history := mTx.HistoryHashSlice()
+	txs := make(map[common.Hash]types.TxResult, len(history))
+
+	// Skip blockchain calls for evicted transactions - they were never successfully sent
+	// For evicted transactions, txs map remains empty
+	if mTx.Status != types.MonitoredTxStatusEvicted {
+		var err error
+		txs, err = c.historyTxResults(ctx, history)
+		if err != nil {
+			return types.MonitoredTxResult{}, err
+		}
+	}
+
+	result := types.MonitoredTxResult{
+		ID:                 mTx.ID,
+		To:                 mTx.To,
+		Nonce:              mTx.Nonce,
+		Value:              mTx.Value,
+		Data:               mTx.Data,
+		MinedAtBlockNumber: mTx.BlockNumber,
+		Status:             mTx.Status,
+		Txs:                txs,
+		FeeHistory:         mTx.FeeHistory,
+		LastTxHash:         mTx.LastTxHash,
+		SentAt:             mTx.SentAt,
+		MinedAt:            mTx.MinedAt,
+		FinalizedAt:        mTx.FinalizedAt,
+		Reason:             mTx.Reason,
+	}
+
+	c.resultCache.set(mTx, result, c.resultCacheTTL(mTx.Status))
+
+	return result, nil
+}
+
+// historyTxResults gets the tx, receipt and revert message of each hash of the history, in a single batch
+// call if BatchResultCalls is set and the node supports it, otherwise one by one
+func (c *Client) historyTxResults(ctx context.Context, history []common.Hash) (map[common.Hash]types.TxResult, error) {
+	if c.cfg.BatchResultCalls && len(history) > 0 {
+		txs, err := c.batchedHistoryTxResults(ctx, history)
+		if !errors.Is(err, etherman.ErrBatchCallsNotSupported) {
+			return txs, err
+		}
+		log.Debugf("batch calls not supported by the node, getting the history txs one by one: %v", err)
+	}
+
+	txs := make(map[common.Hash]types.TxResult, len(history))
+	for _, txHash := range history {
+		tx, _, err := c.etherman.GetTx(ctx, txHash)
+		if !errors.Is(err, ethereum.NotFound) && err != nil {
+			return nil, err
+		}
+
+		receipt, err := c.etherman.GetTxReceipt(ctx, txHash)
+		if !errors.Is(err, ethereum.NotFound) && err != nil {
+			return nil, err
+		}
+
+		revertMessage, err := c.etherman.GetRevertMessage(ctx, tx)
+		if !errors.Is(err, ethereum.NotFound) && err != nil && err.Error() != ErrExecutionReverted.Error() {
+			return nil, err
+		}
+
+		txs[txHash] = types.TxResult{
+			Tx:            tx,
+			Receipt:       receipt,
+			RevertMessage: revertMessage,
+			RawTx:         rawTx(tx),
+		}
+	}
+
+	return txs, nil
+}
+
+// batchedHistoryTxResults gets the txs and receipts of the history in a single batch call. The revert
+// message is only requested for the failed receipts, the only ones that can have it
+func (c *Client) batchedHistoryTxResults(
+	ctx context.Context,
+	history []common.Hash,
+) (map[common.Hash]types.TxResult, error) {
+	historyTxs, receipts, err := c.etherman.GetTxsAndReceipts(ctx, history)
+	if err != nil {
+		return nil, err
+	}
+	if len(historyTxs) != len(history) || len(receipts) != len(history) {
+		return nil, fmt.Errorf("batch call returned %d txs and %d receipts for %d hashes",
+			len(historyTxs), len(receipts), len(history))
+	}
+
+	txs := make(map[common.Hash]types.TxResult, len(history))
+	for i, txHash := range history {
+		tx, receipt := historyTxs[i], receipts[i]
+
+		var revertMessage string
+		if tx != nil && receipt != nil && receipt.Status == ethTypes.ReceiptStatusFailed {
+			revertMessage, err = c.etherman.GetRevertMessage(ctx, tx)
+			if !errors.Is(err, ethereum.NotFound) && err != nil && err.Error() != ErrExecutionReverted.Error() {
+				return nil, err
+			}
+		}
+
+		txs[txHash] = types.TxResult{
+			Tx:            tx,
+			Receipt:       receipt,
+			RevertMessage: revertMessage,
+			RawTx:         rawTx(tx),
+		}
+	}
+
+	return txs, nil
+}
+
+// Start will start the tx management, reading txs from storage,
+// send then to the blockchain and keep monitoring them until they
+// get mined
+func (c *Client) Start() {
+	// If no persistence file is uses check L1 for pending txs
+	if c.cfg.StoragePath == "" && c.cfg.ReadPendingL1Txs {
+		pendingTxs, skipped, err := pendingL1Txs(c.cfg.Etherman.URL, c.from, c.cfg.Etherman.HTTPHeaders)
+		if err != nil {
+			log.Errorf("failed to get pending txs from L1: %v", err)
+		}
+
+		log.Infof("%d L1 pending Txs found", len(pendingTxs))
+
+		recovered := 0
+		for _, mTx := range pendingTxs {
+			err := c.storage.Add(context.Background(), mTx)
+			if err != nil {
+				log.Errorf("failed to add pending tx to storage: %v", err)
+				skipped++
+				continue
+			}
+			recovered++
+		}
+
+		log.Infof("L1 pending txs recovery finished: %d recovered, %d skipped", recovered, skipped)
+	}
+
+	// infinite loop to manage txs as they arrive
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+
+	if c.cfg.StorageMaintenanceInterval.Duration > 0 {
+		go c.maintainStorage(c.ctx)
+	}
+
+	if c.cfg.NonceReconciliationInterval.Duration > 0 {
+		go c.reconcileNoncesPeriodically(c.ctx)
+	}
+
+	if c.cfg.PersistCheckpoint {
+		c.restoreCheckpoint(context.Background())
+	}
+
+	if _, err := c.checkOrphanedTxs(context.Background()); err != nil {
+		log.Errorf("failed to check orphaned txs: %v", err)
+	}
+
+	// txs sent before a restart may have been mined while we were down,
+	// so they are promoted before the monitoring loop re-sends them
+	if err := c.reconcileSentTxs(context.Background()); err != nil {
+		log.Errorf("failed to reconcile sent txs: %v", err)
+	}
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-time.After(c.cfg.FrequencyToMonitorTxs.Duration):
+			err := c.monitorTxs(c.ctx)
+			if err != nil {
+				c.logErrorAndWait("failed to monitor txs: %v", err)
+			}
+			err = c.waitMinedTxToBeSafe(context.Background())
+			if err != nil {
+				c.logErrorAndWait("failed to wait safe tx to be finalized: %v", err)
+			}
+			err = c.waitSafeTxToBeFinalized(context.Background())
+			if err != nil {
+				c.logErrorAndWait("failed to wait safe tx to be finalized: %v", err)
+			}
+			err = c.checkDrained(context.Background())
+			if err != nil {
+				c.logErrorAndWait("failed to check if the tx manager is drained: %v", err)
+			}
+		}
+	}
+}
+
+// Stop stops the monitored tx management
+func (c *Client) Stop() {
+	c.cancel()
+}
+
+// checkOrphanedTxs reports the pending monitored txs whose sender has no configured signer, which
+// can't be signed, evicting them when the OrphanedTxPolicy is evict. It returns the orphaned txs
+func (c *Client) checkOrphanedTxs(ctx context.Context) ([]common.Hash, error) {
+	signers, err := c.etherman.PublicAddress()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the signers: %w", err)
+	}
+	senders, err := c.storage.DistinctSenders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the senders of the monitored txs: %w", translateError(err))
+	}
+
+	orphaned := make([]common.Hash, 0)
+	for _, sender := range senders {
+		if slices.Contains(signers, sender) {
+			continue
+		}
+
+		mTxs, err := c.storage.Query(ctx, types.MonitoredTxFilter{
+			Statuses: []types.MonitoredTxStatus{types.MonitoredTxStatusCreated, types.MonitoredTxStatusSent},
+			From:     &sender,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the pending monitored txs of sender %s: %w",
+				sender.String(), translateError(err))
+		}
+
+		for _, mTx := range mTxs {
+			mTxLogger := createMonitoredTxLogger(mTx)
+			orphaned = append(orphaned, mTx.ID)
+			if c.cfg.OrphanedTxPolicy != OrphanedTxPolicyEvict {
+				mTxLogger.Errorf("ORPHANED TX: no signer configured for sender %s, the tx can't be sent", sender.String())
+				continue
+			}
+
+			mTxLogger.Warnf("ORPHANED TX: no signer configured for sender %s, evicting the tx", sender.String())
+			c.evict(ctx, &monitoredTxnIteration{MonitoredTx: &mTx}, mTxLogger)
+		}
+	}
+
+	return orphaned, nil
+}
+
+// reconcileSentTxs checks the history of all the sent monitored txs and sets
+// as mined the ones with a tx already mined successfully. The txs being processed are skipped
+// and the failure of a tx doesn't stop the reconciliation of the others
+func (c *Client) reconcileSentTxs(ctx context.Context) error {
+	statusesFilter := []types.MonitoredTxStatus{types.MonitoredTxStatusSent}
+	mTxs, err := c.storage.GetByStatus(ctx, statusesFilter)
+	if err != nil {
+		return fmt.Errorf("failed to get sent monitored txs: %w", translateError(err))
+	}
+
+	log.Debugf("found %v sent monitored tx to reconcile", len(mTxs))
+
+	errs := make([]error, 0)
+	for _, mTx := range mTxs {
+		if err := c.reconcileSentTx(ctx, mTx); err != nil {
+			createMonitoredTxLogger(mTx).Errorf("failed to reconcile sent tx: %v", err)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// reconcileSentTx sets as mined the sent monitored tx when a tx of its history was already mined successfully,
+// unless the monitored tx is being processed
+func (c *Client) reconcileSentTx(ctx context.Context, mTx types.MonitoredTx) error {
+	release, err := c.claimMonitoredTx(mTx.ID)
+	if err != nil {
+		log.Debugf("skipping the reconciliation of monitored tx %v: %v", mTx.ID.String(), err)
+		return nil
+	}
+	defer release()
+
+	mTxLogger := createMonitoredTxLogger(mTx)
+	canonicalReceipt := c.minedReceipt(ctx, mTx, mTxLogger)
+	if canonicalReceipt == nil {
+		return nil
+	}
+	// the txs without enough confirmations are set as mined later by the monitoring
+	confirmed, err := c.hasMinConfirmationsForMined(ctx, canonicalReceipt)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return nil
+	}
+
+	mTxLogger.Infof("tx %v was already mined, status changed to %v",
+		canonicalReceipt.TxHash.String(), types.MonitoredTxStatusMined)
+	mTx.Status = types.MonitoredTxStatusMined
+	mTx.BlockNumber = canonicalReceipt.BlockNumber
+	mTx.BlockHash = receiptBlockHash(canonicalReceipt)
+	mTx.MinedAt = time.Now()
+	if err := c.storage.Update(ctx, mTx); err != nil {
+		return fmt.Errorf("failed to update reconciled monitored tx: %w", translateError(err))
+	}
+	c.notifyStatus(ctx, mTx)
+	return nil
+}
+
+// minedReceipt returns the canonical successful receipt of the txs in the history of the monitored tx,
+// nil if none of them was mined successfully
+func (c *Client) minedReceipt(ctx context.Context, mTx types.MonitoredTx, logger *log.Logger) *ethTypes.Receipt {
+	var canonicalReceipt *ethTypes.Receipt
+	for _, txHash := range mTx.HistoryHashSlice() {
+		mined, receipt, err := c.etherman.CheckTxWasMined(ctx, txHash)
+		if err != nil {
+			logger.Warnf("failed to check if tx %v was mined: %v", txHash.String(), err)
+			continue
+		}
+		if !mined || receipt == nil || receipt.Status != ethTypes.ReceiptStatusSuccessful {
+			continue
+		}
+		if isCanonicalReceipt(receipt, canonicalReceipt) {
+			canonicalReceipt = receipt
+		}
+	}
+	return canonicalReceipt
+}
+
+// RepairBlockNumbers backfills the block number of the mined and safe monitored txs stored without it, e.g.
+// by an older version or after a crash, taking it from the receipt of the tx of their history that was mined.
+// These txs can't be promoted to safe or finalized until they get their block number, so the monitoring
+// skips them. The txs whose receipt is not found are kept as they are and reported in the logs
+func (c *Client) RepairBlockNumbers(ctx context.Context) error {
+	mTxs, err := c.storage.GetByStatus(ctx,
+		[]types.MonitoredTxStatus{types.MonitoredTxStatusMined, types.MonitoredTxStatusSafe})
+	if err != nil {
+		return fmt.Errorf("failed to get mined and safe monitored txs: %w", translateError(err))
+	}
+
+	repaired := 0
+	for _, mTx := range mTxs {
+		if mTx.BlockNumber != nil {
+			continue
+		}
+
+		mTxLogger := createMonitoredTxLogger(mTx)
+		receipt := c.minedReceipt(ctx, mTx, mTxLogger)
+		if receipt == nil || receipt.BlockNumber == nil {
+			mTxLogger.Warnf("no receipt found for the history of the %s tx without block number", mTx.Status)
+			continue
+		}
+
+		mTx.BlockNumber = receipt.BlockNumber
+		mTx.BlockHash = receiptBlockHash(receipt)
+		if err := c.storage.Update(ctx, mTx); err != nil {
+			return fmt.Errorf("failed to update block number of monitored tx %s: %w", mTx.ID.String(), translateError(err))
+		}
+		mTxLogger.Infof("block number %v backfilled from the receipt of tx %v", mTx.BlockNumber, receipt.TxHash.String())
+		repaired++
+	}
+
+	log.Infof("%d monitored txs got their missing block number backfilled", repaired)
+	return nil
+}
+
+// reconcileNoncesPeriodically compares the nonces of the monitored txs with the chain state
+// every configured interval until the context is done
+func (c *Client) reconcileNoncesPeriodically(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.NonceReconciliationInterval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.reconcileNonces(ctx); err != nil {
+				log.Errorf("failed to reconcile nonces: %v", err)
+			}
+		}
+	}
+}
+
+// reconcileNonces compares the nonces of the active monitored txs of each sender with the confirmed
+// (latest) and pending nonces of the chain, reporting the drift found. The nonces of the created txs
+// below the confirmed nonce were already consumed, so they are reassigned when ReassignConsumedNonces is set.
+// The failure of a sender doesn't stop the reconciliation of the others
+func (c *Client) reconcileNonces(ctx context.Context) error {
+	mTxs, err := c.storage.GetByStatus(ctx,
+		[]types.MonitoredTxStatus{types.MonitoredTxStatusCreated, types.MonitoredTxStatusSent})
+	if err != nil {
+		return fmt.Errorf("failed to get monitored txs to reconcile nonces: %w", translateError(err))
+	}
+
+	bySender := make(map[common.Address][]types.MonitoredTx)
+	senders := make([]common.Address, 0)
+	for _, mTx := range mTxs {
+		if _, found := bySender[mTx.From]; !found {
+			senders = append(senders, mTx.From)
+		}
+		bySender[mTx.From] = append(bySender[mTx.From], mTx)
+	}
+
+	errs := make([]error, 0)
+	for _, sender := range senders {
+		if err := c.reconcileSenderNonces(ctx, sender, bySender[sender]); err != nil {
+			log.Errorf("failed to reconcile nonces of sender %s: %v", sender.String(), err)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// reconcileSenderNonces reconciles the nonces of the active monitored txs of a single sender, recording its
+// NonceDrift. The consumed nonces of the txs being processed are not reassigned, the next reconciliation does it
+func (c *Client) reconcileSenderNonces(ctx context.Context, sender common.Address, mTxs []types.MonitoredTx) error {
+	confirmedNonce, err := c.etherman.CurrentNonce(ctx, sender)
+	if err != nil {
+		return fmt.Errorf("failed to get confirmed nonce for sender %s: %w", sender.String(), translateError(err))
+	}
+	pendingNonce, err := c.etherman.PendingNonce(ctx, sender)
+	if err != nil {
+		return fmt.Errorf("failed to get pending nonce for sender %s: %w", sender.String(), translateError(err))
+	}
+
+	nextNonce := pendingNonce
+	sentTxs := 0
+	consumed := make([]types.MonitoredTx, 0)
+	for _, mTx := range mTxs {
+		if mTx.Status == types.MonitoredTxStatusSent {
+			sentTxs++
+		}
+		if mTx.Nonce < confirmedNonce {
+			if mTx.Status == types.MonitoredTxStatusCreated && !mTx.FixedNonce {
+				consumed = append(consumed, mTx)
+			} else {
+				createMonitoredTxLogger(mTx).Warnf("nonce %d is below the confirmed nonce %d of the sender",
+					mTx.Nonce, confirmedNonce)
+			}
+			continue
+		}
+		if mTx.Nonce >= nextNonce {
+			nextNonce = mTx.Nonce + 1
+		}
+	}
+
+	drift := newNonceDrift(sender, confirmedNonce, pendingNonce, uint64(sentTxs))
+	c.nonceDrifts.Store(sender, drift)
+	if drift.Drift != 0 {
+		log.Warnf("nonce drift for sender %s: %d txs pending in the network (confirmed nonce %d, pending nonce %d) "+
+			"and %d sent monitored txs", sender.String(), pendingNonce-confirmedNonce, confirmedNonce, pendingNonce,
+			sentTxs)
+	}
+
+	if len(consumed) == 0 {
+		return nil
+	}
+	if !c.cfg.ReassignConsumedNonces {
+		log.Warnf("%d created monitored txs of sender %s have a nonce below the confirmed nonce %d",
+			len(consumed), sender.String(), confirmedNonce)
+		return nil
+	}
+
+	errs := make([]error, 0)
+	for _, mTx := range consumed {
+		err := c.reassignConsumedNonce(ctx, mTx, nextNonce)
+		switch {
+		case errors.Is(err, ErrMonitoredTxProcessing):
+			log.Debugf("skipping the nonce reassignment: %v", err)
+		case err != nil:
+			errs = append(errs, err)
+		default:
+			nextNonce++
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// reassignConsumedNonce assigns the nonce to the created monitored tx whose nonce was already consumed,
+// returning ErrMonitoredTxProcessing if the monitored tx is being processed
+func (c *Client) reassignConsumedNonce(ctx context.Context, mTx types.MonitoredTx, nonce uint64) error {
+	release, err := c.claimMonitoredTx(mTx.ID)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	createMonitoredTxLogger(mTx).Infof("nonce %d already consumed, reassigned to %d", mTx.Nonce, nonce)
+	mTx.Nonce = nonce
+	if err := c.storage.Update(ctx, mTx); err != nil {
+		return fmt.Errorf("failed to update nonce for tx %v: %w", mTx.ID.String(), translateError(err))
+	}
+	return nil
+}
+
+// replicaReader is implemented by the storages that can serve the reads tolerating the replication lag
+// from read replicas
+type replicaReader interface {
+	ReadReplica() types.StorageInterface
+}
+
+// resultsStorage returns the storage the results of the monitored txs are read from, a read replica when
+// the storage supports them. The results are only reported, so a lagging replica just delays them, while
+// the monitoring and the reads followed by a write always use the primary
+func (c *Client) resultsStorage() types.StorageInterface {
+	if reader, ok := c.storage.(replicaReader); ok {
+		return reader.ReadReplica()
+	}
+	return c.storage
+}
+
+// storageMaintainer is implemented by the storages that support periodic maintenance tasks
+type storageMaintainer interface {
+	Maintenance(ctx context.Context) error
+}
+
+// maintainStorage periodically runs the storage maintenance tasks until the context is done.
+// It runs in its own goroutine so it doesn't block the monitoring loop.
+func (c *Client) maintainStorage(ctx context.Context) {
+	maintainer, ok := c.storage.(storageMaintainer)
+	if !ok {
+		log.Infof("storage doesn't support maintenance tasks")
+		return
+	}
+
+	ticker := time.NewTicker(c.cfg.StorageMaintenanceInterval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			start := time.Now()
+			if err := maintainer.Maintenance(ctx); err != nil {
+				log.Errorf("failed to run storage maintenance: %v", err)
+				continue
+			}
+			log.Debugf("storage maintenance done in %v", time.Since(start))
+		}
+	}
+}
+
+// monitorTxs processes all pending monitored txs. The monitored txs are processed with the given ctx,
+// so the ones still being processed when the cycle deadline is reached are not interrupted. While the
+// circuit breaker is open the txs are not sent, but the mined ones are still processed
+func (c *Client) monitorTxs(ctx context.Context) error {
+	broadcastBlocked := false
+	if c.cfg.CircuitBreakerFailedCycles > 0 {
+		if !c.circuitBreaker.allow(c.circuitBreakerCooldown()) {
+			log.Warnf("circuit breaker open, the monitoring cycle won't send txs")
+			broadcastBlocked = true
+		}
+		defer c.circuitBreaker.endCycle(c.cfg.CircuitBreakerFailedCycles)
+	}
+
+	cycleCtx := ctx
+	if c.cfg.MonitorTxsCycleTimeout.Duration > 0 {
+		var cancel context.CancelFunc
+		cycleCtx, cancel = context.WithTimeout(ctx, c.cfg.MonitorTxsCycleTimeout.Duration)
+		defer cancel()
+	}
+
+	iterations, err := c.getMonitoredTxnIteration(cycleCtx)
+	if err != nil {
+		return fmt.Errorf("failed to get monitored txs: %w", translateError(err))
+	}
+
+	log.Debugf("found %v monitored tx to process", len(iterations))
+
+	wg := sync.WaitGroup{}
+	for _, mTx := range iterations {
+		mTx := mTx // force variable shadowing to avoid pointer conflicts
+		mTx.inCycle = true
+		mTx.broadcastBlocked = broadcastBlocked
+		// the monitored txs still being processed after the deadline of a previous cycle are skipped
+		if _, processing := c.processingTxs.LoadOrStore(mTx.ID, struct{}{}); processing {
+			log.Debugf("monitored tx %v is still being processed by a previous cycle", mTx.ID.String())
+			continue
+		}
+		wg.Add(1)
+		go func(c *Client, mTx *monitoredTxnIteration) {
+			mTxLogger := createMonitoredTxLogger(*mTx.MonitoredTx)
+			defer func(mTxLogger *log.Logger) {
+				if err := recover(); err != nil {
+					mTxLogger.Errorf("monitoring recovered from this err: %v", err)
+				}
+				c.processingTxs.Delete(mTx.ID)
+				wg.Done()
+			}(mTxLogger)
+			c.monitorTx(ctx, mTx, mTxLogger)
+		}(c, mTx)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-cycleCtx.Done():
+		log.Warnf("monitoring cycle deadline reached, the txs not processed yet will be reviewed in the next cycle")
+	}
+
+	return nil
+}
+
+// waitMinedTxToBeSafe checks all mined monitored txs and wait to set the tx as safe
+func (c *Client) waitMinedTxToBeSafe(ctx context.Context) error {
+	statusesFilter := []types.MonitoredTxStatus{types.MonitoredTxStatusMined}
+	mTxs, err := c.storage.GetByStatus(ctx, statusesFilter)
+	if err != nil {
+		return fmt.Errorf("failed to get mined monitored txs: %w", translateError(err))
+	}
+
+	log.Debugf("found %v mined monitored tx to process", len(mTxs))
+
+	var safeBlockNumber uint64
+	if c.cfg.SafeStatusL1NumberOfBlocks > 0 {
+		// Overwrite the number of blocks to consider a tx as safe
+		currentBlockNumber, err := c.etherman.GetLatestBlockNumber(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get latest block number: %w", translateError(err))
+		}
+
+		safeBlockNumber = currentBlockNumber - c.cfg.SafeStatusL1NumberOfBlocks
+	} else {
+		// Get Safe block Number
+		safeBlockNumber, err = l1_check_block.L1SafeFetch.BlockNumber(ctx, c.etherman)
+		if err != nil {
+			return fmt.Errorf("failed to get safe block number: %v", err)
+		}
+	}
+
+	for _, mTx := range mTxs {
+		if mTx.BlockNumber == nil {
+			createMonitoredTxLogger(mTx).Warnf("mined tx without block number, it can't be set as safe " +
+				"until RepairBlockNumbers backfills it")
+			continue
+		}
+		if mTx.BlockNumber.Uint64() <= safeBlockNumber {
+			mTxLogger := createMonitoredTxLogger(mTx)
+			reorged, err := c.minedBlockReorged(ctx, mTx)
+			if err != nil {
+				return err
+			}
+			if reorged {
+				if err := c.resetReorgedTx(ctx, mTx, mTxLogger); err != nil {
+					return err
+				}
+				continue
+			}
+			mTxLogger.Infof("safe")
+			mTx.Status = types.MonitoredTxStatusSafe
+			err = c.updateWithRetries(ctx, mTx)
+			if err != nil {
+				return fmt.Errorf("failed to update mined monitored tx: %w", translateError(err))
+			}
+			c.notifyStatus(ctx, mTx)
+		}
+	}
+
+	return nil
+}
+
+// waitSafeTxToBeFinalized checks all safe monitored txs and wait the number of
+// l1 blocks configured to finalize the tx
+func (c *Client) waitSafeTxToBeFinalized(ctx context.Context) error {
+	cycleStartedAt := time.Now()
+
+	var (
+		finaLizedBlockNumber uint64
+		err                  error
+	)
+	if c.cfg.SafeStatusL1NumberOfBlocks > 0 {
+		// Overwrite the number of blocks to consider a tx as finalized
+		currentBlockNumber, err := c.etherman.GetLatestBlockNumber(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get latest block number: %w", translateError(err))
+		}
+
+		finaLizedBlockNumber = currentBlockNumber - c.cfg.FinalizedStatusL1NumberOfBlocks
+	} else {
+		// Get Network Default value
+		finaLizedBlockNumber, err = l1_check_block.L1FinalizedFetch.BlockNumber(ctx, c.etherman)
+		if err != nil {
+			return fmt.Errorf("failed to get finalized block number: %w", translateError(err))
+		}
+	}
+
+	mTxs, err := c.safeTxsToFinalize(ctx, finaLizedBlockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get safe monitored txs: %w", translateError(err))
+	}
+
+	log.Debugf("found %v safe monitored tx to process", len(mTxs))
+
+	for _, mTx := range mTxs {
+		if mTx.BlockNumber == nil {
+			createMonitoredTxLogger(mTx).Warnf("safe tx without block number, it can't be set as finalized " +
+				"until RepairBlockNumbers backfills it")
+			continue
+		}
+		if mTx.BlockNumber.Uint64() <= finaLizedBlockNumber {
+			mTxLogger := createMonitoredTxLogger(mTx)
+			reorged, err := c.minedBlockReorged(ctx, mTx)
+			if err != nil {
+				return err
+			}
+			if reorged {
+				if err := c.resetReorgedTx(ctx, mTx, mTxLogger); err != nil {
+					return err
+				}
+				continue
+			}
+			mTxLogger.Infof("finalized")
+			mTx.Status = types.MonitoredTxStatusFinalized
+			mTx.FinalizedAt = time.Now()
+			err = c.updateWithRetries(ctx, mTx)
+			if err != nil {
+				return fmt.Errorf("failed to update safe monitored tx: %w", translateError(err))
+			}
+			c.notifyStatus(ctx, mTx)
+			c.removeFinalized(ctx, mTx, mTxLogger)
+		}
+	}
+
+	if c.cfg.PersistCheckpoint {
+		checkpoint := types.Checkpoint{LastCycleAt: cycleStartedAt, LastFinalizedBlock: finaLizedBlockNumber}
+		c.checkpoint = &checkpoint
+		if err := c.storage.SetCheckpoint(ctx, checkpoint); err != nil {
+			return fmt.Errorf("failed to persist the checkpoint: %w", translateError(err))
+		}
+	}
+
+	return nil
+}
+
+// removeFinalized removes the finalized monitored tx from the storage when RemoveAfterFinalized is enabled.
+// It must be called once the hooks of the finalized status ran, so they always observe the stored tx
+func (c *Client) removeFinalized(ctx context.Context, mTx types.MonitoredTx, logger *log.Logger) {
+	if !c.cfg.RemoveAfterFinalized || mTx.Status != types.MonitoredTxStatusFinalized {
+		return
+	}
+
+	if err := c.storage.Remove(ctx, mTx.ID); err != nil && !errors.Is(err, types.ErrNotFound) {
+		logger.Errorf("failed to remove finalized monitored tx: %v", translateError(err))
+		return
+	}
+	logger.Infof("removed after being finalized")
+}
+
+// safeTxsToFinalize returns the safe monitored txs to check against the finalized block number. Without
+// checkpoint all of them are returned, otherwise only the ones mined after the last finalized block
+// processed and the ones set as safe since the last cycle, the others were already checked. The safe txs
+// without block number are only reported when all of them are returned
+func (c *Client) safeTxsToFinalize(ctx context.Context, finalizedBlockNumber uint64) ([]types.MonitoredTx, error) {
+	statusesFilter := []types.MonitoredTxStatus{types.MonitoredTxStatusSafe}
+
+	checkpoint, err := c.loadCheckpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoint == nil {
+		return c.storage.GetByStatus(ctx, statusesFilter)
+	}
+
+	fromBlock := checkpoint.LastFinalizedBlock + 1
+	mTxs, err := c.storage.Query(ctx, types.MonitoredTxFilter{
+		Statuses:  statusesFilter,
+		FromBlock: &fromBlock,
+		ToBlock:   &finalizedBlockNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lastFinalizedBlock := checkpoint.LastFinalizedBlock
+	safeSinceCheckpoint, err := c.storage.Query(ctx, types.MonitoredTxFilter{
+		Statuses:     statusesFilter,
+		ToBlock:      &lastFinalizedBlock,
+		UpdatedAfter: &checkpoint.LastCycleAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(safeSinceCheckpoint, mTxs...), nil
+}
+
+// loadCheckpoint returns the checkpoint of the monitoring, loading it from the storage the first time.
+// It returns nil when PersistCheckpoint is disabled or no checkpoint was persisted yet
+func (c *Client) loadCheckpoint(ctx context.Context) (*types.Checkpoint, error) {
+	if !c.cfg.PersistCheckpoint {
+		return nil, nil
+	}
+	if c.checkpoint != nil {
+		return c.checkpoint, nil
+	}
+
+	checkpoint, err := c.storage.GetCheckpoint(ctx)
+	if errors.Is(err, types.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the checkpoint: %w", translateError(err))
+	}
+	c.checkpoint = &checkpoint
+
+	return c.checkpoint, nil
+}
+
+// restoreCheckpoint loads the checkpoint persisted by the previous run and reports the gap since its last
+// successful cycle, the monitored txs may have changed in the network meanwhile
+func (c *Client) restoreCheckpoint(ctx context.Context) {
+	checkpoint, err := c.loadCheckpoint(ctx)
+	if err != nil {
+		log.Errorf("failed to restore the checkpoint: %v", err)
+		return
+	}
+	if checkpoint == nil {
+		log.Infof("no checkpoint found, all the safe txs are checked in the first cycle")
+		return
+	}
+
+	log.Infof("checkpoint restored: last successful cycle at %v (%v ago), last finalized block processed %d",
+		checkpoint.LastCycleAt, time.Since(checkpoint.LastCycleAt).Round(time.Second), checkpoint.LastFinalizedBlock)
+}
+
+// minedBlockReorged checks if the block the monitored tx was mined in was replaced by a reorg, comparing its
+// stored hash with the hash of the canonical block at the same height. The txs without block hash can't be checked
+func (c *Client) minedBlockReorged(ctx context.Context, mTx types.MonitoredTx) (bool, error) {
+	if mTx.BlockHash == nil || mTx.BlockNumber == nil {
+		return false, nil
+	}
+
+	header, err := c.etherman.HeaderByNumber(ctx, mTx.BlockNumber)
+	if err != nil {
+		return false, fmt.Errorf("failed to get header of block %v: %w", mTx.BlockNumber, translateError(err))
+	}
+
+	return header.Hash() != *mTx.BlockHash, nil
+}
+
+// resetReorgedTx sets the monitored tx mined in a reorged block back to sent, so the monitoring checks
+// its history again and sets it as mined in the block it's included now, or sends it again
+func (c *Client) resetReorgedTx(ctx context.Context, mTx types.MonitoredTx, logger *log.Logger) error {
+	logger.Warnf("block %v the tx was mined in was reorged, its hash %s is not canonical anymore, status changed to %v",
+		mTx.BlockNumber, mTx.BlockHash.String(), types.MonitoredTxStatusSent)
+	mTx.Status = types.MonitoredTxStatusSent
+	mTx.BlockNumber = nil
+	mTx.BlockHash = nil
+	mTx.MinedAt = time.Time{}
+	if err := c.updateWithRetries(ctx, mTx); err != nil {
+		return fmt.Errorf("failed to update reorged monitored tx: %w", translateError(err))
+	}
+	c.notifyStatus(ctx, mTx)
+	return nil
+}
+
+// receiptBlockHash returns the hash of the block of the receipt, nil when it's unknown
+func receiptBlockHash(receipt *ethTypes.Receipt) *common.Hash {
+	if receipt == nil || receipt.BlockHash == (common.Hash{}) {
+		return nil
+	}
+	blockHash := receipt.BlockHash
+	return &blockHash
+}
+
+func curlCommandForTx(signedTx *ethTypes.Transaction) string {
+	data, err := signedTx.MarshalBinary()
+	if err != nil {
+		return "err: fails signedTx.MarshalBinary: " + err.Error()
+	}
+	return fmt.Sprintf(`curl -X POST --data '{"jsonrpc":"2.0","method":"eth_sendRawTransaction","params":["%s"],"id":1}'
+		 -H "Content-Type: application/json" <YOUR_ETHEREUM_NODE_URL>`,
+		hexutil.Encode(data))
+}
+
+// rawTx returns the hex encoded binary representation of the signed tx,
+// empty if the tx is nil or it can't be encoded
+func rawTx(signedTx *ethTypes.Transaction) string {
+	if signedTx == nil {
+		return ""
+	}
+	data, err := signedTx.MarshalBinary()
+	if err != nil {
+		log.Warnf("failed to encode tx %v: %v", signedTx.Hash().String(), err)
+		return ""
+	}
+	return hexutil.Encode(data)
+}
+
+// monitorTx does all the monitoring steps to the monitored tx
+func (c *Client) monitorTx(ctx context.Context, mTx *monitoredTxnIteration, logger *log.Logger) {
+	var err error
+	logger.Info("processing")
+
+	// Check if max retries is configured and if this transaction has exceeded the limit
+	if c.cfg.EstimateGasMaxRetries > 0 && mTx.RetryCount >= c.cfg.EstimateGasMaxRetries {
+		logger.Debugf("transaction exceeded max retries (%d), evicting from tx manager", c.cfg.EstimateGasMaxRetries)
+		c.evict(ctx, mTx, logger)
+		return
+	}
+
+	if !mTx.confirmed && c.deadlinePassed(ctx, mTx, logger) {
+		logger.Infof("the chain passed the deadline of the tx (block %d) before it was mined, evicting it",
+			mTx.ValidUntilBlock)
+		c.evict(ctx, mTx, logger)
+		return
+	}
+
+	if !mTx.confirmed && mTx.NoReplace && mTx.Status == types.MonitoredTxStatusSent {
+		c.monitorNoReplaceTx(ctx, mTx, logger)
+		return
+	}
+
+	if !mTx.confirmed && mTx.broadcastBlocked {
+		logger.Debugf("circuit breaker open, the tx is not sent until the cooldown expires")
+		return
+	}
+
+	var signedTx *ethTypes.Transaction
+	if !mTx.confirmed {
+		// review tx and increase gas and gas price if needed
+		if _, blobFeeTooLow := c.blobFeeTooLow.Load(mTx.ID); mTx.Status == types.MonitoredTxStatusSent || blobFeeTooLow {
+			err := c.reviewMonitoredTxGas(ctx, mTx, logger)
+			if err != nil {
+				logger.Errorf("failed to review monitored tx: %v", err)
+				if isRetryableError(err) {
+					// a timeout says nothing about the tx, so it doesn't count towards the eviction
+					logger.Debugf("retryable gas review failure, retry count kept at %d", mTx.RetryCount)
+					return
+				}
+				// Increment retry count when gas review fails
+				mTx.RetryCount++
+				logger.Debugf("incremented retry count to %d after gas review failure", mTx.RetryCount)
+				updateErr := c.updateWithRetries(ctx, *mTx.MonitoredTx)
+				if updateErr != nil {
+					logger.Errorf("failed to update retry count after gas review failure: %v", updateErr)
+				}
+				return
+			}
+		}
+
+		// rebuild transaction
+		tx := mTx.Tx()
+		logger.Debugf("unsigned tx %v created", tx.Hash().String())
+
+		// sign tx
+		signedTx, err = c.etherman.SignTx(ctx, mTx.From, tx)
+		if err != nil {
+			logger.Errorf("failed to sign tx %v: %v", tx.Hash().String(), err)
+			return
+		}
+		logger.Debugf("signed tx %v created", signedTx.Hash().String())
+
+		// add tx to monitored tx history
+		found, err := mTx.AddHistory(signedTx)
+		// a new tx of a monitored tx already sent replaces the previous one
+		replacement := !found && err == nil && mTx.Status == types.MonitoredTxStatusSent
+		if found {
+			logger.Infof("signed tx already existed in the history")
+		} else if err != nil {
+			logger.Errorf("failed to add signed tx %v to monitored tx history: %v", signedTx.Hash().String(), err)
+			return
+		} else {
+			// update monitored tx changes into storage
+			err = c.updateWithRetries(ctx, *mTx.MonitoredTx)
+			if err != nil {
 				logger.Errorf("failed to update monitored tx: %v", err)
 				return
 			}
@@ -685,20 +2527,77 @@ func (c *Client) monitorTx(ctx context.Context, mTx *monitoredTxnIteration, logg
 		logger.Debugf("Sending Tx: %s", curlCommandForTx(signedTx))
 		// check if the tx is already in the network, if not, send it
 		_, _, err = c.etherman.GetTx(ctx, signedTx.Hash())
+		if errors.Is(err, ethereum.NotFound) && c.inInitialBroadcastGrace(mTx.ID, signedTx.Hash()) {
+			logger.Debugf("signed tx not found in the network yet, waiting for it to propagate before sending it again")
+			return
+		}
 		// if not found, send it tx to the network
 		if errors.Is(err, ethereum.NotFound) {
 			logger.Debugf("signed tx not found in the network")
-			err := c.etherman.SendTx(ctx, signedTx)
+			if c.cfg.CheckSenderBalance && !c.senderCanAfford(ctx, mTx, signedTx, logger) {
+				return
+			}
+			if c.cfg.MaxSendAttempts > 0 && mTx.SendAttempts >= c.cfg.MaxSendAttempts {
+				logger.Warnf("tx was sent %d times without being mined, reaching the max send attempts (%d), evicting it",
+					mTx.SendAttempts, c.cfg.MaxSendAttempts)
+				c.evict(ctx, mTx, logger)
+				return
+			}
+			mTx.SendAttempts++
+			if c.cfg.MaxSendAttempts > 0 {
+				// the attempt is persisted before sending it, so the attempts interrupted by a restart are counted
+				if err := c.updateWithRetries(ctx, *mTx.MonitoredTx); err != nil {
+					logger.Errorf("failed to update the send attempts: %v", err)
+					return
+				}
+			}
+			err := c.broadcast(ctx, mTx, signedTx)
+			if err != nil && c.cfg.RaiseGasOnIntrinsicGasTooLow && !mTx.NoReplace && isIntrinsicGasTooLowError(err) {
+				logger.Warnf("tx %v rejected due to intrinsic gas too low, raising gas to send it again", signedTx.Hash().String())
+				var resentTx *ethTypes.Transaction
+				resentTx, err = c.raiseGasAndResend(ctx, mTx, logger)
+				if err == nil {
+					signedTx = resentTx
+				}
+			}
+			if mTx.BlobSidecar != nil && isBlobFeeTooLowError(err) {
+				logger.Warnf("blob tx %v rejected due to its blob fee cap %v too low, raising it to send it again",
+					signedTx.Hash().String(), mTx.BlobGasPrice)
+				c.blobFeeTooLow.Store(mTx.ID, struct{}{})
+			}
+			if isTxTypeNotSupportedError(err) {
+				if mTx.BlobSidecar != nil {
+					logger.Errorf("blob tx %v rejected, the node doesn't support blob txs, evicting it: %v",
+						signedTx.Hash().String(), err)
+					mTx.Reason = fmt.Sprintf("node doesn't support blob txs: %v", err)
+					c.evict(ctx, mTx, logger)
+					return
+				}
+				if c.cfg.AllowLegacyFallback && mTx.GasTipCap != nil && !mTx.NoReplace && !mTx.TxTypeConverted {
+					logger.Warnf("tx %v rejected due to its type not supported, falling back to a legacy tx",
+						signedTx.Hash().String())
+					var resentTx *ethTypes.Transaction
+					resentTx, err = c.fallbackToLegacyAndResend(ctx, mTx, logger)
+					if err == nil {
+						signedTx = resentTx
+					}
+				}
+			}
 			if err != nil {
 				logger.Warnf("failed to send tx %v to network: %v", signedTx.Hash().String(), err)
 				// Add a warning with a curl command to send the transaction manually
 				logger.Warnf(`To manually send the transaction, use the following curl command:
 						%s"`, curlCommandForTx(signedTx))
 
+				if isRetryableError(err) {
+					logger.Debugf("retryable send failure, retry count kept at %d", mTx.RetryCount)
+					return
+				}
+
 				// Increment retry count when sending fails
 				mTx.RetryCount++
 				logger.Debugf("incremented retry count to %d after send failure", mTx.RetryCount)
-				err = c.storage.Update(ctx, *mTx.MonitoredTx)
+				err = c.updateWithRetries(ctx, *mTx.MonitoredTx)
 				if err != nil {
 					logger.Errorf("failed to update retry count after send failure: %v", err)
 				}
@@ -708,13 +2607,23 @@ func (c *Client) monitorTx(ctx context.Context, mTx *monitoredTxnIteration, logg
 			if mTx.Status == types.MonitoredTxStatusCreated {
 				// update tx status to sent
 				mTx.Status = types.MonitoredTxStatusSent
+				mTx.SentAt = time.Now()
 				logger.Debugf("status changed to %v", string(mTx.Status))
 				// update monitored tx changes into storage
-				err = c.storage.Update(ctx, *mTx.MonitoredTx)
+				err = c.updateWithRetries(ctx, *mTx.MonitoredTx)
 				if err != nil {
 					logger.Errorf("failed to update monitored tx changes: %v", err)
 					return
 				}
+				c.notifyStatus(ctx, *mTx.MonitoredTx)
+			} else if replacement {
+				// the bump schedule only escalates with the replacements actually sent
+				mTx.ResendCount++
+				err = c.updateWithRetries(ctx, *mTx.MonitoredTx)
+				if err != nil {
+					logger.Errorf("failed to update the resend count: %v", err)
+					return
+				}
 			}
 		} else {
 			logger.Warnf("signed tx already found in the network")
@@ -733,26 +2642,11 @@ func (c *Client) monitorTx(ctx context.Context, mTx *monitoredTxnIteration, logg
 			return
 		}
 
-		var txReceipt *ethTypes.Receipt
-		waitingReceiptTimeout := time.Now().Add(c.cfg.GetReceiptMaxTime.Duration)
-		// get tx receipt
-		for {
-			txReceipt, err = c.etherman.GetTxReceipt(ctx, signedTx.Hash())
-			if err != nil {
-				if waitingReceiptTimeout.After(time.Now()) {
-					time.Sleep(c.cfg.GetReceiptWaitInterval.Duration)
-				} else {
-					logger.Warnf(
-						"failed to get tx receipt for tx %v after %v: %v",
-						signedTx.Hash().String(),
-						c.cfg.GetReceiptMaxTime,
-						err,
-					)
-					return
-				}
-			} else {
-				break
-			}
+		// get tx receipt
+		txReceipt, err := c.waitTxReceipt(ctx, signedTx.Hash())
+		if err != nil {
+			logger.Warnf("failed to get tx receipt for tx %v: %v", signedTx.Hash().String(), err)
+			return
 		}
 
 		mTx.lastReceipt = txReceipt
@@ -761,47 +2655,250 @@ func (c *Client) monitorTx(ctx context.Context, mTx *monitoredTxnIteration, logg
 
 	// if mined, check receipt and mark as Failed or Confirmed
 	if mTx.lastReceipt.Status == ethTypes.ReceiptStatusSuccessful {
+		confirmed, err := c.hasMinConfirmationsForMined(ctx, mTx.lastReceipt)
+		if err != nil {
+			logger.Errorf("failed to check the confirmations of the mined tx: %v", err)
+			return
+		}
+		if !confirmed {
+			logger.Debugf("mined tx %v waiting for %d confirmations",
+				mTx.lastReceipt.TxHash.String(), c.cfg.MinConfirmationsForMined)
+			return
+		}
 		mTx.Status = types.MonitoredTxStatusMined
 		mTx.BlockNumber = mTx.lastReceipt.BlockNumber
+		mTx.BlockHash = receiptBlockHash(mTx.lastReceipt)
+		mTx.MinedAt = time.Now()
 		logger.Info("mined")
 	} else {
 		// if we should continue to monitor, we move to the next one and this will
 		// be reviewed in the next monitoring cycle
-		if c.shouldContinueToMonitorThisTx(ctx, mTx.lastReceipt) {
+		continueMonitoring, revertReason := c.shouldContinueToMonitorThisTx(ctx, mTx.lastReceipt)
+		if continueMonitoring && !c.shouldFailStuckTx(mTx, logger) {
 			return
 		}
 		// otherwise we understand this monitored tx has failed
 		mTx.Status = types.MonitoredTxStatusFailed
 		mTx.BlockNumber = mTx.lastReceipt.BlockNumber
+		mTx.BlockHash = receiptBlockHash(mTx.lastReceipt)
+		c.recordRevertReason(revertReason)
 		logger.Info("failed")
 	}
 
+	c.lastBroadcasts.Delete(mTx.ID)
+	c.blobFeeTooLow.Delete(mTx.ID)
+	c.insufficientFunds.Delete(mTx.ID)
+	// a replacement sent before the sender ran out of funds can still be mined
+	if strings.HasPrefix(mTx.Reason, ErrInsufficientFunds.Error()) {
+		mTx.Reason = ""
+	}
+
 	// update monitored tx changes into storage
-	err = c.storage.Update(ctx, *mTx.MonitoredTx)
+	err = c.updateWithRetries(ctx, *mTx.MonitoredTx)
+	if err != nil {
+		logger.Errorf("failed to update monitored tx: %v", err)
+		return
+	}
+	c.notifyStatus(ctx, *mTx.MonitoredTx)
+}
+
+// Simulate executes the monitored tx with an eth_call against the latest state without sending it.
+// If its execution would be reverted, it returns a *types.RevertedError, matching types.ErrReverted, with
+// the revert reason. Blob txs are simulated without their blobs
+func (c *Client) Simulate(ctx context.Context, id common.Hash) error {
+	mTx, err := c.storage.Get(ctx, id)
+	if err != nil {
+		return translateError(err)
+	}
+
+	return c.simulate(ctx, mTx)
+}
+
+// simulate executes the monitored tx with an eth_call, see Simulate
+func (c *Client) simulate(ctx context.Context, mTx types.MonitoredTx) error {
+	_, err := c.etherman.CallContract(ctx, mTx.From, mTx.To, mTx.Value, mTx.Data)
+	if reason, reverted := etherman.RevertReasonFromError(err); reverted {
+		return &types.RevertedError{ID: mTx.ID, Reason: reason}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to simulate tx: %w", translateError(err))
+	}
+
+	return nil
+}
+
+// failIfSimulationReverts simulates the monitored tx before it's sent for the first time when
+// SimulateBeforeSend is enabled, setting it as failed without sending it if its execution would be
+// reverted. It returns true if the tx failed the simulation. The blob txs are not simulated, as the
+// eth_call doesn't carry their blobs, and a simulation that can't be done doesn't prevent the sending
+func (c *Client) failIfSimulationReverts(ctx context.Context, mTx *types.MonitoredTx, logger *log.Logger) bool {
+	if !c.cfg.SimulateBeforeSend || mTx.Status != types.MonitoredTxStatusCreated || mTx.BlobSidecar != nil {
+		return false
+	}
+
+	var revertedErr *types.RevertedError
+	err := c.simulate(ctx, *mTx)
+	if !errors.As(err, &revertedErr) {
+		if err != nil {
+			logger.Warnf("failed to simulate tx before sending it, sending it anyway: %v", err)
+		}
+		return false
+	}
+
+	logger.Infof("tx execution would be reverted (reason: %q), setting it as failed without sending it",
+		revertedErr.Reason)
+	mTx.Status = types.MonitoredTxStatusFailed
+	c.recordRevertReason(revertedErr.Reason)
+	if err := c.updateWithRetries(ctx, *mTx); err != nil {
+		logger.Errorf("failed to update monitored tx to failed status: %v", err)
+		return true
+	}
+	c.notifyStatus(ctx, *mTx)
+
+	return true
+}
+
+// deadlinePassed checks if the latest block is after the block the monitored tx is valid until,
+// a failure getting the latest block is logged and the tx is considered still valid
+func (c *Client) deadlinePassed(ctx context.Context, mTx *monitoredTxnIteration, logger *log.Logger) bool {
+	if mTx.ValidUntilBlock == 0 {
+		return false
+	}
+
+	latestBlockNumber, err := c.etherman.GetLatestBlockNumber(ctx)
 	if err != nil {
+		logger.Errorf("failed to get the latest block number to check the deadline of the tx: %v", err)
+		return false
+	}
+
+	return latestBlockNumber > mTx.ValidUntilBlock
+}
+
+// monitorNoReplaceTx checks a sent no replace tx without sending it again. It's set as failed once its tx
+// is mined with a failed receipt and evicted once NoReplaceTxTTL passes without being mined
+func (c *Client) monitorNoReplaceTx(ctx context.Context, mTx *monitoredTxnIteration, logger *log.Logger) {
+	if mTx.lastReceipt == nil {
+		if time.Since(mTx.CreatedAt) <= c.noReplaceTxTTL() {
+			logger.Debugf("no replace tx not mined yet, waiting for it without sending it again")
+			return
+		}
+		logger.Infof("no replace tx not mined in %v, evicting it", c.noReplaceTxTTL())
+		c.evict(ctx, mTx, logger)
+		return
+	}
+
+	c.lastBroadcasts.Delete(mTx.ID)
+	c.blobFeeTooLow.Delete(mTx.ID)
+	c.insufficientFunds.Delete(mTx.ID)
+	mTx.Status = types.MonitoredTxStatusFailed
+	mTx.BlockNumber = mTx.lastReceipt.BlockNumber
+	mTx.BlockHash = receiptBlockHash(mTx.lastReceipt)
+	logger.Info("failed")
+	if err := c.updateWithRetries(ctx, *mTx.MonitoredTx); err != nil {
 		logger.Errorf("failed to update monitored tx: %v", err)
 		return
 	}
+	c.notifyStatus(ctx, *mTx.MonitoredTx)
+}
+
+// evict sets the monitored tx as evicted, so it's not monitored anymore
+func (c *Client) evict(ctx context.Context, mTx *monitoredTxnIteration, logger *log.Logger) {
+	c.lastBroadcasts.Delete(mTx.ID)
+	c.blobFeeTooLow.Delete(mTx.ID)
+	c.insufficientFunds.Delete(mTx.ID)
+	mTx.Status = types.MonitoredTxStatusEvicted
+	if err := c.updateWithRetries(ctx, *mTx.MonitoredTx); err != nil {
+		logger.Errorf("failed to update monitored tx to evicted status: %v", err)
+		return
+	}
+	c.notifyStatus(ctx, *mTx.MonitoredTx)
+}
+
+// fallbackToLegacyAndResend converts the dynamic fee monitored tx into a legacy tx after the node rejected
+// its type, using the fee cap as gas price, then signs the legacy tx and sends it again. The tx is flagged
+// as converted, so UpgradeLegacyTxs doesn't convert it back
+func (c *Client) fallbackToLegacyAndResend(ctx context.Context, mTx *monitoredTxnIteration,
+	logger *log.Logger) (*ethTypes.Transaction, error) {
+	logger.Infof("monitored tx converted from dynamic fee to legacy, GasPrice %v", mTx.GasPrice)
+	mTx.GasTipCap = nil
+	mTx.TxTypeConverted = true
+
+	signedTx, err := c.etherman.SignTx(ctx, mTx.From, mTx.Tx())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign legacy tx: %w", err)
+	}
+	if _, err := mTx.AddHistory(signedTx); err != nil {
+		return nil, fmt.Errorf("failed to add signed tx %v to monitored tx history: %w", signedTx.Hash().String(), err)
+	}
+	if err := c.updateWithRetries(ctx, *mTx.MonitoredTx); err != nil {
+		return nil, fmt.Errorf("failed to update monitored tx: %w", err)
+	}
+	if err := c.broadcast(ctx, mTx, signedTx); err != nil {
+		return nil, err
+	}
+
+	return signedTx, nil
+}
+
+// raiseGasAndResend estimates the gas of the monitored tx again after the tx was rejected because
+// its gas didn't cover the intrinsic gas, then signs the tx with the raised gas and sends it again
+func (c *Client) raiseGasAndResend(ctx context.Context, mTx *monitoredTxnIteration,
+	logger *log.Logger) (*ethTypes.Transaction, error) {
+	var (
+		gas uint64
+		err error
+	)
+	if mTx.BlobSidecar != nil {
+		gas, err = c.etherman.EstimateGasBlobTx(ctx, mTx.From, mTx.To, mTx.GasPrice, mTx.GasTipCap, mTx.Value, mTx.Data)
+	} else {
+		gas, err = c.etherman.EstimateGas(ctx, mTx.From, mTx.To, mTx.Value, mTx.Data)
+		gas = c.withGasEstimationMargin(gas)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", translateError(err))
+	}
+	if gas <= mTx.Gas {
+		return nil, fmt.Errorf("estimated gas %d is not higher than the current gas %d", gas, mTx.Gas)
+	}
+	logger.Infof("monitored tx Gas raised from %v to %v", mTx.Gas, gas)
+	mTx.Gas = gas
+
+	signedTx, err := c.etherman.SignTx(ctx, mTx.From, mTx.Tx())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign tx with raised gas: %w", err)
+	}
+	if _, err := mTx.AddHistory(signedTx); err != nil {
+		return nil, fmt.Errorf("failed to add signed tx %v to monitored tx history: %w", signedTx.Hash().String(), err)
+	}
+	if err := c.updateWithRetries(ctx, *mTx.MonitoredTx); err != nil {
+		return nil, fmt.Errorf("failed to update monitored tx: %w", err)
+	}
+	if err := c.broadcast(ctx, mTx, signedTx); err != nil {
+		return nil, err
+	}
+
+	return signedTx, nil
 }
 
 // shouldContinueToMonitorThisTx checks the the tx receipt and decides if it should
-// continue or not to monitor the monitored tx related to the tx from this receipt
-func (c *Client) shouldContinueToMonitorThisTx(ctx context.Context, receipt *ethTypes.Receipt) bool {
+// continue or not to monitor the monitored tx related to the tx from this receipt,
+// returning as well the revert message of the failed tx when it's available
+func (c *Client) shouldContinueToMonitorThisTx(ctx context.Context, receipt *ethTypes.Receipt) (bool, string) {
 	// if the receipt has a is successful result, stop monitoring
 	if receipt.Status == ethTypes.ReceiptStatusSuccessful {
-		return false
+		return false, ""
 	}
 
 	tx, _, err := c.etherman.GetTx(ctx, receipt.TxHash)
 	if err != nil {
 		log.Errorf("failed to get tx when monitored tx identified as failed, tx : %v", receipt.TxHash.String(), err)
-		return false
+		return false, ""
 	}
-	_, err = c.etherman.GetRevertMessage(ctx, tx)
+	revertMessage, err := c.etherman.GetRevertMessage(ctx, tx)
 	if err != nil {
 		// if the error when getting the revert message is not identified, continue to monitor
 		if err.Error() == ErrExecutionReverted.Error() {
-			return true
+			return true, ""
 		} else {
 			log.Errorf(
 				"failed to get revert message for monitored tx identified as failed, tx %v: %v",
@@ -811,7 +2908,18 @@ func (c *Client) shouldContinueToMonitorThisTx(ctx context.Context, receipt *eth
 		}
 	}
 	// if nothing weird was found, stop monitoring
-	return false
+	return false, revertMessage
+}
+
+// shouldFailStuckTx checks if the monitored tx must stop being sent and be set as failed according to the
+// stuck tx policy, using the failed receipts of the history counted when the monitored tx was loaded
+func (c *Client) shouldFailStuckTx(mTx *monitoredTxnIteration, logger *log.Logger) bool {
+	if c.cfg.StuckTxPolicy != StuckTxPolicyFail || mTx.failedReceipts < stuckTxFailedReceipts {
+		return false
+	}
+
+	logger.Warnf("tx has %d failed receipts, setting it as failed so the caller can resubmit it", mTx.failedReceipts)
+	return true
 }
 
 // reviewMonitoredTxGas checks if gas fields needs to be updated
@@ -824,152 +2932,620 @@ func (c *Client) reviewMonitoredTxGas(ctx context.Context, mTx *monitoredTxnIter
 		err error
 		gas uint64
 	)
+	previousFees := mTx.Fees(time.Time{})
+
+	if _, blobFeeTooLow := c.blobFeeTooLow.LoadAndDelete(mTx.ID); blobFeeTooLow && isBlobTx {
+		if err := c.raiseBlobFeeCap(ctx, mTx, mTxLogger); err != nil {
+			c.blobFeeTooLow.Store(mTx.ID, struct{}{})
+			return err
+		}
+	}
+
+	if mTx.FixedFees {
+		mTxLogger.Debug("tx is using fixed fees, avoiding gas price update")
+	} else {
+		// get gas price
+		gasPrice, err := c.suggestedGasPrice(ctx)
+		if err != nil {
+			err := fmt.Errorf("failed to get suggested gas price: %w", translateError(err))
+			mTxLogger.Errorf(err.Error())
+			return err
+		}
+
+		if c.cfg.BumpOwnDynamicFees && !isBlobTx && mTx.GasTipCap != nil {
+			// dynamic fee txs bump their own tip and fee cap, a replacement raising only the tip is rejected
+			if err := c.bumpDynamicFees(ctx, mTx, gasPrice, mTxLogger); err != nil {
+				return err
+			}
+		} else {
+			if percentage := c.bumpSchedulePercentage(mTx.ResendCount); percentage > 0 {
+				gasPrice = maxBigInt(gasPrice, c.clampGasPrice(bumpByPercentage(mTx.GasPrice, percentage)))
+				if mTx.GasTipCap != nil {
+					gasTipCap := c.clampGasTipCap(bumpByPercentage(mTx.GasTipCap, percentage))
+					mTxLogger.Infof("monitored tx (blob? %t) GasTipCap bumped %.2f%% from %v to %v",
+						isBlobTx, percentage, mTx.GasTipCap, gasTipCap)
+					mTx.GasTipCap = gasTipCap
+				}
+			}
+			if gasPrice.Cmp(mTx.GasPrice) == 1 {
+				mTxLogger.Infof(
+					"monitored tx (blob? %t) GasPrice updated from %v to %v",
+					isBlobTx,
+					mTx.GasPrice.String(),
+					gasPrice.String(),
+				)
+				mTx.GasPrice = gasPrice
+			}
+			// the tip can't be over the fee cap, otherwise the tx is rejected
+			if mTx.GasTipCap != nil && mTx.GasTipCap.Cmp(mTx.GasPrice) == 1 {
+				mTxLogger.Infof("monitored tx (blob? %t) GasTipCap limited from %v to the GasFeeCap %v",
+					isBlobTx, mTx.GasTipCap, mTx.GasPrice)
+				mTx.GasTipCap = new(big.Int).Set(mTx.GasPrice)
+			}
+		}
+
+		if c.cfg.UpgradeLegacyTxs && !isBlobTx && mTx.GasTipCap == nil && !mTx.TxTypeConverted {
+			if err := c.upgradeLegacyTx(ctx, mTx, previousFees.GasPrice, mTxLogger); err != nil {
+				return err
+			}
+		}
+	}
+
+	// get gas
+	if !mTx.EstimateGas {
+		mTxLogger.Info("tx is using a hardcoded gas, avoiding estimate gas")
+		mTx.RecordFees(time.Now(), previousFees)
+		return nil
+	}
+	reestimateGas := c.shouldReestimateGas(mTx)
+	if mTx.BlobSidecar != nil {
+		// blob gas price estimation
+		header, err := c.latestHeaderWithRetries(ctx)
+		if err != nil {
+			log.Errorf("failed to get header: %v", err)
+			return err
+		}
+		parentNumber := new(big.Int).Sub(header.Number, big.NewInt(1))
+		parentHeader, err := c.headerByNumberWithRetries(ctx, parentNumber)
+		if err != nil {
+			log.Errorf("failed to get parent header: %v", err)
+			return err
+		}
+
+		var blobFeeCap *big.Int
+		if parentHeader.ExcessBlobGas != nil && parentHeader.BlobGasUsed != nil {
+			chainConfig := c.blobChainConfig()
+			parentExcessBlobGas := eip4844.CalcExcessBlobGas(chainConfig, parentHeader, header.Time)
+			blobFeeCap = eip4844.CalcBlobFee(chainConfig, parentHeader)
+			if *header.ExcessBlobGas != parentExcessBlobGas {
+				return fmt.Errorf("invalid excessBlobGas: have %d, want %d", *header.ExcessBlobGas, parentExcessBlobGas)
+			}
+		} else {
+			log.Infof("legacy parent header no blob gas info")
+			blobFeeCap = big.NewInt(params.BlobTxMinBlobGasprice)
+		}
+
+		if !mTx.FixedFees {
+			gasTipCap, err := c.suggestedGasTipCap(ctx)
+			if err != nil {
+				log.Errorf("failed to get gas tip cap: %v", err)
+				return err
+			}
+			gasTipCap = c.clampGasTipCap(gasTipCap)
+
+			if gasTipCap.Cmp(mTx.GasTipCap) == 1 {
+				mTxLogger.Infof("monitored tx (blob? %t) GasTipCap updated from %v to %v", isBlobTx, mTx.GasTipCap, gasTipCap)
+				mTx.GasTipCap = gasTipCap
+			}
+		}
+		if blobFeeCap.Cmp(mTx.BlobGasPrice) == 1 {
+			mTxLogger.Infof("monitored tx (blob? %t) BlobFeeCap updated from %v to %v", isBlobTx, mTx.BlobGasPrice, blobFeeCap)
+			mTx.BlobGasPrice = blobFeeCap
+		}
+
+		if reestimateGas {
+			gas, err = c.etherman.EstimateGasBlobTx(ctx, mTx.From, mTx.To, mTx.GasPrice, mTx.GasTipCap, mTx.Value, mTx.Data)
+			if err != nil {
+				if de, ok := err.(rpc.DataError); ok {
+					err = fmt.Errorf("%w (%v)", translateError(err), de.ErrorData())
+				}
+				err := fmt.Errorf("failed to estimate gas blob tx: %w", translateError(err))
+				mTxLogger.Errorf(err.Error())
+				return err
+			}
+		}
+	} else if reestimateGas {
+		gas, err = c.etherman.EstimateGas(ctx, mTx.From, mTx.To, mTx.Value, mTx.Data)
+		if err != nil {
+			if de, ok := err.(rpc.DataError); ok {
+				err = fmt.Errorf("%w (%v)", err, de.ErrorData())
+			}
+			err := fmt.Errorf("failed to estimate gas: %w", translateError(err))
+			mTxLogger.Errorf(err.Error())
+			return err
+		}
+		gas = c.withGasEstimationMargin(gas)
+	}
+
+	if !reestimateGas {
+		mTxLogger.Debug("reusing the gas of the last estimation, avoiding estimate gas")
+	}
+
+	// the estimations never include the gas offset, so they replace the gas while the offset is kept
+	// and added once on top of it when the tx is built
+
+	// if the last tx ran out of gas, make sure the gas limit is increased at least by the configured multiplier
+	if mTx.ranOutOfGas() && c.cfg.OutOfGasMultiplier > 1 {
+		minGasLimit := uint64(float64(mTx.GasLimit()) * c.cfg.OutOfGasMultiplier)
+		if minGasLimit > mTx.GasOffset && gas < minGasLimit-mTx.GasOffset {
+			gas = minGasLimit - mTx.GasOffset
+		}
+	}
+
+	// check gas
+	if gas > mTx.Gas {
+		mTxLogger.Infof("monitored tx (blob? %t) Gas updated from %v to %v (gas limit %v with offset %v)",
+			isBlobTx, mTx.Gas, gas, gas+mTx.GasOffset, mTx.GasOffset)
+		mTx.Gas = gas
+	}
+
+	mTx.RecordFees(time.Now(), previousFees)
+	err = c.updateWithRetries(ctx, *mTx.MonitoredTx)
+	if err != nil {
+		return fmt.Errorf("failed to update monitored tx changes: %w", err)
+	}
+
+	return nil
+}
+
+// bumpDynamicFees bumps both the tip and the fee cap of a dynamic fee tx once by the replacement bump
+// percentage, since the nodes require both to be bumped to accept the replacement. The tip is raised to the
+// suggested one when it's higher and the fee cap to cover the base fee plus the new tip and the suggested gas
+// price. Then the tip is limited by MaxGasTipCap and the fee cap by MaxGasPriceLimit and GasPriceSanityMax
+func (c *Client) bumpDynamicFees(ctx context.Context, mTx *monitoredTxnIteration, suggestedGasPrice *big.Int,
+	mTxLogger *log.Logger) error {
+	header, err := c.etherman.GetHeaderByNumber(ctx, nil)
+	if err != nil {
+		err := fmt.Errorf("failed to get header: %w", translateError(err))
+		mTxLogger.Errorf(err.Error())
+		return err
+	}
+	suggestedTip, err := c.suggestedGasTipCap(ctx)
+	if err != nil {
+		err := fmt.Errorf("failed to get gas tip cap: %w", translateError(err))
+		mTxLogger.Errorf(err.Error())
+		return err
+	}
+
+	percentage := c.replacementBumpPercentage()
+	gasTipCap := c.clampGasTipCap(maxBigInt(suggestedTip, bumpByPercentage(mTx.GasTipCap, percentage)))
+
+	baseFee := header.BaseFee
+	if baseFee == nil {
+		baseFee = big.NewInt(0)
+	}
+	gasFeeCap := maxBigInt(bumpByPercentage(mTx.GasPrice, percentage), new(big.Int).Add(baseFee, gasTipCap))
+	gasFeeCap = c.clampGasPrice(maxBigInt(gasFeeCap, suggestedGasPrice))
+	if c.cfg.GasPriceSanityMax > 0 {
+		gasFeeCap = minBigInt(gasFeeCap, new(big.Int).SetUint64(c.cfg.GasPriceSanityMax))
+	}
+	gasTipCap = minBigInt(gasTipCap, gasFeeCap)
+
+	mTxLogger.Infof("monitored tx GasTipCap updated from %v to %v and GasFeeCap from %v to %v",
+		mTx.GasTipCap, gasTipCap, mTx.GasPrice, gasFeeCap)
+	mTx.GasTipCap = gasTipCap
+	mTx.GasPrice = gasFeeCap
+	return nil
+}
+
+// replacementBumpPercentage returns the minimum percentage the fees of a tx must be increased to replace it
+func (c *Client) replacementBumpPercentage() float64 {
+	if c.cfg.ReplacementBumpPercentage == 0 {
+		return defaultReplacementBumpPercentage
+	}
+	return float64(c.cfg.ReplacementBumpPercentage)
+}
+
+// bumpSchedulePercentage returns the percentage the fees of a sent tx are increased by on its review,
+// growing with the number of previous reviews up to the max percentage. 0 means the schedule is disabled
+func (c *Client) bumpSchedulePercentage(resendCount uint64) float64 {
+	if c.cfg.BumpScheduleBasePercentage <= 0 {
+		return 0
+	}
+	growthFactor := c.cfg.BumpScheduleGrowthFactor
+	if growthFactor <= 0 {
+		growthFactor = defaultBumpScheduleGrowthFactor
+	}
+	maxPercentage := c.cfg.BumpScheduleMaxPercentage
+	if maxPercentage <= 0 {
+		maxPercentage = defaultBumpScheduleMaxPercentage
+	}
+
+	return math.Min(c.cfg.BumpScheduleBasePercentage*math.Pow(growthFactor, float64(resendCount)), maxPercentage)
+}
+
+// withGasEstimationMargin adds the configured GasEstimationMargin percentage to the gas estimated for a non blob tx
+func (c *Client) withGasEstimationMargin(gas uint64) uint64 {
+	if c.cfg.GasEstimationMargin <= 0 {
+		return gas
+	}
+	return gas + uint64(float64(gas)*c.cfg.GasEstimationMargin/percentageBase)
+}
+
+// bumpByPercentage increases the value by the given percentage, at least by 1
+func bumpByPercentage(value *big.Int, percentage float64) *big.Int {
+	factor := big.NewFloat(1 + percentage/percentageBase)
+	bumped, _ := new(big.Float).Mul(new(big.Float).SetInt(value), factor).Int(nil)
+	if bumped.Cmp(value) <= 0 {
+		bumped = new(big.Int).Add(value, big.NewInt(1))
+	}
+	return bumped
+}
+
+// clampGasPrice limits the gas price to the MaxGasPriceLimit when it's configured
+func (c *Client) clampGasPrice(gasPrice *big.Int) *big.Int {
+	if c.cfg.MaxGasPriceLimit > 0 {
+		maxGasPrice := new(big.Int).SetUint64(c.cfg.MaxGasPriceLimit)
+		if gasPrice.Cmp(maxGasPrice) == 1 {
+			return maxGasPrice
+		}
+	}
+	return gasPrice
+}
+
+// maxBigInt returns the greatest of the two values
+func maxBigInt(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
+
+// minBigInt returns the smallest of the two values
+func minBigInt(a, b *big.Int) *big.Int {
+	if a.Cmp(b) <= 0 {
+		return a
+	}
+	return b
+}
+
+// upgradeLegacyTx converts a legacy monitored tx into a dynamic fee one when the network reports a base fee.
+// The fee cap is set to cover twice the base fee plus the suggested tip, and the legacy txs already
+// sent are kept in the history. The gas price of a legacy tx is both its tip and its fee cap for the nodes,
+// so both are bumped at least by the replacement percentage over the previous gas price to replace it
+// The tx is flagged as converted, so AllowLegacyFallback doesn't convert it back
+func (c *Client) upgradeLegacyTx(ctx context.Context, mTx *monitoredTxnIteration, previousGasPrice *big.Int,
+	mTxLogger *log.Logger) error {
+	header, err := c.etherman.GetHeaderByNumber(ctx, nil)
+	if err != nil {
+		err := fmt.Errorf("failed to get header: %w", translateError(err))
+		mTxLogger.Errorf(err.Error())
+		return err
+	}
+	if header.BaseFee == nil {
+		mTxLogger.Debug("network doesn't report a base fee, keeping the legacy tx")
+		return nil
+	}
+
+	gasTipCap, err := c.suggestedGasTipCap(ctx)
+	if err != nil {
+		err := fmt.Errorf("failed to get gas tip cap: %w", translateError(err))
+		mTxLogger.Errorf(err.Error())
+		return err
+	}
+
+	if previousGasPrice != nil {
+		gasTipCap = maxBigInt(gasTipCap, bumpByPercentage(previousGasPrice, c.replacementBumpPercentage()))
+	}
+
+	gasFeeCap := new(big.Int).Add(new(big.Int).Mul(header.BaseFee, big.NewInt(2)), gasTipCap) //nolint:mnd
+	if mTx.GasPrice != nil && mTx.GasPrice.Cmp(gasFeeCap) == 1 {
+		gasFeeCap = new(big.Int).Set(mTx.GasPrice)
+	}
+
+	mTxLogger.Infof("monitored tx upgraded from legacy to dynamic fee, GasFeeCap %v GasTipCap %v", gasFeeCap, gasTipCap)
+	mTx.GasPrice = gasFeeCap
+	mTx.GasTipCap = gasTipCap
+	mTx.TxTypeConverted = true
+	return nil
+}
+
+// latestHeaderWithRetries gets the latest header retrying the transient failures up to the
+// configured number of retries, doubling the time to wait between them
+func (c *Client) latestHeaderWithRetries(ctx context.Context) (*ethTypes.Header, error) {
+	return c.headerByNumberWithRetries(ctx, nil)
+}
 
-	// get gas price
-	gasPrice, err := c.suggestedGasPrice(ctx)
-	if err != nil {
-		err := fmt.Errorf("failed to get suggested gas price: %w", translateError(err))
-		mTxLogger.Errorf(err.Error())
-		return err
+// headerByNumberWithRetries gets the header of the block number, or the latest one when it's nil,
+// retrying the transient failures like latestHeaderWithRetries does
+func (c *Client) headerByNumberWithRetries(ctx context.Context, number *big.Int) (*ethTypes.Header, error) {
+	backoff := c.cfg.GetHeaderRetryBackoff.Duration
+	for attempt := uint64(0); ; attempt++ {
+		header, err := c.etherman.GetHeaderByNumber(ctx, number)
+		if err == nil {
+			return header, nil
+		}
+		if attempt >= c.cfg.GetHeaderMaxRetries {
+			return nil, err
+		}
+
+		log.Warnf("failed to get header, retrying in %v (%d/%d): %v",
+			backoff, attempt+1, c.cfg.GetHeaderMaxRetries, err)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
 	}
+}
 
-	// check gas price
-	if gasPrice.Cmp(mTx.GasPrice) == 1 {
-		mTxLogger.Infof(
-			"monitored tx (blob? %t) GasPrice updated from %v to %v",
-			isBlobTx,
-			mTx.GasPrice.String(),
-			gasPrice.String(),
-		)
-		mTx.GasPrice = gasPrice
+// suggestedGasTipCap returns the gas tip cap suggested from the fee history of the latest blocks when
+// TipCapFeeHistoryBlocks is configured, or the one suggested by the node otherwise
+func (c *Client) suggestedGasTipCap(ctx context.Context) (*big.Int, error) {
+	if c.cfg.TipCapFeeHistoryBlocks == 0 {
+		return c.etherman.GetSuggestGasTipCap(ctx)
 	}
 
-	// get gas
-	if !mTx.EstimateGas {
-		mTxLogger.Info("tx is using a hardcoded gas, avoiding estimate gas")
+	percentile := c.cfg.TipCapFeeHistoryPercentile
+	if percentile == 0 {
+		percentile = defaultTipCapFeeHistoryPercentile
+	}
+	return c.etherman.SuggestTipCapFromHistory(ctx, c.cfg.TipCapFeeHistoryBlocks, percentile)
+}
+
+// updateWithRetries updates the monitored tx in the storage retrying the failures up to the configured
+// number of retries, doubling the time to wait between them. When the update keeps failing the changes
+// of the monitored tx are lost, so the caller must not act on them: the monitored tx is loaded again
+// from the storage in the next monitoring cycle
+func (c *Client) updateWithRetries(ctx context.Context, mTx types.MonitoredTx) error {
+	return c.retryStorageUpdate(ctx, "monitored tx "+mTx.ID.String(), func() error {
+		return c.storage.Update(ctx, mTx)
+	})
+}
+
+// updateAllWithRetries updates the monitored txs in a single storage transaction, so either all
+// of them or none are updated, retrying the whole transaction like updateWithRetries does
+func (c *Client) updateAllWithRetries(ctx context.Context, mTxs []types.MonitoredTx) error {
+	if len(mTxs) == 0 {
 		return nil
 	}
-	if mTx.BlobSidecar != nil {
-		// blob gas price estimation
-		header, err := c.etherman.GetHeaderByNumber(ctx, nil)
-		if err != nil {
-			log.Errorf("failed to get header: %v", err)
-			return err
-		}
-		parentNumber := new(big.Int).Sub(header.Number, big.NewInt(1))
-		parentHeader, err := c.etherman.GetHeaderByNumber(ctx, parentNumber)
-		if err != nil {
-			log.Errorf("failed to get parent header: %v", err)
-			return err
-		}
 
-		var blobFeeCap *big.Int
-		if parentHeader.ExcessBlobGas != nil && parentHeader.BlobGasUsed != nil {
-			parentExcessBlobGas := eip4844.CalcExcessBlobGas(&params.ChainConfig{}, parentHeader, header.Time)
-			blobFeeCap = eip4844.CalcBlobFee(&params.ChainConfig{}, parentHeader)
-			if *header.ExcessBlobGas != parentExcessBlobGas {
-				return fmt.Errorf("invalid excessBlobGas: have %d, want %d", *header.ExcessBlobGas, parentExcessBlobGas)
+	return c.retryStorageUpdate(ctx, fmt.Sprintf("%d monitored txs", len(mTxs)), func() error {
+		return c.storage.WithTx(ctx, func(storage types.StorageInterface) error {
+			for _, mTx := range mTxs {
+				if err := storage.Update(ctx, mTx); err != nil {
+					return fmt.Errorf("failed to update monitored tx %s: %w", mTx.ID.String(), err)
+				}
 			}
-		} else {
-			log.Infof("legacy parent header no blob gas info")
-			blobFeeCap = big.NewInt(params.BlobTxMinBlobGasprice)
-		}
+			return nil
+		})
+	})
+}
 
-		gasTipCap, err := c.etherman.GetSuggestGasTipCap(ctx)
-		if err != nil {
-			log.Errorf("failed to get gas tip cap: %v", err)
+// retryStorageUpdate runs the update of the storage retrying it up to StorageUpdateMaxRetries
+// times when it fails, doubling the time to wait between them. ErrNotFound is not retried
+func (c *Client) retryStorageUpdate(ctx context.Context, target string, update func() error) error {
+	backoff := c.cfg.StorageUpdateRetryBackoff.Duration
+	for attempt := uint64(0); ; attempt++ {
+		err := update()
+		if err == nil {
+			return nil
+		}
+		if attempt >= c.cfg.StorageUpdateMaxRetries || errors.Is(err, types.ErrNotFound) {
 			return err
 		}
 
-		if gasTipCap.Cmp(mTx.GasTipCap) == 1 {
-			mTxLogger.Infof("monitored tx (blob? %t) GasTipCap updated from %v to %v", isBlobTx, mTx.GasTipCap, gasTipCap)
-			mTx.GasTipCap = gasTipCap
-		}
-		if blobFeeCap.Cmp(mTx.BlobGasPrice) == 1 {
-			mTxLogger.Infof("monitored tx (blob? %t) BlobFeeCap updated from %v to %v", isBlobTx, mTx.BlobGasPrice, blobFeeCap)
-			mTx.BlobGasPrice = blobFeeCap
+		log.Warnf("failed to update %s, retrying in %v (%d/%d): %v",
+			target, backoff, attempt+1, c.cfg.StorageUpdateMaxRetries, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
 		}
+		backoff *= 2
+	}
+}
 
-		gas, err = c.etherman.EstimateGasBlobTx(ctx, mTx.From, mTx.To, mTx.GasPrice, mTx.GasTipCap, mTx.Value, mTx.Data)
-		if err != nil {
-			if de, ok := err.(rpc.DataError); ok {
-				err = fmt.Errorf("%w (%v)", translateError(err), de.ErrorData())
-			}
-			err := fmt.Errorf("failed to estimate gas blob tx: %w", translateError(err))
-			mTxLogger.Errorf(err.Error())
-			return err
+// blobChainConfig returns the chain config used to compute the blob fee of the L1 blocks
+func (c *Client) blobChainConfig() *params.ChainConfig {
+	return c.cfg.Etherman.BlobSchedule.ChainConfig()
+}
+
+// raiseBlobFeeCap raises the blob fee cap of a blob tx rejected because it didn't cover the blob base fee.
+// The new one covers the blob base fee of the latest block and it's bumped at least by the replacement
+// percentage, so the blob tx can replace the previous one
+func (c *Client) raiseBlobFeeCap(ctx context.Context, mTx *monitoredTxnIteration, mTxLogger *log.Logger) error {
+	header, err := c.etherman.GetHeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get header: %w", translateError(err))
+	}
+
+	blobFeeCap := bumpByPercentage(mTx.BlobGasPrice, c.replacementBumpPercentage())
+	if header.ExcessBlobGas != nil {
+		blobFeeCap = maxBigInt(blobFeeCap, eip4844.CalcBlobFee(c.blobChainConfig(), header))
+	}
+	mTxLogger.Infof("monitored tx BlobFeeCap raised from %v to %v after being rejected due to blob fee too low",
+		mTx.BlobGasPrice, blobFeeCap)
+	mTx.BlobGasPrice = blobFeeCap
+
+	return nil
+}
+
+// clampGasTipCap keeps the gas tip cap between the configured minimum and maximum
+func (c *Client) clampGasTipCap(gasTipCap *big.Int) *big.Int {
+	if c.cfg.MinGasTipCap > 0 {
+		minGasTipCap := new(big.Int).SetUint64(c.cfg.MinGasTipCap)
+		if gasTipCap.Cmp(minGasTipCap) < 0 {
+			log.Debugf("gas tip cap %v raised to the minimum %v", gasTipCap.String(), minGasTipCap.String())
+			gasTipCap = minGasTipCap
 		}
-	} else {
-		gas, err = c.etherman.EstimateGas(ctx, mTx.From, mTx.To, mTx.Value, mTx.Data)
-		if err != nil {
-			if de, ok := err.(rpc.DataError); ok {
-				err = fmt.Errorf("%w (%v)", err, de.ErrorData())
-			}
-			err := fmt.Errorf("failed to estimate gas: %w", translateError(err))
-			mTxLogger.Errorf(err.Error())
-			return err
+	}
+
+	if c.cfg.MaxGasTipCap > 0 {
+		maxGasTipCap := new(big.Int).SetUint64(c.cfg.MaxGasTipCap)
+		if gasTipCap.Cmp(maxGasTipCap) > 0 {
+			log.Debugf("gas tip cap %v limited to the maximum %v", gasTipCap.String(), maxGasTipCap.String())
+			gasTipCap = maxGasTipCap
 		}
 	}
 
-	// check gas
-	if gas > mTx.Gas {
-		mTxLogger.Infof("monitored tx (blob? %t) Gas updated from %v to %v", isBlobTx, mTx.Gas, gas)
-		mTx.Gas = gas
+	return gasTipCap
+}
+
+// shouldReestimateGas checks if the gas of the monitored tx must be estimated again when reviewing it,
+// otherwise the gas of the last successful estimation is reused
+func (c *Client) shouldReestimateGas(mTx *monitoredTxnIteration) bool {
+	return c.cfg.ReestimateGasOnReview || mTx.Gas == 0 || mTx.ranOutOfGas()
+}
+
+// simulateCreatedTxs simulates the created monitored txs when SimulateBeforeSend is enabled, failing the ones
+// that would revert. It's done before locking the nonces, so the simulations don't block the other senders.
+// It returns the IDs of the txs that can be sent, nil when the simulation is disabled
+func (c *Client) simulateCreatedTxs(ctx context.Context) (map[common.Hash]bool, error) {
+	if !c.cfg.SimulateBeforeSend {
+		return nil, nil
 	}
 
-	err = c.storage.Update(ctx, *mTx.MonitoredTx)
+	createdTxs, err := c.storage.GetByStatus(ctx, []types.MonitoredTxStatus{types.MonitoredTxStatusCreated})
 	if err != nil {
-		return fmt.Errorf("failed to update monitored tx changes: %w", err)
+		return nil, fmt.Errorf("failed to get txs to simulate: %w", translateError(err))
 	}
 
-	return nil
+	simulated := make(map[common.Hash]bool, len(createdTxs))
+	for _, tx := range createdTxs {
+		tx := tx
+		release, err := c.claimMonitoredTx(tx.ID)
+		if err != nil {
+			continue
+		}
+		if !c.failIfSimulationReverts(ctx, &tx, createMonitoredTxLogger(tx)) {
+			simulated[tx.ID] = true
+		}
+		release()
+	}
+	return simulated, nil
 }
 
 // getMonitoredTxnIteration gets all monitored txs that need to be sent or resent in current monitor iteration
 func (c *Client) getMonitoredTxnIteration(ctx context.Context) ([]*monitoredTxnIteration, error) {
+	// the txs that would revert are failed before getting a nonce, so they don't leave a nonce gap
+	simulated, err := c.simulateCreatedTxs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.nonceMu.Lock()
+	defer c.nonceMu.Unlock()
+
 	txsToUpdate, err := c.storage.GetByStatus(ctx,
 		[]types.MonitoredTxStatus{types.MonitoredTxStatusCreated, types.MonitoredTxStatusSent})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get txs to update nonces: %w", translateError(err))
 	}
 
+	// the txs with a higher priority are processed and assigned nonces first, keeping
+	// the storage order (the order they were added) for the txs with the same priority
+	sort.SliceStable(txsToUpdate, func(i, j int) bool {
+		return txsToUpdate[i].Priority > txsToUpdate[j].Priority
+	})
+
 	iterations := make([]*monitoredTxnIteration, 0, len(txsToUpdate))
-	senderNonces := make(map[common.Address]uint64)
+	// activeNonces keeps the monitored tx using each nonce of each sender to detect double assignments
+	activeNonces := make(map[common.Address]map[uint64]common.Hash)
+	// the nonces are assigned once the nonces kept by the other txs are known, so they are not reused
+	toAssign := make([]*monitoredTxnIteration, 0, len(txsToUpdate))
 
 	for _, tx := range txsToUpdate {
 		tx := tx
 
-		iteration := &monitoredTxnIteration{MonitoredTx: &tx}
-		iterations = append(iterations, iteration)
-
-		updateNonce := iteration.shouldUpdateNonce(ctx, c.etherman)
-		if !updateNonce {
+		// the monitored txs still being processed keep their nonces, they are reserved so no other
+		// monitored tx gets them, but they are neither reassigned nor saved by this cycle
+		if _, processing := c.processingTxs.Load(tx.ID); processing {
+			if tx.Status != types.MonitoredTxStatusCreated || tx.FixedNonce {
+				_ = assignActiveNonce(activeNonces, tx)
+			}
 			continue
 		}
 
-		nonce, ok := senderNonces[tx.From]
-		if !ok {
-			// if there are no pending txs, we get the pending nonce from the etherman
-			nonce, err = c.etherman.PendingNonce(ctx, tx.From)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get pending nonce for sender: %s. Error: %w", tx.From, err)
+		// the txs created after the simulation are left to the next cycle, so they are simulated first
+		if simulated != nil && tx.Status == types.MonitoredTxStatusCreated && !simulated[tx.ID] {
+			if tx.FixedNonce {
+				_ = assignActiveNonce(activeNonces, tx)
 			}
+			continue
+		}
 
-			senderNonces[tx.From] = nonce
+		iteration := &monitoredTxnIteration{MonitoredTx: &tx}
+		if iteration.shouldUpdateNonce(ctx, c.etherman) {
+			toAssign = append(toAssign, iteration)
+		} else if err := assignActiveNonce(activeNonces, tx); err != nil {
+			// a nonce kept by more than one active monitored tx would make them replace each
+			// other, so the monitored tx is not processed until the conflict is solved
+			createMonitoredTxLogger(tx).Error(err.Error())
+			continue
 		}
 
-		iteration.Nonce = nonce
-		err = c.storage.Update(ctx, tx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to update nonce for tx %v: %w", tx.ID.String(), translateError(err))
+		iterations = append(iterations, iteration)
+	}
+
+	senderNonces := make(map[common.Address]uint64)
+	nonceUpdates := make([]types.MonitoredTx, 0, len(toAssign))
+	for _, iteration := range toAssign {
+		nonce, ok := senderNonces[iteration.From]
+		// a nonce provider hands out a nonce on each call, so it's asked for the nonce of each tx,
+		// while the nonce of the network is requested once per sender and increased locally
+		if !ok || c.nonceProvider != nil {
+			nonce, err = c.sourceNonce(ctx, iteration.From)
+			if err != nil {
+				return nil, err
+			}
 		}
 
-		senderNonces[tx.From]++
+		// the nonces used by other active monitored txs of the sender are skipped
+		iteration.Nonce = nextFreeNonce(activeNonces, iteration.From, nonce)
+		_ = assignActiveNonce(activeNonces, *iteration.MonitoredTx)
+		senderNonces[iteration.From] = iteration.Nonce + 1
+		nonceUpdates = append(nonceUpdates, *iteration.MonitoredTx)
+	}
+
+	// the nonces of the cycle are persisted atomically, so a failure can't leave
+	// some of the monitored txs with the new nonces and the others with the old ones
+	if err := c.updateAllWithRetries(ctx, nonceUpdates); err != nil {
+		return nil, fmt.Errorf("failed to update the nonces: %w", translateError(err))
 	}
 
 	return iterations, nil
 }
 
+// nextFreeNonce returns the first nonce of the sender from the given one that is not used by an active monitored tx
+func nextFreeNonce(activeNonces map[common.Address]map[uint64]common.Hash, sender common.Address, nonce uint64) uint64 {
+	for {
+		if _, used := activeNonces[sender][nonce]; !used {
+			return nonce
+		}
+		nonce++
+	}
+}
+
+// assignActiveNonce records the nonce of the monitored tx as used by its sender,
+// returning ErrNonceAlreadyAssigned if it's already used by another active monitored tx
+func assignActiveNonce(activeNonces map[common.Address]map[uint64]common.Hash, mTx types.MonitoredTx) error {
+	nonces, ok := activeNonces[mTx.From]
+	if !ok {
+		nonces = make(map[uint64]common.Hash)
+		activeNonces[mTx.From] = nonces
+	}
+
+	if id, assigned := nonces[mTx.Nonce]; assigned {
+		return fmt.Errorf("%w: nonce %d of sender %s is already assigned to monitored tx %s",
+			ErrNonceAlreadyAssigned, mTx.Nonce, mTx.From.String(), id.String())
+	}
+
+	nonces[mTx.Nonce] = mTx.ID
+	return nil
+}
+
 func (c *Client) suggestedGasPrice(ctx context.Context) (*big.Int, error) {
 	// get gas price
 	gasPrice, err := c.etherman.SuggestedGasPrice(ctx)
@@ -977,6 +3553,12 @@ func (c *Client) suggestedGasPrice(ctx context.Context) (*big.Int, error) {
 		return nil, err
 	}
 
+	// a suggestion over the sanity max is a misbehaving node, so it's rejected instead of clamped
+	if c.cfg.GasPriceSanityMax > 0 && gasPrice.Cmp(new(big.Int).SetUint64(c.cfg.GasPriceSanityMax)) == 1 {
+		return nil, fmt.Errorf("%w: suggested %s, sanity max %d",
+			ErrGasPriceAboveSanityMax, gasPrice.String(), c.cfg.GasPriceSanityMax)
+	}
+
 	// adjust the gas price by the margin factor
 	marginFactor := big.NewFloat(0).SetFloat64(c.cfg.GasPriceMarginFactor)
 	fGasPrice := big.NewFloat(0).SetInt(gasPrice)
@@ -984,19 +3566,20 @@ func (c *Client) suggestedGasPrice(ctx context.Context) (*big.Int, error) {
 
 	// if there is a max gas price limit configured and the current
 	// adjusted gas price is over this limit, set the gas price as the limit
-	if c.cfg.MaxGasPriceLimit > 0 {
-		maxGasPrice := big.NewInt(0).SetUint64(c.cfg.MaxGasPriceLimit)
-		if adjustedGasPrice.Cmp(maxGasPrice) == 1 {
-			adjustedGasPrice.Set(maxGasPrice)
-		}
-	}
-
-	return adjustedGasPrice, nil
+	return c.clampGasPrice(adjustedGasPrice), nil
 }
 
 // logErrorAndWait used when an error is detected before trying again
 func (c *Client) logErrorAndWait(msg string, err error) {
 	log.Errorf(msg, err)
+	if errors.Is(err, ErrStorageUnavailable) {
+		time.Sleep(storageUnavailableIntervalInSeconds * time.Second)
+		return
+	}
+	if isRetryableError(err) {
+		time.Sleep(retryableFailureIntervalInSeconds * time.Second)
+		return
+	}
 	time.Sleep(failureIntervalInSeconds * time.Second)
 }
 
@@ -1004,11 +3587,28 @@ func (c *Client) logErrorAndWait(msg string, err error) {
 // when processing monitored txs
 type ResultHandler func(types.MonitoredTxResult)
 
+// ErrResultHandler used by the caller to handle results when processing monitored txs,
+// returning an error keeps the monitored tx pending so it's handled again in the next pass
+type ErrResultHandler func(types.MonitoredTxResult) error
+
 // ProcessPendingMonitoredTxs will check all monitored txs
 // and wait until all of them are either confirmed or failed before continuing
 //
 // for the confirmed and failed ones, the resultHandler will be triggered
 func (c *Client) ProcessPendingMonitoredTxs(ctx context.Context, resultHandler ResultHandler) {
+	c.ProcessPendingMonitoredTxsWithErrHandler(ctx, func(result types.MonitoredTxResult) error {
+		resultHandler(result)
+		return nil
+	})
+}
+
+// ProcessPendingMonitoredTxsWithErrHandler will check all monitored txs
+// and wait until all of them are either confirmed or failed before continuing
+//
+// for the confirmed and failed ones, the resultHandler will be triggered. The confirmed ones are set as
+// safe before the handler is triggered, so a failure setting them as safe doesn't trigger it twice. If the
+// handler returns an error for a confirmed one, it's set back as mined so it's handled again in the next pass
+func (c *Client) ProcessPendingMonitoredTxsWithErrHandler(ctx context.Context, resultHandler ErrResultHandler) {
 	statusesFilter := []types.MonitoredTxStatus{
 		types.MonitoredTxStatusCreated,
 		types.MonitoredTxStatusSent,
@@ -1022,7 +3622,11 @@ func (c *Client) ProcessPendingMonitoredTxs(ctx context.Context, resultHandler R
 		if err != nil {
 			// if something goes wrong here, we log, wait a bit and keep it in the infinite loop to not unlock the caller.
 			log.Errorf("failed to get results by statuses from eth tx manager to monitored txs err: ", err)
-			time.Sleep(time.Second)
+			if errors.Is(err, ErrStorageUnavailable) {
+				time.Sleep(storageUnavailableIntervalInSeconds * time.Second)
+				continue
+			}
+			time.Sleep(c.pendingTxsPollInterval())
 			continue
 		}
 
@@ -1045,26 +3649,30 @@ func (c *Client) ProcessPendingMonitoredTxs(ctx context.Context, resultHandler R
 				} else {
 					mTxResultLogger.Info("monitored tx safe")
 				}
-				resultHandler(result)
+				if err := resultHandler(result); err != nil {
+					mTxResultLogger.Errorf("failed to handle monitored tx result, err: %v", err)
+					// the result is set back as mined, so it's going to be handled again in the next cycle
+					// by the outer loop.
+					if err := c.revertStatusSafe(ctx, result.ID); err != nil {
+						mTxResultLogger.Errorf("failed to set monitored tx back as mined, err: %v", err)
+					}
+				}
 				continue
 			}
 
 			// if the result is failed, we need to go around it and rebuild a batch verification
-			if result.Status == types.MonitoredTxStatusFailed {
-				resultHandler(result)
-				continue
-			}
-
 			// if the result is evicted, it exceeded max retries - notify caller
-			if result.Status == types.MonitoredTxStatusEvicted {
-				resultHandler(result)
+			if result.Status == types.MonitoredTxStatusFailed || result.Status == types.MonitoredTxStatusEvicted {
+				if err := resultHandler(result); err != nil {
+					mTxResultLogger.Errorf("failed to handle monitored tx result, err: %v", err)
+				}
 				continue
 			}
 
 			// if the result is either not confirmed or failed, it means we need to wait until it gets confirmed of failed.
 			for {
 				// wait before refreshing the result info
-				time.Sleep(time.Second)
+				time.Sleep(c.pendingTxsPollInterval())
 
 				// refresh the result info
 				result, err := c.Result(ctx, result.ID)
@@ -1073,12 +3681,10 @@ func (c *Client) ProcessPendingMonitoredTxs(ctx context.Context, resultHandler R
 					continue
 				}
 
-				// if the result status is mined, safe, finalized, failed or evicted, breaks the wait loop
+				// if the result status is mined, safe or terminal (finalized, failed or evicted), breaks the wait loop
 				if result.Status == types.MonitoredTxStatusMined ||
 					result.Status == types.MonitoredTxStatusSafe ||
-					result.Status == types.MonitoredTxStatusFinalized ||
-					result.Status == types.MonitoredTxStatusFailed ||
-					result.Status == types.MonitoredTxStatusEvicted {
+					result.Status.IsTerminal() {
 					break
 				}
 
@@ -1088,6 +3694,95 @@ func (c *Client) ProcessPendingMonitoredTxs(ctx context.Context, resultHandler R
 	}
 }
 
+// defaultWaitPollInterval is the time WaitForAll waits between two checks of a monitored tx
+// when WaitOptions.PollInterval is not provided
+const defaultWaitPollInterval = time.Second
+
+// WaitOptions customizes how WaitForAllWithOptions waits for the monitored txs
+type WaitOptions struct {
+	// PollInterval is the time to wait between two checks of each monitored tx, 0 means 1s
+	PollInterval time.Duration
+
+	// SkipNotFound stops waiting for the monitored txs that are not found, since they were removed,
+	// leaving them out of the results instead of failing
+	SkipNotFound bool
+}
+
+// WaitForAll waits until the results of all the provided monitored txs satisfy the predicate or the
+// context is done, checking them concurrently every second. It fails if any of them is not found.
+// The results satisfying the predicate are returned even when it fails, e.g.
+//
+//	results, err := c.WaitForAll(ctx, ids, func(r types.MonitoredTxResult) bool {
+//		return r.Status == types.MonitoredTxStatusFinalized || r.Status == types.MonitoredTxStatusFailed
+//	})
+func (c *Client) WaitForAll(ctx context.Context, ids []common.Hash,
+	until func(types.MonitoredTxResult) bool) (map[common.Hash]types.MonitoredTxResult, error) {
+	return c.WaitForAllWithOptions(ctx, ids, until, WaitOptions{})
+}
+
+// WaitForAllWithOptions works as WaitForAll using the provided options
+func (c *Client) WaitForAllWithOptions(ctx context.Context, ids []common.Hash,
+	until func(types.MonitoredTxResult) bool, opts WaitOptions) (map[common.Hash]types.MonitoredTxResult, error) {
+	pollInterval := opts.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultWaitPollInterval
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[common.Hash]types.MonitoredTxResult, len(ids))
+		errs    []error
+	)
+
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id common.Hash) {
+			defer wg.Done()
+			result, err := c.waitFor(ctx, id, until, pollInterval)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				results[id] = result
+			case errors.Is(err, ErrNotFound) && opts.SkipNotFound:
+				log.Infof("stopped waiting for monitored tx %s, it was not found", id.String())
+			default:
+				errs = append(errs, fmt.Errorf("failed to wait for monitored tx %s: %w", id.String(), err))
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	return results, errors.Join(errs...)
+}
+
+// waitFor polls the result of the monitored tx until it satisfies the predicate, the monitored tx
+// is not found or the context is done
+func (c *Client) waitFor(ctx context.Context, id common.Hash, until func(types.MonitoredTxResult) bool,
+	pollInterval time.Duration) (types.MonitoredTxResult, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		result, err := c.Result(ctx, id)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			return types.MonitoredTxResult{}, err
+		case err != nil:
+			log.Errorf("failed to get result of monitored tx %s, err: %v", id.String(), err)
+		case until(result):
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return types.MonitoredTxResult{}, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
 // EncodeBlobData encodes data into blob data type
 func (c *Client) EncodeBlobData(data []byte) (kzg4844.Blob, error) {
 	dataLen := len(data)
@@ -1119,7 +3814,8 @@ func (c *Client) EncodeBlobData(data []byte) (kzg4844.Blob, error) {
 	return blob, nil
 }
 
-// MakeBlobSidecar constructs a blob tx sidecar
+// MakeBlobSidecar constructs a blob tx sidecar. The number of blobs a tx can carry is limited by the
+// network, the txs added with a sidecar of more than MaxBlobsPerTx blobs are rejected with ErrTooManyBlobs
 func (c *Client) MakeBlobSidecar(blobs []kzg4844.Blob) *ethTypes.BlobTxSidecar {
 	commitments := make([]kzg4844.Commitment, 0, len(blobs))
 	proofs := make([]kzg4844.Proof, 0, len(blobs))
@@ -1177,6 +3873,201 @@ func CreateMonitoredTxResultLogger(mTxResult types.MonitoredTxResult) *log.Logge
 	)
 }
 
+// broadcast delivers the signed tx through the private relay if the monitored tx
+// was flagged to use it, otherwise the tx is sent to the public mempool. Only the broadcasts
+// of the monitoring cycles are recorded by the circuit breaker
+func (c *Client) broadcast(ctx context.Context, mTx *monitoredTxnIteration, signedTx *ethTypes.Transaction) error {
+	err := c.broadcastTx(ctx, mTx, signedTx)
+	if mTx.inCycle {
+		c.circuitBreaker.recordBroadcast(err)
+	}
+	if err == nil {
+		c.lastBroadcasts.Store(mTx.ID, lastBroadcast{txHash: signedTx.Hash(), at: time.Now()})
+	}
+	return err
+}
+
+// lastBroadcast is the last tx broadcast of a monitored tx
+type lastBroadcast struct {
+	txHash common.Hash
+	at     time.Time
+}
+
+// inInitialBroadcastGrace checks if the tx was broadcast for the monitored tx less than InitialBroadcastGrace ago,
+// so not finding it in the network means it wasn't propagated yet instead of being dropped
+func (c *Client) inInitialBroadcastGrace(id, txHash common.Hash) bool {
+	if c.cfg.InitialBroadcastGrace.Duration <= 0 {
+		return false
+	}
+	value, found := c.lastBroadcasts.Load(id)
+	if !found {
+		return false
+	}
+	broadcast, ok := value.(lastBroadcast)
+	return ok && broadcast.txHash == txHash && time.Since(broadcast.at) < c.cfg.InitialBroadcastGrace.Duration
+}
+
+func (c *Client) broadcastTx(ctx context.Context, mTx *monitoredTxnIteration, signedTx *ethTypes.Transaction) error {
+	if !mTx.PrivateRelay {
+		return NewPublicMempoolBroadcaster(c.etherman).Broadcast(ctx, signedTx)
+	}
+
+	if c.relayBroadcaster == nil {
+		return ErrNoRelayBroadcaster
+	}
+
+	return c.relayBroadcaster.Broadcast(ctx, signedTx)
+}
+
+// RevertReasons returns the number of monitored txs failed by revert reason inside the RevertReasonsWindow,
+// the most repeated reason first. The failures whose revert reason couldn't be retrieved are
+// aggregated as "unknown". The counts are kept in memory, so they start from zero on restart
+func (c *Client) RevertReasons() []RevertReasonCount {
+	return c.revertReasons.counts(c.revertReasonsWindow())
+}
+
+// recordRevertReason aggregates the revert reason of a failed monitored tx
+func (c *Client) recordRevertReason(reason string) {
+	c.revertReasons.record(reason, c.revertReasonsWindow(), c.cfg.RevertReasonAlertThreshold)
+}
+
+// revertReasonsWindow returns the configured window of the revert reasons aggregation or the default one
+func (c *Client) revertReasonsWindow() time.Duration {
+	if c.cfg.RevertReasonsWindow.Duration > 0 {
+		return c.cfg.RevertReasonsWindow.Duration
+	}
+	return defaultRevertReasonsWindow
+}
+
+// waitTxReceipt polls the receipt of a mined tx. A receipt not found yet is polled up to GetReceiptMaxTime,
+// while the node failing gives up once the failures last GetReceiptErrorMaxTime in a row
+func (c *Client) waitTxReceipt(ctx context.Context, txHash common.Hash) (*ethTypes.Receipt, error) {
+	startedAt := time.Now()
+	var failingSince time.Time
+	for {
+		receipt, err := c.etherman.GetTxReceipt(ctx, txHash)
+		if err == nil {
+			return receipt, nil
+		}
+
+		now := time.Now()
+		if errors.Is(err, ethereum.NotFound) {
+			failingSince = time.Time{}
+		} else {
+			if failingSince.IsZero() {
+				failingSince = now
+			}
+			if errorMaxTime := c.getReceiptErrorMaxTime(); now.Sub(failingSince) >= errorMaxTime {
+				return nil, fmt.Errorf("the node kept failing for %v: %w", errorMaxTime, translateError(err))
+			}
+		}
+		if now.Sub(startedAt) >= c.cfg.GetReceiptMaxTime.Duration {
+			return nil, fmt.Errorf("receipt not found after %v: %w", c.cfg.GetReceiptMaxTime, translateError(err))
+		}
+
+		time.Sleep(c.cfg.GetReceiptWaitInterval.Duration)
+	}
+}
+
+// getReceiptErrorMaxTime returns the configured time the receipt polling tolerates the node failing
+// or GetReceiptMaxTime
+func (c *Client) getReceiptErrorMaxTime() time.Duration {
+	if c.cfg.GetReceiptErrorMaxTime.Duration > 0 {
+		return c.cfg.GetReceiptErrorMaxTime.Duration
+	}
+	return c.cfg.GetReceiptMaxTime.Duration
+}
+
+// pendingTxsPollInterval returns the configured interval ProcessPendingMonitoredTxs polls the
+// pending results with or the default one
+func (c *Client) pendingTxsPollInterval() time.Duration {
+	if c.cfg.PendingTxsPollInterval.Duration > 0 {
+		return c.cfg.PendingTxsPollInterval.Duration
+	}
+	return defaultPendingTxsPollInterval
+}
+
+// noReplaceTxTTL returns the configured time a no replace tx waits to be mined or the default one
+func (c *Client) noReplaceTxTTL() time.Duration {
+	if c.cfg.NoReplaceTxTTL.Duration > 0 {
+		return c.cfg.NoReplaceTxTTL.Duration
+	}
+	return defaultNoReplaceTxTTL
+}
+
+// maxBlobsPerTx returns the configured maximum number of blobs of a tx or the default one
+func (c *Client) maxBlobsPerTx() uint64 {
+	if c.cfg.MaxBlobsPerTx > 0 {
+		return c.cfg.MaxBlobsPerTx
+	}
+	return defaultMaxBlobsPerTx
+}
+
+// circuitBreakerCooldown returns the configured cooldown of the circuit breaker or the default one
+func (c *Client) circuitBreakerCooldown() time.Duration {
+	if c.cfg.CircuitBreakerCooldown.Duration > 0 {
+		return c.cfg.CircuitBreakerCooldown.Duration
+	}
+	return defaultCircuitBreakerCooldown
+}
+
+// senderCanAfford checks the sender balance covers the cost of the tx, reporting only once the monitored
+// txs that can't be sent until the sender gets funded. The reason is recorded on the monitored tx while
+// it's held back, so it's visible in its result
+func (c *Client) senderCanAfford(
+	ctx context.Context, mTx *monitoredTxnIteration, signedTx *ethTypes.Transaction, logger *log.Logger,
+) bool {
+	balance, err := c.etherman.BalanceAt(ctx, mTx.From)
+	if err != nil {
+		logger.Warnf("failed to get sender balance, sending tx anyway: %v", err)
+		return true
+	}
+
+	cost := signedTx.Cost()
+	if balance.Cmp(cost) < 0 {
+		if _, reported := c.insufficientFunds.LoadOrStore(mTx.ID, struct{}{}); !reported {
+			logger.Errorf("%v: sender balance %v doesn't cover the tx cost %v, tx won't be sent until the sender is funded",
+				ErrInsufficientFunds, balance.String(), cost.String())
+			mTx.Reason = fmt.Sprintf("%v: sender balance %v doesn't cover the tx cost %v",
+				ErrInsufficientFunds, balance.String(), cost.String())
+			if err := c.updateWithRetries(ctx, *mTx.MonitoredTx); err != nil {
+				logger.Errorf("failed to record the insufficient funds reason: %v", err)
+			}
+		}
+		return false
+	}
+
+	_, reported := c.insufficientFunds.LoadAndDelete(mTx.ID)
+	// the reason recorded before a restart is cleared as well
+	if reported || strings.HasPrefix(mTx.Reason, ErrInsufficientFunds.Error()) {
+		logger.Infof("sender balance %v covers the tx cost %v again", balance.String(), cost.String())
+		mTx.Reason = ""
+		if err := c.updateWithRetries(ctx, *mTx.MonitoredTx); err != nil {
+			logger.Errorf("failed to clear the insufficient funds reason: %v", err)
+		}
+	}
+	return true
+}
+
+// isIntrinsicGasTooLowError checks if the error returned when sending a tx
+// means that the gas of the tx doesn't cover its intrinsic gas
+func isIntrinsicGasTooLowError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), errMsgIntrinsicGasTooLow)
+}
+
+// isBlobFeeTooLowError checks if the error returned when sending a blob tx
+// means that its blob fee cap doesn't cover the blob base fee
+func isBlobFeeTooLowError(err error) bool {
+	return err != nil && (strings.Contains(err.Error(), errMsgBlobGasPriceTooLow) ||
+		strings.Contains(err.Error(), errMsgBlobFeeCapTooLow))
+}
+
+// isTxTypeNotSupportedError checks if the error returned when sending a tx
+// means that the node doesn't support the type of the tx
+func isTxTypeNotSupportedError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), errMsgTxTypeNotSupported)
+}
+
 func translateError(err error) error {
 	if err == nil {
 		return nil
@@ -1189,5 +4080,16 @@ func translateError(err error) error {
 	if err.Error() == types.ErrNotFound.Error() {
 		return ErrNotFound
 	}
+	// A timeout is retryable, unlike the definitive failures
+	if errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, ErrRPCTimeout) {
+		return fmt.Errorf("%w: %w", ErrRPCTimeout, err)
+	}
 	return err
 }
+
+// isRetryableError returns true if the error is transient, so the operation can be tried again
+// soon without counting it as a failure of the monitored tx
+func isRetryableError(err error) bool {
+	err = translateError(err)
+	return errors.Is(err, ErrRPCTimeout) || errors.Is(err, ErrStorageUnavailable)
+}
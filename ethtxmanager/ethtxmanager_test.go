@@ -1,16 +1,21 @@
 package ethtxmanager
 
 import (
+	"bytes"
 	context "context"
 	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
+	"strings"
 	"testing"
 	"time"
 
 	localCommon "github.com/0xPolygon/zkevm-ethtx-manager/common"
+	configTypes "github.com/0xPolygon/zkevm-ethtx-manager/config/types"
 	"github.com/0xPolygon/zkevm-ethtx-manager/etherman"
 	"github.com/0xPolygon/zkevm-ethtx-manager/ethtxmanager/sqlstorage"
 	"github.com/0xPolygon/zkevm-ethtx-manager/mocks"
@@ -18,14 +23,26 @@ import (
 	signertypes "github.com/agglayer/go_signer/signer/types"
 	"github.com/ethereum/go-ethereum"
 	common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
 var errGenericNotFound = errors.New("not found")
 
+// the core client only depends on the methods of these interfaces, so the generated mocks must satisfy them
+var (
+	_ types.EthermanInterface = (*mocks.EthermanInterface)(nil)
+	_ types.StorageInterface  = (*mocks.StorageInterface)(nil)
+)
+
 func TestTxManagerExploratory(t *testing.T) {
 	t.Skip("skipping test")
 	storagePath := path.Join(t.TempDir(), "txmanager.sqlite")
@@ -72,6 +89,133 @@ func TestResult(t *testing.T) {
 	require.ErrorIs(t, err, ErrNotFound)
 }
 
+func TestResultRevertReason(t *testing.T) {
+	testData := newTestData(t, false)
+	to := common.HexToAddress("0x1")
+	tx := ethtypes.NewTransaction(1, to, big.NewInt(1), 21000, big.NewInt(1), nil)
+	receipt := &ethtypes.Receipt{Status: ethtypes.ReceiptStatusFailed, TxHash: tx.Hash(), BlockNumber: big.NewInt(10)}
+
+	id := common.HexToHash("0x123")
+	require.NoError(t, testData.sut.storage.Add(testData.ctx, types.MonitoredTx{
+		ID:          id,
+		To:          &to,
+		Status:      types.MonitoredTxStatusFailed,
+		BlockNumber: big.NewInt(10),
+		History:     map[common.Hash]bool{tx.Hash(): true},
+	}))
+
+	testData.ethermanMock.EXPECT().GetTx(testData.ctx, tx.Hash()).Return(tx, false, nil).Once()
+	testData.ethermanMock.EXPECT().GetTxReceipt(testData.ctx, tx.Hash()).Return(receipt, nil).Once()
+	testData.ethermanMock.EXPECT().GetRevertMessage(testData.ctx, tx).Return("invalid batch", nil).Once()
+
+	result, err := testData.sut.Result(testData.ctx, id)
+	require.NoError(t, err)
+
+	reason, found := result.RevertReason()
+	require.True(t, found)
+	require.Equal(t, "invalid batch", reason)
+
+	err = result.Err()
+	require.ErrorIs(t, err, types.ErrReverted)
+	var revertedErr *types.RevertedError
+	require.ErrorAs(t, err, &revertedErr)
+	require.Equal(t, tx.Hash(), revertedErr.TxHash)
+	require.Equal(t, "invalid batch", revertedErr.Reason)
+}
+
+func TestPendingL1Txs(t *testing.T) {
+	from := common.HexToAddress("0x2")
+	to := common.HexToAddress("0x1")
+	dynamicFeeTxHash := common.HexToHash("0xabc")
+	blobTxHash := common.HexToHash("0xdef")
+	txpoolContent := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"result":{"pending":{"%[1]s":{
+		"1":{"type":"0x0","hash":"0x01","from":"%[1]s","to":"%[2]s","nonce":"0x1","gasPrice":"0x64",
+			"gas":"0x5208","value":"0x1","input":"0x"},
+		"2":{"type":"0x2","hash":"%[3]s","from":"%[1]s","to":"%[2]s","nonce":"0x2","gasPrice":"0xc8",
+			"maxFeePerGas":"0xc8","maxPriorityFeePerGas":"0xa","gas":"0x5208","value":"0x2","input":"0x1234"},
+		"3":{"type":"0x3","hash":"%[4]s","from":"%[1]s","to":"%[2]s","nonce":"0x3","gasPrice":"0xc8",
+			"maxFeePerGas":"0xc8","maxPriorityFeePerGas":"0xa","maxFeePerBlobGas":"0x5",
+			"blobVersionedHashes":["%[4]s","%[3]s"],"gas":"0x5208","value":"0x0","input":"0x"},
+		"4":{"type":"0x1","hash":"0x04","from":"%[1]s","to":"%[2]s","nonce":"0x4","gasPrice":"0x64",
+			"accessList":[{"address":"%[2]s","storageKeys":[]}],"gas":"0x5208","value":"0x0","input":"0x"}
+	}},"queued":{}}}`, from.Hex(), to.Hex(), dynamicFeeTxHash.Hex(), blobTxHash.Hex())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := w.Write([]byte(txpoolContent))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	// the blob tx and the tx with an access list can't be sent again without losing them
+	mTxs, skipped, err := pendingL1Txs(server.URL, from, nil)
+	require.NoError(t, err)
+	require.Len(t, mTxs, 2)
+	require.Equal(t, 2, skipped)
+
+	byNonce := make(map[uint64]types.MonitoredTx, len(mTxs))
+	for _, mTx := range mTxs {
+		byNonce[mTx.Nonce] = mTx
+	}
+
+	legacyTx := byNonce[1]
+	require.Equal(t, big.NewInt(100), legacyTx.GasPrice)
+	require.Nil(t, legacyTx.GasTipCap)
+	require.Equal(t, ethtypes.LegacyTxType, int(legacyTx.Tx().Type()))
+
+	dynamicFeeTx := byNonce[2]
+	require.Equal(t, types.MonitoredTxStatusSent, dynamicFeeTx.Status)
+	require.Equal(t, from, dynamicFeeTx.From)
+	require.Equal(t, &to, dynamicFeeTx.To)
+	require.Equal(t, big.NewInt(2), dynamicFeeTx.Value)
+	require.Equal(t, []byte{0x12, 0x34}, dynamicFeeTx.Data)
+	require.Equal(t, uint64(21000), dynamicFeeTx.Gas)
+	require.Equal(t, big.NewInt(200), dynamicFeeTx.GasPrice)
+	require.Equal(t, big.NewInt(10), dynamicFeeTx.GasTipCap)
+	require.True(t, dynamicFeeTx.History[dynamicFeeTxHash])
+	require.Equal(t, ethtypes.DynamicFeeTxType, int(dynamicFeeTx.Tx().Type()))
+
+	blobTx := l1Tx{Type: "0x3", Hash: blobTxHash.Hex(), Nonce: "0x3", Gas: "0x5208", Value: "0x0",
+		MaxFeePerGas: "0xc8", MaxPriorityFeePerGas: "0xa", MaxFeePerBlobGas: "0x5"}
+	_, err = blobTx.toMonitoredTx()
+	require.ErrorIs(t, err, ErrUnrecoverableTx)
+}
+
+func TestPendingL1TxsSkipMalformed(t *testing.T) {
+	from := common.HexToAddress("0x2")
+	to := common.HexToAddress("0x1")
+	txpoolContent := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"result":{"pending":{"%[1]s":{
+		"1":{"type":"0x0","hash":"0x01","from":"%[1]s","to":"%[2]s","nonce":"0x1","gasPrice":"0x64",
+			"gas":"0x5208","value":"0x1","input":"0x"},
+		"2":{"type":"0x0","hash":"0x02","from":"%[1]s","to":"%[2]s","nonce":"0x2","gasPrice":"0x64",
+			"gas":"0x5208","value":"not a number","input":"0x"},
+		"3":{"type":"0x2","hash":"0x03","from":"%[1]s","to":"%[2]s","nonce":"0x3","gasPrice":"0xc8",
+			"maxFeePerGas":"0xc8","gas":"0x5208","value":"0x0","input":"0x"},
+		"4":{"type":"0x0","hash":"0x04","from":"%[1]s","to":"%[2]s","nonce":4,"gasPrice":"0x64",
+			"gas":"0x5208","value":"0x0","input":"0x"},
+		"5":{"type":"0x7f","hash":"0x05","from":"%[1]s","to":"%[2]s","nonce":"0x5","gasPrice":"0x64",
+			"gas":"0x5208","value":"0x0","input":"0x"},
+		"6":{"type":"0x2","hash":"0x06","from":"%[1]s","to":"%[2]s","nonce":"0x6","gasPrice":"0xc8",
+			"maxFeePerGas":"0xc8","maxPriorityFeePerGas":"0xa","gas":"0x5208","value":"0x0","input":"0x"}
+	}},"queued":{}}}`, from.Hex(), to.Hex())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := w.Write([]byte(txpoolContent))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	mTxs, skipped, err := pendingL1Txs(server.URL, from, nil)
+	require.NoError(t, err)
+	require.Equal(t, 4, skipped)
+	require.Len(t, mTxs, 2)
+
+	nonces := make([]uint64, 0, len(mTxs))
+	for _, mTx := range mTxs {
+		nonces = append(nonces, mTx.Nonce)
+	}
+	require.ElementsMatch(t, []uint64{1, 6}, nonces)
+}
+
 func TestGetMonitoredTxnIteration(t *testing.T) {
 	ctx := context.Background()
 	etherman := mocks.NewEthermanInterface(t)
@@ -215,6 +359,37 @@ func TestNew(t *testing.T) {
 	require.NotNil(t, sut)
 }
 
+func TestNewWithStorage(t *testing.T) {
+	mockEtherman := mocks.NewEthermanInterface(t)
+	mockStorage := mocks.NewStorageInterface(t)
+	from := common.HexToAddress("0x1")
+	mockEtherman.EXPECT().PublicAddress().Return([]common.Address{from}, nil).Once()
+
+	sut, err := NewWithStorage(Config{}, mockStorage, mockEtherman)
+	require.NoError(t, err)
+	require.Equal(t, mockStorage, sut.storage)
+	require.Equal(t, mockEtherman, sut.etherman)
+	require.Equal(t, from, sut.from)
+
+	// the injected storage is used
+	id := common.HexToHash("0x2")
+	mockStorage.EXPECT().Get(mock.Anything, id).Return(types.MonitoredTx{}, types.ErrNotFound).Once()
+	_, err = sut.Result(context.Background(), id)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = NewWithStorage(Config{}, nil, mockEtherman)
+	require.Error(t, err)
+	_, err = NewWithStorage(Config{}, mockStorage, nil)
+	require.Error(t, err)
+	// the bump schedule can't escalate the fees without a ceiling
+	_, err = NewWithStorage(Config{BumpScheduleBasePercentage: 12.5}, mockStorage, mockEtherman)
+	require.ErrorContains(t, err, "MaxGasPriceLimit")
+
+	mockEtherman.EXPECT().PublicAddress().Return(nil, nil).Once()
+	_, err = NewWithStorage(Config{}, mockStorage, mockEtherman)
+	require.ErrorContains(t, err, "no public address found")
+}
+
 // compareTxsWithout dates compares the two MonitoredTx instances, but without dates, since some functions are altering it
 func compareTxsWithoutDates(t *testing.T, expected, actual types.MonitoredTx) {
 	t.Helper()
@@ -357,7 +532,7 @@ func TestMonitorTxEstimateGasMaxRetries(t *testing.T) {
 				testData.ethermanMock.EXPECT().SignTx(testData.ctx, mock.Anything, mock.Anything).Return(ethtypes.NewTx(&ethtypes.LegacyTx{}), nil).Maybe()
 				testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(nil).Maybe()
 				testData.ethermanMock.EXPECT().GetTx(testData.ctx, mock.Anything).Return(nil, false, errGenericNotFound).Maybe()
-				testData.ethermanMock.EXPECT().SendTx(testData.ctx, mock.Anything).Return(nil).Maybe()
+				testData.ethermanMock.EXPECT().SendTxIdempotent(testData.ctx, mock.Anything).Return(nil).Maybe()
 				testData.ethermanMock.EXPECT().WaitTxToBeMined(testData.ctx, mock.Anything, mock.Anything).Return(false, nil).Maybe()
 			}
 
@@ -370,6 +545,83 @@ func TestMonitorTxEstimateGasMaxRetries(t *testing.T) {
 	}
 }
 
+func TestTranslateErrorTimeout(t *testing.T) {
+	err := translateError(fmt.Errorf("failed to get suggested gas price: %w", context.DeadlineExceeded))
+	require.ErrorIs(t, err, ErrRPCTimeout)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.True(t, isRetryableError(err))
+	// translating it again doesn't wrap it twice
+	require.Equal(t, err, translateError(err))
+
+	require.False(t, isRetryableError(errors.New("execution reverted")))
+	require.False(t, isRetryableError(errGenericNotFound))
+	require.False(t, isRetryableError(nil))
+}
+
+func TestMonitorTxRetryableErrors(t *testing.T) {
+	newMonitoredTx := func(status types.MonitoredTxStatus) *monitoredTxnIteration {
+		return &monitoredTxnIteration{
+			MonitoredTx: &types.MonitoredTx{
+				ID:         common.HexToHash("0x123"),
+				From:       common.HexToAddress("0x456"),
+				To:         &common.Address{},
+				Status:     status,
+				RetryCount: 1,
+				History:    make(map[common.Hash]bool),
+				Value:      big.NewInt(0),
+				Data:       []byte{},
+				Gas:        21000,
+				GasPrice:   big.NewInt(1000000000),
+			},
+		}
+	}
+	timeoutErr := fmt.Errorf("post failed: %w", context.DeadlineExceeded)
+
+	t.Run("gas review timeout doesn't increment the retry count", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.sut.cfg = Config{EstimateGasMaxRetries: 3, GasPriceMarginFactor: 1}
+		mTx := newMonitoredTx(types.MonitoredTxStatusSent)
+
+		testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(nil, timeoutErr).Once()
+
+		testData.sut.monitorTx(testData.ctx, mTx, createMonitoredTxLogger(*mTx.MonitoredTx))
+		require.Equal(t, uint64(1), mTx.RetryCount)
+		testData.storageMock.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("gas review definitive failure increments the retry count", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.sut.cfg = Config{EstimateGasMaxRetries: 3, GasPriceMarginFactor: 1}
+		mTx := newMonitoredTx(types.MonitoredTxStatusSent)
+
+		testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(nil, errors.New("boom")).Once()
+		testData.storageMock.EXPECT().Update(testData.ctx, mock.MatchedBy(func(tx types.MonitoredTx) bool {
+			return tx.RetryCount == 2
+		})).Return(nil).Once()
+
+		testData.sut.monitorTx(testData.ctx, mTx, createMonitoredTxLogger(*mTx.MonitoredTx))
+		require.Equal(t, uint64(2), mTx.RetryCount)
+	})
+
+	t.Run("send timeout doesn't increment the retry count", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.sut.cfg = Config{EstimateGasMaxRetries: 3}
+		mTx := newMonitoredTx(types.MonitoredTxStatusCreated)
+		signedTx := ethtypes.NewTx(&ethtypes.LegacyTx{Nonce: 1})
+
+		testData.ethermanMock.EXPECT().SignTx(testData.ctx, mTx.From, mock.Anything).Return(signedTx, nil).Once()
+		testData.storageMock.EXPECT().Update(testData.ctx, mock.MatchedBy(func(tx types.MonitoredTx) bool {
+			return tx.RetryCount == 1
+		})).Return(nil).Once()
+		testData.ethermanMock.EXPECT().GetTx(testData.ctx, signedTx.Hash()).Return(nil, false, ethereum.NotFound).Once()
+		testData.ethermanMock.EXPECT().SendTxIdempotent(testData.ctx, signedTx).Return(timeoutErr).Once()
+
+		testData.sut.monitorTx(testData.ctx, mTx, createMonitoredTxLogger(*mTx.MonitoredTx))
+		require.Equal(t, uint64(1), mTx.RetryCount)
+		require.Equal(t, types.MonitoredTxStatusCreated, mTx.Status)
+	})
+}
+
 func TestMonitorTxEstimateGasMaxRetriesIntegration(t *testing.T) {
 	// This test uses real storage to verify the complete flow
 	testData := newTestData(t, false)
@@ -459,6 +711,96 @@ func TestProcessPendingMonitoredTxs(t *testing.T) {
 		testData.sut.ProcessPendingMonitoredTxs(testData.ctx, resultHandler)
 		require.Equal(t, types.MonitoredTxStatusEvicted, status)
 	})
+
+	t.Run("Mined transaction - handler error keeps it pending", func(t *testing.T) {
+		testData := newTestData(t, true)
+		tx := types.MonitoredTx{
+			ID: common.HexToHash("0x1"), Status: types.MonitoredTxStatusMined,
+			CreatedAt: time.Now(), UpdatedAt: time.Now(),
+		}
+
+		safeTx := tx
+		safeTx.Status = types.MonitoredTxStatusSafe
+
+		testData.storageMock.EXPECT().GetByStatus(mock.Anything, mock.Anything).Return([]types.MonitoredTx{tx}, nil).Twice()
+		// set as safe, set back as mined after the handler error and set as safe again
+		testData.storageMock.EXPECT().Get(mock.Anything, tx.ID).Return(tx, nil).Once()
+		testData.storageMock.EXPECT().Get(mock.Anything, tx.ID).Return(safeTx, nil).Once()
+		testData.storageMock.EXPECT().Get(mock.Anything, tx.ID).Return(tx, nil).Once()
+		testData.storageMock.EXPECT().Update(mock.Anything, mock.MatchedBy(func(mTx types.MonitoredTx) bool {
+			return mTx.ID == tx.ID && mTx.Status == types.MonitoredTxStatusSafe
+		})).Return(nil).Twice()
+		testData.storageMock.EXPECT().Update(mock.Anything, mock.MatchedBy(func(mTx types.MonitoredTx) bool {
+			return mTx.ID == tx.ID && mTx.Status == types.MonitoredTxStatusMined
+		})).Return(nil).Once()
+		testData.storageMock.EXPECT().GetByStatus(mock.Anything, mock.Anything).Return([]types.MonitoredTx{}, nil).Once()
+
+		var callCount, successCount int
+		resultHandler := func(result types.MonitoredTxResult) error {
+			callCount++
+			if callCount == 1 {
+				return errors.New("handler error")
+			}
+			successCount++
+			return nil
+		}
+
+		testData.sut.ProcessPendingMonitoredTxsWithErrHandler(testData.ctx, resultHandler)
+		require.Equal(t, 2, callCount)
+		require.Equal(t, 1, successCount)
+	})
+
+	t.Run("Mined transaction - set safe failure doesn't handle it twice", func(t *testing.T) {
+		testData := newTestData(t, true)
+		tx := types.MonitoredTx{
+			ID: common.HexToHash("0x1"), Status: types.MonitoredTxStatusMined,
+			CreatedAt: time.Now(), UpdatedAt: time.Now(),
+		}
+
+		testData.storageMock.EXPECT().GetByStatus(mock.Anything, mock.Anything).Return([]types.MonitoredTx{tx}, nil).Twice()
+		testData.storageMock.EXPECT().Get(mock.Anything, tx.ID).Return(tx, nil).Twice()
+		testData.storageMock.EXPECT().Update(mock.Anything, mock.Anything).Return(errors.New("database locked")).Once()
+		testData.storageMock.EXPECT().Update(mock.Anything, mock.Anything).Return(nil).Once()
+		testData.storageMock.EXPECT().GetByStatus(mock.Anything, mock.Anything).Return([]types.MonitoredTx{}, nil).Once()
+
+		var callCount int
+		resultHandler := func(result types.MonitoredTxResult) error {
+			callCount++
+			return nil
+		}
+
+		testData.sut.ProcessPendingMonitoredTxsWithErrHandler(testData.ctx, resultHandler)
+		require.Equal(t, 1, callCount)
+	})
+
+	t.Run("Pending transaction - polled with the configured interval", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.sut.cfg.PendingTxsPollInterval = configTypes.NewDuration(20 * time.Millisecond)
+		tx := types.MonitoredTx{
+			ID: common.HexToHash("0x1"), Status: types.MonitoredTxStatusSent,
+			CreatedAt: time.Now(), UpdatedAt: time.Now(),
+		}
+		minedTx := tx
+		minedTx.Status = types.MonitoredTxStatusMined
+
+		var polls []time.Time
+		recordPoll := func(context.Context, common.Hash) { polls = append(polls, time.Now()) }
+		testData.storageMock.EXPECT().GetByStatus(mock.Anything, mock.Anything).Return([]types.MonitoredTx{tx}, nil).Once()
+		testData.storageMock.EXPECT().Get(mock.Anything, tx.ID).Run(recordPoll).Return(tx, nil).Twice()
+		testData.storageMock.EXPECT().Get(mock.Anything, tx.ID).Run(recordPoll).Return(minedTx, nil).Once()
+		testData.storageMock.EXPECT().GetByStatus(mock.Anything, mock.Anything).Return([]types.MonitoredTx{}, nil).Once()
+
+		start := time.Now()
+		testData.sut.ProcessPendingMonitoredTxs(testData.ctx, func(types.MonitoredTxResult) {})
+		elapsed := time.Since(start)
+
+		require.Len(t, polls, 3)
+		for i := 1; i < len(polls); i++ {
+			require.GreaterOrEqual(t, polls[i].Sub(polls[i-1]), 20*time.Millisecond)
+		}
+		// the default interval would take at least 3s
+		require.Less(t, elapsed, time.Second)
+	})
 }
 
 func TestMonitorTxGasReviewFailureRetryIncrement(t *testing.T) {
@@ -537,7 +879,7 @@ func TestMonitorTxSendFailureRetryIncrement(t *testing.T) {
 		// Mock signing and transaction existence check, but fail on SendTx
 		testData.ethermanMock.EXPECT().SignTx(testData.ctx, mock.Anything, mock.Anything).Return(ethtypes.NewTx(&ethtypes.LegacyTx{}), nil).Once()
 		testData.ethermanMock.EXPECT().GetTx(testData.ctx, mock.Anything).Return(nil, false, ethereum.NotFound).Once()
-		testData.ethermanMock.EXPECT().SendTx(testData.ctx, mock.Anything).Return(errors.New("network error")).Once()
+		testData.ethermanMock.EXPECT().SendTxIdempotent(testData.ctx, mock.Anything).Return(errors.New("network error")).Once()
 
 		// Mock storage updates - first for history, second for retry count after send failure
 		testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(nil).Times(2)
@@ -548,3 +890,4060 @@ func TestMonitorTxSendFailureRetryIncrement(t *testing.T) {
 		require.Equal(t, uint64(2), mTx.RetryCount)
 	})
 }
+
+func TestAddWithFees(t *testing.T) {
+	testData := newTestData(t, true)
+	to := common.HexToAddress("0x1")
+	feeCap := big.NewInt(100)
+	tipCap := big.NewInt(10)
+
+	_, err := testData.sut.AddWithFees(testData.ctx, &to, big.NewInt(1), []byte{}, 0, nil, 21000, nil, tipCap)
+	require.Error(t, err)
+
+	testData.storageMock.EXPECT().Add(testData.ctx, mock.MatchedBy(func(mTx types.MonitoredTx) bool {
+		return mTx.FixedFees && !mTx.EstimateGas &&
+			mTx.Gas == 21000 &&
+			mTx.GasPrice.Cmp(feeCap) == 0 &&
+			mTx.GasTipCap.Cmp(tipCap) == 0
+	})).Return(nil).Once()
+
+	_, err = testData.sut.AddWithFees(testData.ctx, &to, big.NewInt(1), []byte{}, 0, nil, 21000, feeCap, tipCap)
+	require.NoError(t, err)
+}
+
+func TestReviewMonitoredTxGasFixedFees(t *testing.T) {
+	testData := newTestData(t, true)
+	testData.sut.cfg.ReestimateGasOnReview = true
+	to := common.HexToAddress("0x1")
+
+	mTx := &monitoredTxnIteration{
+		MonitoredTx: &types.MonitoredTx{
+			ID:          common.HexToHash("0x123"),
+			From:        common.HexToAddress("0x456"),
+			To:          &to,
+			Status:      types.MonitoredTxStatusSent,
+			Value:       big.NewInt(0),
+			Gas:         21000,
+			GasPrice:    big.NewInt(100),
+			GasTipCap:   big.NewInt(10),
+			EstimateGas: true,
+			FixedFees:   true,
+			History:     make(map[common.Hash]bool),
+		},
+	}
+
+	// SuggestedGasPrice is not expected to be called since the fees are pinned
+	testData.ethermanMock.EXPECT().EstimateGas(testData.ctx, mTx.From, mTx.To, mTx.Value, mTx.Data).Return(uint64(25000), nil).Twice()
+	testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(nil).Twice()
+
+	logger := createMonitoredTxLogger(*mTx.MonitoredTx)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, testData.sut.reviewMonitoredTxGas(testData.ctx, mTx, logger))
+		require.Equal(t, big.NewInt(100), mTx.GasPrice)
+		require.Equal(t, big.NewInt(10), mTx.GasTipCap)
+		require.Equal(t, uint64(25000), mTx.Gas)
+	}
+
+	tx := mTx.Tx()
+	require.Equal(t, uint8(ethtypes.DynamicFeeTxType), tx.Type())
+	require.Equal(t, big.NewInt(100), tx.GasFeeCap())
+	require.Equal(t, big.NewInt(10), tx.GasTipCap())
+}
+
+func TestAddWithKey(t *testing.T) {
+	testData := newTestData(t, false)
+	testData.sut.from = common.HexToAddress("0x2")
+	to := common.HexToAddress("0x1")
+	data := []byte("data")
+	testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(1), nil)
+	testData.ethermanMock.EXPECT().EstimateGas(testData.ctx, testData.sut.from, &to, big.NewInt(1), data).Return(uint64(21000), nil)
+
+	// identical payloads collide when using the default id
+	id, err := testData.sut.AddWithGas(testData.ctx, &to, big.NewInt(1), data, 0, nil, 21000)
+	require.NoError(t, err)
+	_, err = testData.sut.AddWithGas(testData.ctx, &to, big.NewInt(1), data, 0, nil, 21000)
+	require.ErrorIs(t, err, types.ErrAlreadyExists)
+
+	// distinct keys disambiguate identical payloads
+	idA, err := testData.sut.AddWithKey(testData.ctx, &to, big.NewInt(1), data, 0, nil, []byte("a"))
+	require.NoError(t, err)
+	idB, err := testData.sut.AddWithKey(testData.ctx, &to, big.NewInt(1), data, 0, nil, []byte("b"))
+	require.NoError(t, err)
+	require.NotEqual(t, idA, idB)
+	require.NotEqual(t, id, idA)
+
+	// same key dedups intentionally
+	_, err = testData.sut.AddWithKey(testData.ctx, &to, big.NewInt(1), data, 0, nil, []byte("a"))
+	require.ErrorIs(t, err, types.ErrAlreadyExists)
+
+	_, err = testData.sut.AddWithKey(testData.ctx, &to, big.NewInt(1), data, 0, nil, nil)
+	require.Error(t, err)
+}
+
+func TestReviewMonitoredTxGasReestimation(t *testing.T) {
+	to := common.HexToAddress("0x1")
+	newIteration := func() *monitoredTxnIteration {
+		return &monitoredTxnIteration{
+			MonitoredTx: &types.MonitoredTx{
+				ID:          common.HexToHash("0x123"),
+				From:        common.HexToAddress("0x456"),
+				To:          &to,
+				Status:      types.MonitoredTxStatusSent,
+				Value:       big.NewInt(0),
+				Gas:         21000,
+				GasPrice:    big.NewInt(100),
+				EstimateGas: true,
+				History:     make(map[common.Hash]bool),
+			},
+		}
+	}
+
+	tests := []struct {
+		name                  string
+		reestimateGasOnReview bool
+		lastReceipt           *ethtypes.Receipt
+		estimatedGas          uint64
+		expectedEstimateCalls int
+		expectedGas           uint64
+	}{
+		{
+			name:                  "re-estimate on every review",
+			reestimateGasOnReview: true,
+			estimatedGas:          22000,
+			expectedEstimateCalls: 2,
+			expectedGas:           22000,
+		},
+		{
+			name:                  "reuse last estimation",
+			reestimateGasOnReview: false,
+			expectedEstimateCalls: 0,
+			expectedGas:           21000,
+		},
+		{
+			name:                  "reuse last estimation but last tx ran out of gas",
+			reestimateGasOnReview: false,
+			lastReceipt:           &ethtypes.Receipt{Status: ethtypes.ReceiptStatusFailed, GasUsed: 21000},
+			estimatedGas:          21000,
+			expectedEstimateCalls: 2,
+			// multiplied twice, once per review
+			expectedGas: 30240,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testData := newTestData(t, true)
+			testData.sut.cfg.ReestimateGasOnReview = tt.reestimateGasOnReview
+			testData.sut.cfg.OutOfGasMultiplier = 1.2
+			mTx := newIteration()
+			mTx.lastReceipt = tt.lastReceipt
+
+			testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(100), nil).Times(2)
+			if tt.expectedEstimateCalls > 0 {
+				testData.ethermanMock.EXPECT().EstimateGas(testData.ctx, mTx.From, mTx.To, mTx.Value, mTx.Data).
+					Return(tt.estimatedGas, nil).Times(tt.expectedEstimateCalls)
+			}
+			testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(nil).Times(2)
+
+			logger := createMonitoredTxLogger(*mTx.MonitoredTx)
+			for i := 0; i < 2; i++ {
+				if tt.lastReceipt != nil {
+					// the receipt of the last tx always consumes all the gas provided
+					tt.lastReceipt.GasUsed = mTx.Gas
+				}
+				require.NoError(t, testData.sut.reviewMonitoredTxGas(testData.ctx, mTx, logger))
+			}
+
+			require.Equal(t, tt.expectedGas, mTx.Gas)
+			testData.ethermanMock.AssertNumberOfCalls(t, "EstimateGas", tt.expectedEstimateCalls)
+		})
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	source := newTestData(t, false)
+	target := newTestData(t, false)
+
+	to := common.HexToAddress("0x1")
+	mTxs := []types.MonitoredTx{
+		{
+			ID: common.HexToHash("0x1"), From: common.HexToAddress("0x2"), To: &to,
+			Nonce: 1, Value: big.NewInt(1), Data: []byte("data"),
+			Gas: 21000, GasPrice: big.NewInt(100),
+			Status:      types.MonitoredTxStatusSent,
+			History:     map[common.Hash]bool{common.HexToHash("0x3"): true},
+			BlockNumber: big.NewInt(10),
+			CreatedAt:   time.Now().Add(-time.Hour).Truncate(time.Second),
+			UpdatedAt:   time.Now().Add(-time.Minute).Truncate(time.Second),
+			EstimateGas: true,
+		},
+		{
+			ID: common.HexToHash("0x4"), From: common.HexToAddress("0x2"), To: &to,
+			Nonce: 2, Value: big.NewInt(2), Data: []byte("blob"),
+			Gas: 50000, GasPrice: big.NewInt(100), GasTipCap: big.NewInt(10), BlobGasPrice: big.NewInt(1),
+			BlobSidecar: &ethtypes.BlobTxSidecar{
+				Blobs:       []kzg4844.Blob{{1, 2, 3}},
+				Commitments: []kzg4844.Commitment{{4, 5, 6}},
+				Proofs:      []kzg4844.Proof{{7, 8, 9}},
+			},
+			Status:    types.MonitoredTxStatusCreated,
+			History:   map[common.Hash]bool{},
+			CreatedAt: time.Now().Add(-time.Hour).Truncate(time.Second),
+			UpdatedAt: time.Now().Add(-time.Hour).Truncate(time.Second),
+		},
+	}
+	for _, mTx := range mTxs {
+		require.NoError(t, source.sut.storage.Add(source.ctx, mTx))
+	}
+	// an outdated version of one of the txs already exists in the target storage
+	outdated := mTxs[0]
+	outdated.Status = types.MonitoredTxStatusCreated
+	require.NoError(t, target.sut.storage.Add(target.ctx, outdated))
+
+	var buf bytes.Buffer
+	require.NoError(t, source.sut.Export(source.ctx, &buf))
+	require.NoError(t, target.sut.Import(target.ctx, &buf))
+
+	for _, mTx := range mTxs {
+		imported, err := target.sut.storage.Get(target.ctx, mTx.ID)
+		require.NoError(t, err)
+		require.True(t, mTx.CreatedAt.Equal(imported.CreatedAt))
+		require.True(t, mTx.UpdatedAt.Equal(imported.UpdatedAt))
+		compareTxsWithoutDates(t, mTx, imported)
+	}
+
+	t.Run("the replacement is atomic", func(t *testing.T) {
+		testData := newTestData(t, true)
+		txStorage := mocks.NewStorageInterface(t)
+		testData.storageMock.EXPECT().WithTx(testData.ctx, mock.Anything).
+			RunAndReturn(func(_ context.Context, fn func(types.StorageInterface) error) error {
+				return fn(txStorage)
+			}).Once()
+		txStorage.EXPECT().Remove(testData.ctx, mTxs[0].ID).Return(nil).Once()
+		txStorage.EXPECT().Add(testData.ctx, mock.Anything).Return(errors.New("add failed")).Once()
+
+		var buf bytes.Buffer
+		require.NoError(t, source.sut.Export(source.ctx, &buf))
+		require.ErrorContains(t, testData.sut.Import(testData.ctx, &buf), "add failed")
+	})
+}
+
+func TestMonitorTxIntrinsicGasTooLow(t *testing.T) {
+	testData := newTestData(t, true)
+	testData.sut.cfg.RaiseGasOnIntrinsicGasTooLow = true
+	to := common.HexToAddress("0x1")
+
+	mTx := &monitoredTxnIteration{
+		MonitoredTx: &types.MonitoredTx{
+			ID:       common.HexToHash("0x123"),
+			From:     common.HexToAddress("0x456"),
+			To:       &to,
+			Status:   types.MonitoredTxStatusCreated,
+			Value:    big.NewInt(0),
+			Data:     []byte("data"),
+			Gas:      21000,
+			GasPrice: big.NewInt(1000000000),
+			History:  make(map[common.Hash]bool),
+		},
+	}
+
+	testData.ethermanMock.EXPECT().SignTx(testData.ctx, mTx.From, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ common.Address, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+			return tx, nil
+		}).Twice()
+	testData.ethermanMock.EXPECT().GetTx(testData.ctx, mock.Anything).Return(nil, false, ethereum.NotFound).Once()
+	testData.ethermanMock.EXPECT().SendTxIdempotent(testData.ctx, mock.MatchedBy(func(tx *ethtypes.Transaction) bool {
+		return tx.Gas() == 21000
+	})).Return(errors.New("intrinsic gas too low: gas 21000, minimum needed 21064")).Once()
+	testData.ethermanMock.EXPECT().EstimateGas(testData.ctx, mTx.From, mTx.To, mTx.Value, mTx.Data).Return(uint64(21064), nil).Once()
+	testData.ethermanMock.EXPECT().SendTxIdempotent(testData.ctx, mock.MatchedBy(func(tx *ethtypes.Transaction) bool {
+		return tx.Gas() == 21064
+	})).Return(nil).Once()
+	testData.ethermanMock.EXPECT().WaitTxToBeMined(testData.ctx, mock.MatchedBy(func(tx *ethtypes.Transaction) bool {
+		return tx.Gas() == 21064
+	}), mock.Anything).Return(false, nil).Once()
+	testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(nil)
+
+	logger := createMonitoredTxLogger(*mTx.MonitoredTx)
+	testData.sut.monitorTx(testData.ctx, mTx, logger)
+
+	require.Equal(t, uint64(21064), mTx.Gas)
+	require.Equal(t, types.MonitoredTxStatusSent, mTx.Status)
+	require.Equal(t, uint64(0), mTx.RetryCount)
+	require.Len(t, mTx.History, 2)
+}
+
+func TestMonitorTxTypeNotSupported(t *testing.T) {
+	to := common.HexToAddress("0x1")
+	errTypeNotSupported := errors.New("transaction type not supported")
+
+	t.Run("dynamic fee tx falls back to legacy", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.sut.cfg.AllowLegacyFallback = true
+
+		mTx := &monitoredTxnIteration{
+			MonitoredTx: &types.MonitoredTx{
+				ID:        common.HexToHash("0x123"),
+				From:      common.HexToAddress("0x456"),
+				To:        &to,
+				Status:    types.MonitoredTxStatusCreated,
+				Value:     big.NewInt(0),
+				Gas:       21000,
+				GasPrice:  big.NewInt(100),
+				GasTipCap: big.NewInt(10),
+				History:   make(map[common.Hash]bool),
+			},
+		}
+
+		testData.ethermanMock.EXPECT().SignTx(testData.ctx, mTx.From, mock.Anything).
+			RunAndReturn(func(_ context.Context, _ common.Address, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+				return tx, nil
+			}).Twice()
+		testData.ethermanMock.EXPECT().GetTx(testData.ctx, mock.Anything).Return(nil, false, ethereum.NotFound).Once()
+		testData.ethermanMock.EXPECT().SendTxIdempotent(testData.ctx, mock.MatchedBy(func(tx *ethtypes.Transaction) bool {
+			return tx.Type() == ethtypes.DynamicFeeTxType
+		})).Return(errTypeNotSupported).Once()
+		testData.ethermanMock.EXPECT().SendTxIdempotent(testData.ctx, mock.MatchedBy(func(tx *ethtypes.Transaction) bool {
+			return tx.Type() == ethtypes.LegacyTxType && tx.GasPrice().Cmp(big.NewInt(100)) == 0
+		})).Return(nil).Once()
+		testData.ethermanMock.EXPECT().WaitTxToBeMined(testData.ctx, mock.Anything, mock.Anything).Return(false, nil).Once()
+		testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(nil)
+
+		logger := createMonitoredTxLogger(*mTx.MonitoredTx)
+		testData.sut.monitorTx(testData.ctx, mTx, logger)
+
+		require.Nil(t, mTx.GasTipCap)
+		require.True(t, mTx.TxTypeConverted)
+		require.Equal(t, ethtypes.LegacyTxType, int(mTx.Tx().Type()))
+		require.Equal(t, types.MonitoredTxStatusSent, mTx.Status)
+		require.Len(t, mTx.History, 2)
+	})
+
+	t.Run("upgraded legacy tx doesn't fall back", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.sut.cfg.AllowLegacyFallback = true
+
+		mTx := &monitoredTxnIteration{
+			MonitoredTx: &types.MonitoredTx{
+				ID:              common.HexToHash("0x123"),
+				From:            common.HexToAddress("0x456"),
+				To:              &to,
+				Status:          types.MonitoredTxStatusCreated,
+				Value:           big.NewInt(0),
+				Gas:             21000,
+				GasPrice:        big.NewInt(100),
+				GasTipCap:       big.NewInt(10),
+				TxTypeConverted: true,
+				History:         make(map[common.Hash]bool),
+			},
+		}
+
+		testData.ethermanMock.EXPECT().SignTx(testData.ctx, mTx.From, mock.Anything).
+			RunAndReturn(func(_ context.Context, _ common.Address, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+				return tx, nil
+			}).Once()
+		testData.ethermanMock.EXPECT().GetTx(testData.ctx, mock.Anything).Return(nil, false, ethereum.NotFound).Once()
+		testData.ethermanMock.EXPECT().SendTxIdempotent(testData.ctx, mock.Anything).Return(errTypeNotSupported).Once()
+		testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(nil)
+
+		logger := createMonitoredTxLogger(*mTx.MonitoredTx)
+		testData.sut.monitorTx(testData.ctx, mTx, logger)
+
+		require.Equal(t, big.NewInt(10), mTx.GasTipCap)
+		require.Equal(t, types.MonitoredTxStatusCreated, mTx.Status)
+		require.Len(t, mTx.History, 1)
+	})
+
+	t.Run("blob tx is evicted", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.sut.cfg.AllowLegacyFallback = true
+
+		mTx := &monitoredTxnIteration{
+			MonitoredTx: &types.MonitoredTx{
+				ID:           common.HexToHash("0x123"),
+				From:         common.HexToAddress("0x456"),
+				To:           &to,
+				Status:       types.MonitoredTxStatusCreated,
+				Value:        big.NewInt(0),
+				Gas:          21000,
+				GasPrice:     big.NewInt(100),
+				GasTipCap:    big.NewInt(10),
+				BlobSidecar:  &ethtypes.BlobTxSidecar{},
+				BlobGasPrice: big.NewInt(1),
+				History:      make(map[common.Hash]bool),
+			},
+		}
+
+		testData.ethermanMock.EXPECT().SignTx(testData.ctx, mTx.From, mock.Anything).
+			RunAndReturn(func(_ context.Context, _ common.Address, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+				return tx, nil
+			}).Once()
+		testData.ethermanMock.EXPECT().GetTx(testData.ctx, mock.Anything).Return(nil, false, ethereum.NotFound).Once()
+		testData.ethermanMock.EXPECT().SendTxIdempotent(testData.ctx, mock.Anything).Return(errTypeNotSupported).Once()
+		testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(nil).Once()
+		testData.storageMock.EXPECT().Update(testData.ctx, mock.MatchedBy(func(tx types.MonitoredTx) bool {
+			return tx.Status == types.MonitoredTxStatusEvicted && strings.Contains(tx.Reason, errTypeNotSupported.Error())
+		})).Return(nil).Once()
+
+		logger := createMonitoredTxLogger(*mTx.MonitoredTx)
+		testData.sut.monitorTx(testData.ctx, mTx, logger)
+
+		require.Equal(t, types.MonitoredTxStatusEvicted, mTx.Status)
+	})
+}
+
+func TestMonitorTxInsufficientFunds(t *testing.T) {
+	testData := newTestData(t, true)
+	testData.sut.cfg.CheckSenderBalance = true
+	to := common.HexToAddress("0x1")
+
+	mTx := &monitoredTxnIteration{
+		MonitoredTx: &types.MonitoredTx{
+			ID:       common.HexToHash("0x123"),
+			From:     common.HexToAddress("0x456"),
+			To:       &to,
+			Status:   types.MonitoredTxStatusCreated,
+			Value:    big.NewInt(1),
+			Data:     []byte("data"),
+			Gas:      21000,
+			GasPrice: big.NewInt(1000000000),
+			History:  make(map[common.Hash]bool),
+		},
+	}
+	cost := mTx.Tx().Cost()
+
+	testData.ethermanMock.EXPECT().SignTx(testData.ctx, mTx.From, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ common.Address, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+			return tx, nil
+		})
+	testData.ethermanMock.EXPECT().GetTx(testData.ctx, mock.Anything).Return(nil, false, ethereum.NotFound)
+	testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(nil)
+
+	// the sender can't afford the tx, so it's not sent in any of the cycles
+	testData.ethermanMock.EXPECT().BalanceAt(testData.ctx, mTx.From).Return(new(big.Int).Sub(cost, big.NewInt(1)), nil).Twice()
+	logger := createMonitoredTxLogger(*mTx.MonitoredTx)
+	testData.sut.monitorTx(testData.ctx, mTx, logger)
+	testData.sut.monitorTx(testData.ctx, mTx, logger)
+
+	require.Equal(t, types.MonitoredTxStatusCreated, mTx.Status)
+	require.Equal(t, uint64(0), mTx.RetryCount)
+	_, reported := testData.sut.insufficientFunds.Load(mTx.ID)
+	require.True(t, reported)
+	require.Contains(t, mTx.Reason, ErrInsufficientFunds.Error())
+
+	// once funded, the tx is sent
+	testData.ethermanMock.EXPECT().BalanceAt(testData.ctx, mTx.From).Return(cost, nil).Once()
+	testData.ethermanMock.EXPECT().SendTxIdempotent(testData.ctx, mock.Anything).Return(nil).Once()
+	testData.ethermanMock.EXPECT().WaitTxToBeMined(testData.ctx, mock.Anything, mock.Anything).Return(false, nil).Once()
+	testData.sut.monitorTx(testData.ctx, mTx, logger)
+
+	require.Equal(t, types.MonitoredTxStatusSent, mTx.Status)
+	_, reported = testData.sut.insufficientFunds.Load(mTx.ID)
+	require.False(t, reported)
+	require.Empty(t, mTx.Reason)
+}
+
+func TestReconcileSentTxs(t *testing.T) {
+	testData := newTestData(t, false)
+	to := common.HexToAddress("0x1")
+	minedTx := ethtypes.NewTransaction(1, to, big.NewInt(1), 21000, big.NewInt(1), nil)
+	pendingTx := ethtypes.NewTransaction(2, to, big.NewInt(1), 21000, big.NewInt(1), nil)
+
+	// txs sent before the restart
+	mined := types.MonitoredTx{
+		ID: common.HexToHash("0x1"), From: common.HexToAddress("0x2"), To: &to, Nonce: 1,
+		Value: big.NewInt(1), Data: []byte("data"), Gas: 21000, GasPrice: big.NewInt(1),
+		Status: types.MonitoredTxStatusSent, History: map[common.Hash]bool{minedTx.Hash(): true},
+	}
+	pending := mined
+	pending.ID = common.HexToHash("0x3")
+	pending.Nonce = 2
+	pending.History = map[common.Hash]bool{pendingTx.Hash(): true}
+	// a tx being processed by the monitoring is left to it
+	inFlight := pending
+	inFlight.ID = common.HexToHash("0x4")
+	inFlight.Nonce = 3
+	require.NoError(t, testData.sut.storage.Add(testData.ctx, mined))
+	require.NoError(t, testData.sut.storage.Add(testData.ctx, pending))
+	require.NoError(t, testData.sut.storage.Add(testData.ctx, inFlight))
+	testData.sut.processingTxs.Store(inFlight.ID, struct{}{})
+
+	receipt := &ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful, BlockNumber: big.NewInt(10)}
+	testData.ethermanMock.EXPECT().CheckTxWasMined(testData.ctx, minedTx.Hash()).Return(true, receipt, nil).Once()
+	testData.ethermanMock.EXPECT().CheckTxWasMined(testData.ctx, pendingTx.Hash()).Return(false, nil, nil).Once()
+
+	require.NoError(t, testData.sut.reconcileSentTxs(testData.ctx))
+
+	reconciled, err := testData.sut.storage.Get(testData.ctx, mined.ID)
+	require.NoError(t, err)
+	require.Equal(t, types.MonitoredTxStatusMined, reconciled.Status)
+	require.Equal(t, big.NewInt(10), reconciled.BlockNumber)
+
+	notReconciled, err := testData.sut.storage.Get(testData.ctx, pending.ID)
+	require.NoError(t, err)
+	require.Equal(t, types.MonitoredTxStatusSent, notReconciled.Status)
+}
+
+func TestCanonicalReceipt(t *testing.T) {
+	earlierTxHash := common.HexToHash("0x1")
+	laterTxHash := common.HexToHash("0x2")
+
+	tests := []struct {
+		name              string
+		earlierStatus     uint64
+		laterStatus       uint64
+		expectedCanonical common.Hash
+		expectedFailed    int
+	}{
+		{
+			name:              "both successful, the later one is canonical",
+			earlierStatus:     ethtypes.ReceiptStatusSuccessful,
+			laterStatus:       ethtypes.ReceiptStatusSuccessful,
+			expectedCanonical: laterTxHash,
+		},
+		{
+			name:              "successful preferred over a later failed one",
+			earlierStatus:     ethtypes.ReceiptStatusSuccessful,
+			laterStatus:       ethtypes.ReceiptStatusFailed,
+			expectedCanonical: earlierTxHash,
+			expectedFailed:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			etherman := mocks.NewEthermanInterface(t)
+			earlierReceipt := &ethtypes.Receipt{Status: tt.earlierStatus, TxHash: earlierTxHash, BlockNumber: big.NewInt(10)}
+			laterReceipt := &ethtypes.Receipt{Status: tt.laterStatus, TxHash: laterTxHash, BlockNumber: big.NewInt(12)}
+			etherman.EXPECT().CheckTxWasMined(ctx, earlierTxHash).Return(true, earlierReceipt, nil).Once()
+			etherman.EXPECT().CheckTxWasMined(ctx, laterTxHash).Return(true, laterReceipt, nil).Once()
+
+			mTx := &monitoredTxnIteration{
+				MonitoredTx: &types.MonitoredTx{
+					ID:      common.HexToHash("0x123"),
+					Status:  types.MonitoredTxStatusSent,
+					History: map[common.Hash]bool{earlierTxHash: true, laterTxHash: true},
+				},
+			}
+
+			require.False(t, mTx.shouldUpdateNonce(ctx, etherman))
+			require.True(t, mTx.confirmed)
+			require.Equal(t, tt.expectedCanonical, mTx.lastReceipt.TxHash)
+			require.Equal(t, tt.expectedFailed, mTx.failedReceipts)
+		})
+	}
+}
+
+func TestReconcileNonces(t *testing.T) {
+	to := common.HexToAddress("0x1")
+	sender := common.HexToAddress("0x2")
+	newMonitoredTx := func(id string, nonce uint64, status types.MonitoredTxStatus) types.MonitoredTx {
+		return types.MonitoredTx{
+			ID: common.HexToHash(id), From: sender, To: &to, Nonce: nonce,
+			Value: big.NewInt(1), Gas: 21000, GasPrice: big.NewInt(1),
+			Status: status, History: map[common.Hash]bool{},
+		}
+	}
+	sent := newMonitoredTx("0x1", 5, types.MonitoredTxStatusSent)
+	// the nonce 3 was consumed by an external tx of the same sender
+	consumed := newMonitoredTx("0x2", 3, types.MonitoredTxStatusCreated)
+	valid := newMonitoredTx("0x3", 6, types.MonitoredTxStatusCreated)
+
+	tests := []struct {
+		name          string
+		reassign      bool
+		inFlight      bool
+		expectedNonce uint64
+	}{
+		{name: "reported only", reassign: false, expectedNonce: 3},
+		{name: "reassigned", reassign: true, expectedNonce: 7},
+		{name: "in flight not reassigned", reassign: true, inFlight: true, expectedNonce: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testData := newTestData(t, false)
+			testData.sut.cfg.ReassignConsumedNonces = tt.reassign
+			for _, mTx := range []types.MonitoredTx{sent, consumed, valid} {
+				require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+			}
+			if tt.inFlight {
+				testData.sut.processingTxs.Store(consumed.ID, struct{}{})
+			}
+
+			testData.ethermanMock.EXPECT().CurrentNonce(testData.ctx, sender).Return(uint64(5), nil).Once()
+			testData.ethermanMock.EXPECT().PendingNonce(testData.ctx, sender).Return(uint64(6), nil).Once()
+
+			require.NoError(t, testData.sut.reconcileNonces(testData.ctx))
+
+			reconciled, err := testData.sut.storage.Get(testData.ctx, consumed.ID)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedNonce, reconciled.Nonce)
+
+			notReconciled, err := testData.sut.storage.Get(testData.ctx, valid.ID)
+			require.NoError(t, err)
+			require.Equal(t, valid.Nonce, notReconciled.Nonce)
+
+			// 1 tx pending in the network and 1 sent monitored tx
+			drifts := testData.sut.NonceDrifts()
+			require.Len(t, drifts, 1)
+			require.Equal(t, sender, drifts[0].Sender)
+			require.Equal(t, uint64(1), drifts[0].SentTxs)
+			require.Equal(t, int64(0), drifts[0].Drift)
+		})
+	}
+
+	t.Run("failed sender doesn't stop the others", func(t *testing.T) {
+		testData := newTestData(t, false)
+		otherSender := common.HexToAddress("0x4")
+		other := newMonitoredTx("0x4", 1, types.MonitoredTxStatusSent)
+		other.From = otherSender
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, sent))
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, other))
+
+		testData.ethermanMock.EXPECT().CurrentNonce(testData.ctx, sender).Return(uint64(0), errors.New("boom")).Once()
+		testData.ethermanMock.EXPECT().CurrentNonce(testData.ctx, otherSender).Return(uint64(1), nil).Once()
+		// the sent tx of the other sender was dropped by the network
+		testData.ethermanMock.EXPECT().PendingNonce(testData.ctx, otherSender).Return(uint64(1), nil).Once()
+
+		require.Error(t, testData.sut.reconcileNonces(testData.ctx))
+
+		drifts := testData.sut.NonceDrifts()
+		require.Len(t, drifts, 1)
+		require.Equal(t, otherSender, drifts[0].Sender)
+		require.Equal(t, int64(-1), drifts[0].Drift)
+	})
+}
+
+func TestMonitorTxStuckTxPolicy(t *testing.T) {
+	to := common.HexToAddress("0x1")
+	firstTx := ethtypes.NewTransaction(1, to, big.NewInt(1), 21000, big.NewInt(1), nil)
+	secondTx := ethtypes.NewTransaction(2, to, big.NewInt(1), 21000, big.NewInt(1), nil)
+	failedReceipt := &ethtypes.Receipt{
+		Status: ethtypes.ReceiptStatusFailed, TxHash: secondTx.Hash(), BlockNumber: big.NewInt(10),
+	}
+
+	tests := []struct {
+		name           string
+		policy         StuckTxPolicy
+		expectedStatus types.MonitoredTxStatus
+	}{
+		{
+			name:           "bump - keeps monitoring the tx",
+			policy:         StuckTxPolicyBump,
+			expectedStatus: types.MonitoredTxStatusSent,
+		},
+		{
+			name:           "fail - sets the tx as failed",
+			policy:         StuckTxPolicyFail,
+			expectedStatus: types.MonitoredTxStatusFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testData := newTestData(t, true)
+			testData.sut.cfg.StuckTxPolicy = tt.policy
+
+			mTx := &monitoredTxnIteration{
+				MonitoredTx: &types.MonitoredTx{
+					ID:      common.HexToHash("0x123"),
+					Status:  types.MonitoredTxStatusSent,
+					History: map[common.Hash]bool{firstTx.Hash(): true, secondTx.Hash(): true},
+				},
+				confirmed:      true,
+				lastReceipt:    failedReceipt,
+				failedReceipts: 2,
+			}
+
+			// the tx reverted without revealing the reason
+			testData.ethermanMock.EXPECT().GetTx(testData.ctx, secondTx.Hash()).Return(secondTx, false, nil).Once()
+			testData.ethermanMock.EXPECT().GetRevertMessage(testData.ctx, secondTx).Return("", ErrExecutionReverted).Once()
+
+			// the failed receipts counted when the tx was loaded are used without requesting them again
+			if tt.policy == StuckTxPolicyFail {
+				testData.storageMock.EXPECT().Update(testData.ctx, mock.MatchedBy(func(tx types.MonitoredTx) bool {
+					return tx.Status == types.MonitoredTxStatusFailed
+				})).Return(nil).Once()
+			}
+
+			logger := createMonitoredTxLogger(*mTx.MonitoredTx)
+			testData.sut.monitorTx(testData.ctx, mTx, logger)
+
+			require.Equal(t, tt.expectedStatus, mTx.Status)
+		})
+	}
+}
+
+func TestOnStatus(t *testing.T) {
+	testData := newTestData(t, true)
+	to := common.HexToAddress("0x1")
+	tx := ethtypes.NewTransaction(1, to, big.NewInt(1), 21000, big.NewInt(1), nil)
+
+	var failedCalls, minedCalls int
+	testData.sut.OnStatus(types.MonitoredTxStatusFailed, func(_ context.Context, mTx types.MonitoredTx) {
+		require.Equal(t, types.MonitoredTxStatusFailed, mTx.Status)
+		failedCalls++
+	})
+	testData.sut.OnStatus(types.MonitoredTxStatusMined, func(_ context.Context, _ types.MonitoredTx) {
+		minedCalls++
+	})
+	// a panicking hook doesn't prevent the other hooks from running
+	testData.sut.OnStatus(types.MonitoredTxStatusFailed, func(_ context.Context, _ types.MonitoredTx) {
+		panic("hook failure")
+	})
+
+	mTx := &monitoredTxnIteration{
+		MonitoredTx: &types.MonitoredTx{
+			ID:      common.HexToHash("0x123"),
+			Status:  types.MonitoredTxStatusSent,
+			History: map[common.Hash]bool{tx.Hash(): true},
+		},
+		confirmed: true,
+		lastReceipt: &ethtypes.Receipt{
+			Status: ethtypes.ReceiptStatusFailed, TxHash: tx.Hash(), BlockNumber: big.NewInt(10),
+		},
+	}
+
+	testData.ethermanMock.EXPECT().GetTx(testData.ctx, tx.Hash()).Return(tx, false, nil).Once()
+	testData.ethermanMock.EXPECT().GetRevertMessage(testData.ctx, tx).Return("invalid batch", nil).Once()
+	testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(nil).Once()
+
+	logger := createMonitoredTxLogger(*mTx.MonitoredTx)
+	testData.sut.monitorTx(testData.ctx, mTx, logger)
+
+	require.Equal(t, types.MonitoredTxStatusFailed, mTx.Status)
+	require.Equal(t, 1, failedCalls)
+	require.Equal(t, 0, minedCalls)
+}
+
+func TestRevertReasons(t *testing.T) {
+	testData := newTestData(t, true)
+	testData.sut.cfg.RevertReasonsWindow = configTypes.NewDuration(time.Hour)
+	testData.sut.cfg.RevertReasonAlertThreshold = 3
+	now := time.Now()
+	testData.sut.revertReasons.now = func() time.Time { return now }
+
+	to := common.HexToAddress("0x1")
+	revertReasons := []string{"contract paused", "invalid batch", "contract paused", "contract paused"}
+	for i, reason := range revertReasons {
+		tx := ethtypes.NewTransaction(uint64(i), to, big.NewInt(1), 21000, big.NewInt(1), nil)
+		mTx := &monitoredTxnIteration{
+			MonitoredTx: &types.MonitoredTx{
+				ID:      common.BigToHash(big.NewInt(int64(i + 1))),
+				Status:  types.MonitoredTxStatusSent,
+				History: map[common.Hash]bool{tx.Hash(): true},
+			},
+			confirmed: true,
+			lastReceipt: &ethtypes.Receipt{
+				Status: ethtypes.ReceiptStatusFailed, TxHash: tx.Hash(), BlockNumber: big.NewInt(10),
+			},
+		}
+
+		testData.ethermanMock.EXPECT().GetTx(testData.ctx, tx.Hash()).Return(tx, false, nil).Once()
+		testData.ethermanMock.EXPECT().GetRevertMessage(testData.ctx, tx).Return(reason, nil).Once()
+		testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(nil).Once()
+
+		testData.sut.monitorTx(testData.ctx, mTx, createMonitoredTxLogger(*mTx.MonitoredTx))
+		require.Equal(t, types.MonitoredTxStatusFailed, mTx.Status)
+		now = now.Add(time.Minute)
+	}
+
+	// the failures are aggregated by revert reason, the most repeated first
+	require.Equal(t, []RevertReasonCount{
+		{Reason: "contract paused", Count: 3, LastSeen: now.Add(-time.Minute)},
+		{Reason: "invalid batch", Count: 1, LastSeen: now.Add(-3 * time.Minute)},
+	}, testData.sut.RevertReasons())
+
+	// the failures out of the window are not counted anymore
+	now = now.Add(time.Hour - time.Minute)
+	require.Equal(t, []RevertReasonCount{
+		{Reason: "contract paused", Count: 1, LastSeen: now.Add(-time.Hour)},
+	}, testData.sut.RevertReasons())
+
+	now = now.Add(time.Hour)
+	require.Empty(t, testData.sut.RevertReasons())
+}
+
+func TestMonitorTxStorageUpdateRetries(t *testing.T) {
+	to := common.HexToAddress("0x1")
+	tx := ethtypes.NewTransaction(1, to, big.NewInt(1), 21000, big.NewInt(1), nil)
+	newIteration := func() *monitoredTxnIteration {
+		return &monitoredTxnIteration{
+			MonitoredTx: &types.MonitoredTx{
+				ID:      common.HexToHash("0x123"),
+				Status:  types.MonitoredTxStatusSent,
+				History: map[common.Hash]bool{tx.Hash(): true},
+			},
+			confirmed: true,
+			lastReceipt: &ethtypes.Receipt{
+				Status: ethtypes.ReceiptStatusSuccessful, TxHash: tx.Hash(), BlockNumber: big.NewInt(10),
+			},
+		}
+	}
+	storageErr := fmt.Errorf("%w: database is locked", types.ErrStorageUnavailable)
+
+	t.Run("update fails once then succeeds", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.sut.cfg.StorageUpdateMaxRetries = 2
+		testData.sut.cfg.StorageUpdateRetryBackoff = configTypes.NewDuration(time.Millisecond)
+		minedCalls := 0
+		testData.sut.OnStatus(types.MonitoredTxStatusMined, func(_ context.Context, _ types.MonitoredTx) {
+			minedCalls++
+		})
+
+		testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(storageErr).Once()
+		testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(nil).Once()
+
+		mTx := newIteration()
+		testData.sut.monitorTx(testData.ctx, mTx, createMonitoredTxLogger(*mTx.MonitoredTx))
+
+		require.Equal(t, types.MonitoredTxStatusMined, mTx.Status)
+		require.Equal(t, 1, minedCalls)
+	})
+
+	t.Run("update keeps failing", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.sut.cfg.StorageUpdateMaxRetries = 2
+		testData.sut.cfg.StorageUpdateRetryBackoff = configTypes.NewDuration(time.Millisecond)
+		minedCalls := 0
+		testData.sut.OnStatus(types.MonitoredTxStatusMined, func(_ context.Context, _ types.MonitoredTx) {
+			minedCalls++
+		})
+
+		testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(storageErr).Times(3)
+
+		mTx := newIteration()
+		testData.sut.monitorTx(testData.ctx, mTx, createMonitoredTxLogger(*mTx.MonitoredTx))
+
+		// the status that couldn't be stored is not notified
+		require.Equal(t, 0, minedCalls)
+	})
+
+	t.Run("storage unavailable is retryable", func(t *testing.T) {
+		require.True(t, isRetryableError(storageErr))
+		require.False(t, isRetryableError(errors.New("constraint failed")))
+	})
+}
+
+func TestOnStatusTimeout(t *testing.T) {
+	sut := &Client{cfg: Config{StatusHookTimeout: configTypes.NewDuration(10 * time.Millisecond)}}
+	release := make(chan struct{})
+	defer close(release)
+
+	sut.OnStatus(types.MonitoredTxStatusEvicted, func(_ context.Context, _ types.MonitoredTx) {
+		<-release
+	})
+
+	start := time.Now()
+	sut.notifyStatus(context.Background(), types.MonitoredTx{Status: types.MonitoredTxStatusEvicted})
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestForceResend(t *testing.T) {
+	testData := newTestData(t, false)
+	testData.sut.cfg.MaxGasPriceLimit = 100
+	to := common.HexToAddress("0x1")
+	sentTx := ethtypes.NewTransaction(1, to, big.NewInt(1), 21000, big.NewInt(10), nil)
+
+	pending := types.MonitoredTx{
+		ID: common.HexToHash("0x1"), From: common.HexToAddress("0x2"), To: &to, Nonce: 1,
+		Value: big.NewInt(1), Gas: 21000, GasPrice: big.NewInt(10),
+		Status: types.MonitoredTxStatusSent, History: map[common.Hash]bool{sentTx.Hash(): true},
+	}
+	mined := pending
+	mined.ID = common.HexToHash("0x3")
+	mined.Status = types.MonitoredTxStatusMined
+	require.NoError(t, testData.sut.storage.Add(testData.ctx, pending))
+	require.NoError(t, testData.sut.storage.Add(testData.ctx, mined))
+
+	// terminal txs are refused
+	err := testData.sut.ForceResend(testData.ctx, mined.ID, big.NewInt(500))
+	require.ErrorIs(t, err, ErrTerminalMonitoredTx)
+
+	// the gas price is required
+	require.Error(t, testData.sut.ForceResend(testData.ctx, pending.ID, nil))
+
+	// the txs being processed by the monitoring loop are refused
+	testData.sut.processingTxs.Store(pending.ID, struct{}{})
+	err = testData.sut.ForceResend(testData.ctx, pending.ID, big.NewInt(500))
+	require.ErrorIs(t, err, ErrMonitoredTxProcessing)
+	testData.sut.processingTxs.Delete(pending.ID)
+
+	forcedGasPrice := big.NewInt(500)
+	testData.ethermanMock.EXPECT().SignTx(testData.ctx, pending.From, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ common.Address, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+			return tx, nil
+		}).Once()
+	testData.ethermanMock.EXPECT().SendTxIdempotent(testData.ctx, mock.MatchedBy(func(tx *ethtypes.Transaction) bool {
+		return tx.GasPrice().Cmp(forcedGasPrice) == 0 && tx.Nonce() == pending.Nonce
+	})).Return(nil).Once()
+
+	require.NoError(t, testData.sut.ForceResend(testData.ctx, pending.ID, forcedGasPrice))
+
+	resent, err := testData.sut.storage.Get(testData.ctx, pending.ID)
+	require.NoError(t, err)
+	require.Equal(t, forcedGasPrice, resent.GasPrice)
+	require.Len(t, resent.History, 2)
+
+	// the tx is released once resent
+	_, processing := testData.sut.processingTxs.Load(pending.ID)
+	require.False(t, processing)
+
+	// a created tx is assigned the next nonce of its sender instead of its stale one
+	created := pending
+	created.ID = common.HexToHash("0x4")
+	created.Nonce = 0
+	created.Status = types.MonitoredTxStatusCreated
+	created.History = make(map[common.Hash]bool)
+	require.NoError(t, testData.sut.storage.Add(testData.ctx, created))
+	testData.ethermanMock.EXPECT().PendingNonce(testData.ctx, created.From).Return(uint64(1), nil).Once()
+	testData.ethermanMock.EXPECT().SignTx(testData.ctx, created.From, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ common.Address, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+			return tx, nil
+		}).Once()
+	// the nonce 1 is used by the pending tx
+	testData.ethermanMock.EXPECT().SendTxIdempotent(testData.ctx, mock.MatchedBy(func(tx *ethtypes.Transaction) bool {
+		return tx.Nonce() == 2
+	})).Return(nil).Once()
+
+	require.NoError(t, testData.sut.ForceResend(testData.ctx, created.ID, forcedGasPrice))
+
+	sent, err := testData.sut.storage.Get(testData.ctx, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), sent.Nonce)
+	require.Equal(t, types.MonitoredTxStatusSent, sent.Status)
+}
+
+func TestWaitForAll(t *testing.T) {
+	testData := newTestData(t, false)
+	to := common.HexToAddress("0x1")
+
+	ids := []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2"), common.HexToHash("0x3")}
+	for i, id := range ids {
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, types.MonitoredTx{
+			ID:     id,
+			From:   common.HexToAddress("0x456"),
+			To:     &to,
+			Nonce:  uint64(i),
+			Status: types.MonitoredTxStatusSent,
+		}))
+	}
+
+	// drive the monitored txs to finalized one step at a time
+	go func() {
+		statuses := []types.MonitoredTxStatus{
+			types.MonitoredTxStatusMined,
+			types.MonitoredTxStatusSafe,
+			types.MonitoredTxStatusFinalized,
+		}
+		for _, status := range statuses {
+			for _, id := range ids {
+				time.Sleep(20 * time.Millisecond)
+				mTx, err := testData.sut.storage.Get(testData.ctx, id)
+				if err != nil {
+					return
+				}
+				mTx.Status = status
+				mTx.BlockNumber = big.NewInt(10)
+				if err := testData.sut.storage.Update(testData.ctx, mTx); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	isFinalized := func(r types.MonitoredTxResult) bool {
+		return r.Status == types.MonitoredTxStatusFinalized
+	}
+	ctx, cancel := context.WithTimeout(testData.ctx, 5*time.Second)
+	defer cancel()
+
+	results, err := testData.sut.WaitForAllWithOptions(ctx, ids, isFinalized,
+		WaitOptions{PollInterval: 10 * time.Millisecond})
+	require.NoError(t, err)
+	require.Len(t, results, len(ids))
+	for _, id := range ids {
+		require.Equal(t, types.MonitoredTxStatusFinalized, results[id].Status)
+	}
+
+	t.Run("removed monitored tx", func(t *testing.T) {
+		removedID := common.HexToHash("0x4")
+		waitIDs := append([]common.Hash{removedID}, ids...)
+		opts := WaitOptions{PollInterval: 10 * time.Millisecond}
+
+		results, err := testData.sut.WaitForAllWithOptions(ctx, waitIDs, isFinalized, opts)
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Len(t, results, len(ids))
+
+		opts.SkipNotFound = true
+		results, err = testData.sut.WaitForAllWithOptions(ctx, waitIDs, isFinalized, opts)
+		require.NoError(t, err)
+		require.Len(t, results, len(ids))
+		require.NotContains(t, results, removedID)
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(testData.ctx, 50*time.Millisecond)
+		defer cancel()
+
+		results, err := testData.sut.WaitForAll(ctx, ids, func(r types.MonitoredTxResult) bool {
+			return r.Status == types.MonitoredTxStatusFailed
+		})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Empty(t, results)
+	})
+}
+
+func TestRetry(t *testing.T) {
+	to := common.HexToAddress("0x1")
+	sentTx := ethtypes.NewTransaction(1, to, big.NewInt(1), 21000, big.NewInt(10), nil)
+
+	tests := []struct {
+		name          string
+		status        types.MonitoredTxStatus
+		estimateGas   bool
+		fixedNonce    bool
+		processing    bool
+		currentNonce  uint64
+		historyMined  bool
+		expectedError error
+	}{
+		{name: "failed", status: types.MonitoredTxStatusFailed, estimateGas: true},
+		{
+			name: "failed with a fixed nonce", status: types.MonitoredTxStatusFailed, fixedNonce: true,
+			expectedError: ErrInvalidStatus,
+		},
+		{
+			name: "processing", status: types.MonitoredTxStatusFailed, processing: true,
+			expectedError: ErrMonitoredTxProcessing,
+		},
+		{name: "evicted", status: types.MonitoredTxStatusEvicted, currentNonce: 2},
+		{
+			name: "evicted with its nonce not consumed", status: types.MonitoredTxStatusEvicted, currentNonce: 1,
+			expectedError: ErrNonceNotConsumed,
+		},
+		{
+			name: "evicted with a tx of its history mined", status: types.MonitoredTxStatusEvicted, currentNonce: 2,
+			historyMined: true, expectedError: ErrInvalidStatus,
+		},
+		{name: "mined", status: types.MonitoredTxStatusMined, expectedError: ErrInvalidStatus},
+		{name: "safe", status: types.MonitoredTxStatusSafe, expectedError: ErrInvalidStatus},
+		{name: "finalized", status: types.MonitoredTxStatusFinalized, expectedError: ErrInvalidStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testData := newTestData(t, false)
+			mTx := types.MonitoredTx{
+				ID: common.HexToHash("0x1"), From: common.HexToAddress("0x2"), To: &to, Nonce: 1,
+				Value: big.NewInt(1), Data: []byte("data"), Gas: 21000, GasPrice: big.NewInt(10),
+				Status: tt.status, BlockNumber: big.NewInt(10), RetryCount: 3, EstimateGas: tt.estimateGas,
+				FixedNonce: tt.fixedNonce, History: map[common.Hash]bool{sentTx.Hash(): true},
+			}
+			require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+			if tt.processing {
+				testData.sut.processingTxs.Store(mTx.ID, struct{}{})
+			}
+			if tt.status == types.MonitoredTxStatusEvicted {
+				testData.ethermanMock.EXPECT().CurrentNonce(testData.ctx, mTx.From).Return(tt.currentNonce, nil).Once()
+				if tt.currentNonce > mTx.Nonce {
+					testData.ethermanMock.EXPECT().CheckTxWasMined(testData.ctx, sentTx.Hash()).
+						Return(tt.historyMined, nil, nil).Once()
+				}
+			}
+
+			err := testData.sut.Retry(testData.ctx, mTx.ID)
+			stored, getErr := testData.sut.storage.Get(testData.ctx, mTx.ID)
+			require.NoError(t, getErr)
+
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				require.Equal(t, tt.status, stored.Status)
+				require.Len(t, stored.History, 1)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, types.MonitoredTxStatusCreated, stored.Status)
+			require.Empty(t, stored.History)
+			require.Zero(t, stored.RetryCount)
+			require.Nil(t, stored.BlockNumber)
+			// the gas estimation is left to the next monitoring cycle
+			if tt.estimateGas {
+				require.Zero(t, stored.Gas)
+			} else {
+				require.Equal(t, mTx.Gas, stored.Gas)
+			}
+		})
+	}
+}
+
+type fakeRelayBroadcaster struct {
+	txs []*ethtypes.Transaction
+}
+
+func (f *fakeRelayBroadcaster) Broadcast(_ context.Context, tx *ethtypes.Transaction) error {
+	f.txs = append(f.txs, tx)
+	return nil
+}
+
+func TestMonitorTxPrivateRelay(t *testing.T) {
+	testData := newTestData(t, false)
+	to := common.HexToAddress("0x1")
+	data := []byte("data")
+
+	// txs can't be flagged to use the private relay until a relay is provided
+	_, err := testData.sut.AddWithPrivateRelay(testData.ctx, &to, big.NewInt(1), data, 0, nil)
+	require.ErrorIs(t, err, ErrNoRelayBroadcaster)
+
+	relay := &fakeRelayBroadcaster{}
+	testData.sut.SetRelayBroadcaster(relay)
+
+	testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(1), nil).Once()
+	testData.ethermanMock.EXPECT().EstimateGas(testData.ctx, mock.Anything, &to, big.NewInt(1), data).Return(uint64(21000), nil).Once()
+	id, err := testData.sut.AddWithPrivateRelay(testData.ctx, &to, big.NewInt(1), data, 0, nil)
+	require.NoError(t, err)
+
+	mTx, err := testData.sut.storage.Get(testData.ctx, id)
+	require.NoError(t, err)
+	require.True(t, mTx.PrivateRelay)
+
+	// the tx is delivered through the relay and not sent to the public mempool
+	testData.ethermanMock.EXPECT().SignTx(testData.ctx, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ common.Address, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+			return tx, nil
+		}).Once()
+	testData.ethermanMock.EXPECT().GetTx(testData.ctx, mock.Anything).Return(nil, false, ethereum.NotFound).Once()
+	testData.ethermanMock.EXPECT().WaitTxToBeMined(testData.ctx, mock.Anything, mock.Anything).Return(false, nil).Once()
+
+	iteration := &monitoredTxnIteration{MonitoredTx: &mTx}
+	testData.sut.monitorTx(testData.ctx, iteration, createMonitoredTxLogger(mTx))
+
+	require.Len(t, relay.txs, 1)
+	require.Equal(t, types.MonitoredTxStatusSent, iteration.Status)
+}
+
+func TestInitialBroadcastGrace(t *testing.T) {
+	to := common.HexToAddress("0x1")
+	signedTx := ethtypes.NewTx(&ethtypes.LegacyTx{Nonce: 1, To: &to, Value: big.NewInt(1), Gas: 21000, GasPrice: big.NewInt(1)})
+	newIteration := func() *monitoredTxnIteration {
+		return &monitoredTxnIteration{MonitoredTx: &types.MonitoredTx{
+			ID: common.HexToHash("0x123"), From: common.HexToAddress("0x456"), To: &to, Nonce: 1,
+			Value: big.NewInt(1), Gas: 21000, GasPrice: big.NewInt(1), FixedFees: true,
+			Status: types.MonitoredTxStatusSent, History: map[common.Hash]bool{signedTx.Hash(): true},
+		}}
+	}
+
+	testCases := []struct {
+		name           string
+		lastBroadcast  *lastBroadcast
+		expectedResent bool
+	}{
+		{
+			name:           "within the grace",
+			lastBroadcast:  &lastBroadcast{txHash: signedTx.Hash(), at: time.Now().Add(-10 * time.Second)},
+			expectedResent: false,
+		},
+		{
+			name:           "past the grace",
+			lastBroadcast:  &lastBroadcast{txHash: signedTx.Hash(), at: time.Now().Add(-2 * time.Minute)},
+			expectedResent: true,
+		},
+		{
+			name:           "other tx broadcast within the grace",
+			lastBroadcast:  &lastBroadcast{txHash: common.HexToHash("0xabc"), at: time.Now().Add(-10 * time.Second)},
+			expectedResent: true,
+		},
+		{
+			name:           "unknown last broadcast",
+			expectedResent: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testData := newTestData(t, true)
+			testData.sut.cfg.InitialBroadcastGrace = configTypes.NewDuration(time.Minute)
+			mTx := newIteration()
+			if tc.lastBroadcast != nil {
+				testData.sut.lastBroadcasts.Store(mTx.ID, *tc.lastBroadcast)
+			}
+
+			testData.ethermanMock.EXPECT().SignTx(testData.ctx, mTx.From, mock.Anything).Return(signedTx, nil).Once()
+			testData.ethermanMock.EXPECT().GetTx(testData.ctx, signedTx.Hash()).Return(nil, false, ethereum.NotFound).Once()
+			if tc.expectedResent {
+				testData.ethermanMock.EXPECT().SendTxIdempotent(testData.ctx, signedTx).Return(nil).Once()
+				testData.ethermanMock.EXPECT().WaitTxToBeMined(testData.ctx, signedTx, mock.Anything).Return(false, nil).Once()
+			}
+
+			testData.sut.monitorTx(testData.ctx, mTx, createMonitoredTxLogger(*mTx.MonitoredTx))
+
+			// a tx broadcast again starts a new grace
+			require.True(t, testData.sut.inInitialBroadcastGrace(mTx.ID, signedTx.Hash()))
+		})
+	}
+}
+
+func TestMonitorTxsCircuitBreaker(t *testing.T) {
+	testData := newTestData(t, false)
+	testData.sut.cfg.CircuitBreakerFailedCycles = 2
+	testData.sut.cfg.CircuitBreakerCooldown = configTypes.NewDuration(time.Minute)
+	now := time.Now()
+	testData.sut.circuitBreaker.now = func() time.Time { return now }
+
+	to := common.HexToAddress("0x1")
+	mTx := types.MonitoredTx{
+		ID: common.HexToHash("0x123"), From: common.HexToAddress("0x456"), To: &to, Nonce: 1, FixedNonce: true,
+		Value: big.NewInt(1), Gas: 21000, GasPrice: big.NewInt(1), Status: types.MonitoredTxStatusCreated,
+		History: make(map[common.Hash]bool),
+	}
+	require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+
+	signedTx := ethtypes.NewTx(&ethtypes.LegacyTx{Nonce: 1, To: &to, Value: big.NewInt(1), Gas: 21000})
+	testData.ethermanMock.EXPECT().SignTx(mock.Anything, mTx.From, mock.Anything).Return(signedTx, nil)
+	testData.ethermanMock.EXPECT().GetTx(mock.Anything, signedTx.Hash()).Return(nil, false, ethereum.NotFound)
+	// the first two cycles fail, open the breaker and the probe fails too
+	testData.ethermanMock.EXPECT().SendTxIdempotent(mock.Anything, signedTx).Return(errors.New("key banned")).Times(3)
+
+	cycle := func() {
+		t.Helper()
+		require.NoError(t, testData.sut.monitorTxs(testData.ctx))
+	}
+
+	cycle()
+	require.False(t, testData.sut.circuitBreaker.isOpen())
+	cycle()
+	require.True(t, testData.sut.circuitBreaker.isOpen())
+
+	// the broadcasts outside the monitoring cycles are not counted
+	require.Error(t, testData.sut.broadcast(testData.ctx, &monitoredTxnIteration{MonitoredTx: &mTx}, signedTx))
+	testData.ethermanMock.AssertNumberOfCalls(t, "SendTxIdempotent", 3)
+	testData.ethermanMock.EXPECT().SendTxIdempotent(mock.Anything, signedTx).Return(errors.New("key banned")).Once()
+
+	// a tx sent before the breaker opened is mined during the cooldown
+	minedTx := ethtypes.NewTransaction(2, to, big.NewInt(1), 21000, big.NewInt(1), nil)
+	mined := mTx
+	mined.ID = common.HexToHash("0x124")
+	mined.Nonce = 2
+	mined.Status = types.MonitoredTxStatusSent
+	mined.History = map[common.Hash]bool{minedTx.Hash(): true}
+	require.NoError(t, testData.sut.storage.Add(testData.ctx, mined))
+	receipt := &ethtypes.Receipt{
+		Status: ethtypes.ReceiptStatusSuccessful, TxHash: minedTx.Hash(), BlockNumber: big.NewInt(10),
+	}
+	testData.ethermanMock.EXPECT().CheckTxWasMined(mock.Anything, minedTx.Hash()).Return(true, receipt, nil).Once()
+
+	// the txs are not sent during the cooldown, but the mined ones are still processed
+	now = now.Add(30 * time.Second)
+	cycle()
+	testData.ethermanMock.AssertNumberOfCalls(t, "SendTxIdempotent", 3)
+	require.True(t, testData.sut.circuitBreaker.isOpen())
+	stored, err := testData.sut.storage.Get(testData.ctx, mined.ID)
+	require.NoError(t, err)
+	require.Equal(t, types.MonitoredTxStatusMined, stored.Status)
+
+	// the failed probe opens the breaker again
+	now = now.Add(time.Minute)
+	cycle()
+	testData.ethermanMock.AssertNumberOfCalls(t, "SendTxIdempotent", 4)
+	require.True(t, testData.sut.circuitBreaker.isOpen())
+	cycle()
+	testData.ethermanMock.AssertNumberOfCalls(t, "SendTxIdempotent", 4)
+
+	// the successful probe closes it
+	now = now.Add(time.Minute)
+	testData.ethermanMock.EXPECT().SendTxIdempotent(mock.Anything, signedTx).Return(nil).Once()
+	testData.ethermanMock.EXPECT().WaitTxToBeMined(mock.Anything, signedTx, mock.Anything).Return(false, nil).Once()
+	cycle()
+	require.False(t, testData.sut.circuitBreaker.isOpen())
+
+	stored, err = testData.sut.storage.Get(testData.ctx, mTx.ID)
+	require.NoError(t, err)
+	require.Equal(t, types.MonitoredTxStatusSent, stored.Status)
+}
+
+func TestMonitorTxsCycleTimeout(t *testing.T) {
+	testData := newTestData(t, true)
+	testData.sut.cfg.MonitorTxsCycleTimeout = configTypes.NewDuration(100 * time.Millisecond)
+	to := common.HexToAddress("0x1")
+
+	mTx := types.MonitoredTx{
+		ID: common.HexToHash("0x123"), From: common.HexToAddress("0x456"), To: &to,
+		Status: types.MonitoredTxStatusCreated, Value: big.NewInt(1), Gas: 21000, GasPrice: big.NewInt(1),
+		History: make(map[common.Hash]bool),
+	}
+
+	testData.storageMock.EXPECT().GetByStatus(mock.Anything, mock.Anything).Return([]types.MonitoredTx{mTx}, nil).Twice()
+	testData.storageMock.EXPECT().Update(mock.Anything, mock.Anything).Return(nil)
+	testData.storageMock.EXPECT().WithTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, fn func(types.StorageInterface) error) error {
+			return fn(testData.storageMock)
+		})
+	// the nonce is only assigned by the first cycle, the next one skips the tx being processed
+	testData.ethermanMock.EXPECT().PendingNonce(mock.Anything, mTx.From).Return(uint64(1), nil).Once()
+	testData.ethermanMock.EXPECT().SignTx(mock.Anything, mTx.From, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ common.Address, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+			return tx, nil
+		}).Once()
+	testData.ethermanMock.EXPECT().GetTx(mock.Anything, mock.Anything).Return(nil, false, ethereum.NotFound).Once()
+	testData.ethermanMock.EXPECT().SendTxIdempotent(mock.Anything, mock.Anything).Return(nil).Once()
+
+	// waiting the tx to be mined takes longer than the cycle and ignores the cancellation
+	release := make(chan struct{})
+	defer close(release)
+	processingCtx := make(chan context.Context, 1)
+	testData.ethermanMock.EXPECT().WaitTxToBeMined(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, _ *ethtypes.Transaction, _ time.Duration) (bool, error) {
+			processingCtx <- ctx
+			<-release
+			return false, nil
+		}).Once()
+
+	start := time.Now()
+	require.NoError(t, testData.sut.monitorTxs(testData.ctx))
+	require.Less(t, time.Since(start), time.Second)
+
+	// the tx keeps being processed after the cycle deadline
+	require.NoError(t, (<-processingCtx).Err())
+
+	// the next cycle skips the tx still being processed
+	require.NoError(t, testData.sut.monitorTxs(testData.ctx))
+}
+
+func TestReviewMonitoredTxGasTipCapBounds(t *testing.T) {
+	to := common.HexToAddress("0x1")
+	tests := []struct {
+		name              string
+		minGasTipCap      uint64
+		maxGasTipCap      uint64
+		currentGasTipCap  int64
+		suggestedTipCap   int64
+		expectedGasTipCap int64
+	}{
+		{
+			name:              "raised to the minimum",
+			minGasTipCap:      50,
+			currentGasTipCap:  10,
+			suggestedTipCap:   20,
+			expectedGasTipCap: 50,
+		},
+		{
+			name:              "limited to the maximum",
+			maxGasTipCap:      30,
+			currentGasTipCap:  10,
+			suggestedTipCap:   100,
+			expectedGasTipCap: 30,
+		},
+		{
+			name:              "not decreased when already over the maximum",
+			maxGasTipCap:      30,
+			currentGasTipCap:  40,
+			suggestedTipCap:   100,
+			expectedGasTipCap: 40,
+		},
+		{
+			name:              "within bounds",
+			minGasTipCap:      5,
+			maxGasTipCap:      30,
+			currentGasTipCap:  10,
+			suggestedTipCap:   20,
+			expectedGasTipCap: 20,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testData := newTestData(t, true)
+			testData.sut.cfg.GasPriceMarginFactor = 1
+			testData.sut.cfg.MinGasTipCap = tt.minGasTipCap
+			testData.sut.cfg.MaxGasTipCap = tt.maxGasTipCap
+
+			mTx := &monitoredTxnIteration{
+				MonitoredTx: &types.MonitoredTx{
+					ID:           common.HexToHash("0x123"),
+					From:         common.HexToAddress("0x456"),
+					To:           &to,
+					Status:       types.MonitoredTxStatusSent,
+					Value:        big.NewInt(1),
+					Gas:          21000,
+					GasPrice:     big.NewInt(100),
+					GasTipCap:    big.NewInt(tt.currentGasTipCap),
+					BlobSidecar:  &ethtypes.BlobTxSidecar{},
+					BlobGasPrice: big.NewInt(params.BlobTxMinBlobGasprice),
+					EstimateGas:  true,
+					History:      make(map[common.Hash]bool),
+				},
+			}
+
+			header := &ethtypes.Header{Number: big.NewInt(10)}
+			testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(100), nil).Once()
+			testData.ethermanMock.EXPECT().GetHeaderByNumber(testData.ctx, mock.Anything).Return(header, nil).Twice()
+			testData.ethermanMock.EXPECT().GetSuggestGasTipCap(testData.ctx).Return(big.NewInt(tt.suggestedTipCap), nil).Once()
+			testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(nil).Once()
+
+			logger := createMonitoredTxLogger(*mTx.MonitoredTx)
+			require.NoError(t, testData.sut.reviewMonitoredTxGas(testData.ctx, mTx, logger))
+			require.Equal(t, big.NewInt(tt.expectedGasTipCap), mTx.GasTipCap)
+		})
+	}
+}
+
+func TestAddBlobTxLatestHeader(t *testing.T) {
+	to := common.HexToAddress("0x1")
+	excessBlobGas := uint64(20_000_000)
+	header := &ethtypes.Header{Number: big.NewInt(10), ExcessBlobGas: &excessBlobGas}
+	errTransient := errors.New("connection reset by peer")
+
+	newBlobTestData := func(t *testing.T, maxRetries uint64) *testEthTxManagerData {
+		t.Helper()
+		testData := newTestData(t, true)
+		testData.sut.cfg.GasPriceMarginFactor = 1
+		testData.sut.cfg.GetHeaderMaxRetries = maxRetries
+		testData.sut.cfg.GetHeaderRetryBackoff = configTypes.NewDuration(time.Millisecond)
+		testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(100), nil).Once()
+		return testData
+	}
+
+	t.Run("retry then success with a single header fetch", func(t *testing.T) {
+		testData := newBlobTestData(t, 2)
+		testData.ethermanMock.EXPECT().GetHeaderByNumber(testData.ctx, mock.Anything).Return(nil, errTransient).Once()
+		testData.ethermanMock.EXPECT().GetHeaderByNumber(testData.ctx, mock.Anything).Return(header, nil).Once()
+		testData.ethermanMock.EXPECT().GetSuggestGasTipCap(testData.ctx).Return(big.NewInt(1), nil).Once()
+
+		expectedBlobFeeCap := new(big.Int).Mul(eip4844.CalcBlobFee(testData.sut.blobChainConfig(), header), big.NewInt(10))
+		testData.storageMock.EXPECT().Add(testData.ctx, mock.MatchedBy(func(mTx types.MonitoredTx) bool {
+			return mTx.BlobGasPrice.Cmp(expectedBlobFeeCap) == 0
+		})).Return(nil).Once()
+
+		_, err := testData.sut.AddWithGas(testData.ctx, &to, big.NewInt(0), nil, 0, &ethtypes.BlobTxSidecar{}, 21000)
+		require.NoError(t, err)
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		testData := newBlobTestData(t, 1)
+		testData.ethermanMock.EXPECT().GetHeaderByNumber(testData.ctx, mock.Anything).Return(nil, errTransient).Twice()
+
+		_, err := testData.sut.AddWithGas(testData.ctx, &to, big.NewInt(0), nil, 0, &ethtypes.BlobTxSidecar{}, 21000)
+		require.ErrorIs(t, err, errTransient)
+	})
+}
+
+func TestReviewMonitoredTxBlobSchedule(t *testing.T) {
+	to := common.HexToAddress("0x1")
+	parentExcessBlobGas := uint64(20_000_000)
+	parentBlobGasUsed := uint64(0)
+	parentHeader := &ethtypes.Header{
+		Number:        big.NewInt(9),
+		ExcessBlobGas: &parentExcessBlobGas,
+		BlobGasUsed:   &parentBlobGasUsed,
+	}
+
+	errTransient := errors.New("transient error")
+	reviewBlobGasPrice := func(t *testing.T, blobSchedule etherman.BlobScheduleConfig, transientFailures bool) *big.Int {
+		t.Helper()
+
+		testData := newTestData(t, true)
+		testData.sut.cfg.GasPriceMarginFactor = 1
+		testData.sut.cfg.Etherman.BlobSchedule = blobSchedule
+		testData.sut.cfg.GetHeaderMaxRetries = 1
+
+		chainConfig := blobSchedule.ChainConfig()
+		excessBlobGas := eip4844.CalcExcessBlobGas(chainConfig, parentHeader, 0)
+		header := &ethtypes.Header{Number: big.NewInt(10), ExcessBlobGas: &excessBlobGas}
+
+		mTx := &monitoredTxnIteration{
+			MonitoredTx: &types.MonitoredTx{
+				ID:           common.HexToHash("0x123"),
+				From:         common.HexToAddress("0x456"),
+				To:           &to,
+				Status:       types.MonitoredTxStatusSent,
+				Value:        big.NewInt(1),
+				Gas:          21000,
+				GasPrice:     big.NewInt(100),
+				GasTipCap:    big.NewInt(1),
+				BlobSidecar:  &ethtypes.BlobTxSidecar{},
+				BlobGasPrice: big.NewInt(params.BlobTxMinBlobGasprice),
+				FixedFees:    true,
+				EstimateGas:  true,
+				History:      make(map[common.Hash]bool),
+			},
+		}
+
+		// the fees are fixed, so only the blob fee cap is reviewed from the headers, each one retried once
+		// when transientFailures is set
+		if transientFailures {
+			testData.ethermanMock.EXPECT().GetHeaderByNumber(testData.ctx, (*big.Int)(nil)).Return(nil, errTransient).Once()
+		}
+		testData.ethermanMock.EXPECT().GetHeaderByNumber(testData.ctx, (*big.Int)(nil)).Return(header, nil).Once()
+		if transientFailures {
+			testData.ethermanMock.EXPECT().GetHeaderByNumber(testData.ctx, big.NewInt(9)).Return(nil, errTransient).Once()
+		}
+		testData.ethermanMock.EXPECT().GetHeaderByNumber(testData.ctx, big.NewInt(9)).Return(parentHeader, nil).Once()
+		testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(nil).Once()
+
+		logger := createMonitoredTxLogger(*mTx.MonitoredTx)
+		require.NoError(t, testData.sut.reviewMonitoredTxGas(testData.ctx, mTx, logger))
+		return mTx.BlobGasPrice
+	}
+
+	defaultBlobGasPrice := reviewBlobGasPrice(t, etherman.BlobScheduleConfig{}, false)
+	require.Equal(t, eip4844.CalcBlobFee(etherman.BlobScheduleConfig{}.ChainConfig(), parentHeader), defaultBlobGasPrice)
+
+	customBlobGasPrice := reviewBlobGasPrice(t, etherman.BlobScheduleConfig{UpdateFraction: 1_000_000}, false)
+	require.Equal(t, 1, customBlobGasPrice.Cmp(defaultBlobGasPrice))
+
+	// the transient failures getting the headers are retried
+	require.Equal(t, defaultBlobGasPrice, reviewBlobGasPrice(t, etherman.BlobScheduleConfig{}, true))
+}
+
+func TestReviewMonitoredTxUpgradeLegacyTx(t *testing.T) {
+	to := common.HexToAddress("0x1")
+	newLegacyTx := func() *monitoredTxnIteration {
+		legacyTx := ethtypes.NewTransaction(1, to, big.NewInt(1), 21000, big.NewInt(100), nil)
+		return &monitoredTxnIteration{
+			MonitoredTx: &types.MonitoredTx{
+				ID:       common.HexToHash("0x123"),
+				From:     common.HexToAddress("0x456"),
+				To:       &to,
+				Nonce:    1,
+				Status:   types.MonitoredTxStatusSent,
+				Value:    big.NewInt(1),
+				Gas:      21000,
+				GasPrice: big.NewInt(100),
+				History:  map[common.Hash]bool{legacyTx.Hash(): true},
+			},
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.sut.cfg.GasPriceMarginFactor = 1
+		mTx := newLegacyTx()
+
+		testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(100), nil).Once()
+
+		logger := createMonitoredTxLogger(*mTx.MonitoredTx)
+		require.NoError(t, testData.sut.reviewMonitoredTxGas(testData.ctx, mTx, logger))
+		require.Nil(t, mTx.GasTipCap)
+		require.Equal(t, ethtypes.LegacyTxType, int(mTx.Tx().Type()))
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.sut.cfg.GasPriceMarginFactor = 1
+		testData.sut.cfg.UpgradeLegacyTxs = true
+		mTx := newLegacyTx()
+
+		header := &ethtypes.Header{Number: big.NewInt(10), BaseFee: big.NewInt(80)}
+		testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(100), nil).Once()
+		testData.ethermanMock.EXPECT().GetHeaderByNumber(testData.ctx, mock.Anything).Return(header, nil).Once()
+		testData.ethermanMock.EXPECT().GetSuggestGasTipCap(testData.ctx).Return(big.NewInt(5), nil).Once()
+
+		logger := createMonitoredTxLogger(*mTx.MonitoredTx)
+		require.NoError(t, testData.sut.reviewMonitoredTxGas(testData.ctx, mTx, logger))
+		// the tip and the fee cap are both bumped over the gas price of the legacy tx to replace it
+		require.Equal(t, big.NewInt(110), mTx.GasTipCap)
+		require.Equal(t, big.NewInt(270), mTx.GasPrice)
+		require.Equal(t, ethtypes.DynamicFeeTxType, int(mTx.Tx().Type()))
+		require.True(t, mTx.TxTypeConverted)
+		// the legacy tx sent before the upgrade is kept in the history
+		require.Len(t, mTx.History, 1)
+	})
+
+	t.Run("fallen back tx is not upgraded", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.sut.cfg.GasPriceMarginFactor = 1
+		testData.sut.cfg.UpgradeLegacyTxs = true
+		mTx := newLegacyTx()
+		mTx.TxTypeConverted = true
+
+		testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(100), nil).Once()
+
+		logger := createMonitoredTxLogger(*mTx.MonitoredTx)
+		require.NoError(t, testData.sut.reviewMonitoredTxGas(testData.ctx, mTx, logger))
+		require.Nil(t, mTx.GasTipCap)
+		require.Equal(t, ethtypes.LegacyTxType, int(mTx.Tx().Type()))
+	})
+}
+
+func TestReviewMonitoredTxBumpOwnDynamicFees(t *testing.T) {
+	to := common.HexToAddress("0x1")
+	tests := []struct {
+		name              string
+		gasFeeCap         int64
+		baseFee           int64
+		maxGasTipCap      uint64
+		maxGasPriceLimit  uint64
+		gasPriceSanityMax uint64
+		expectedGasFeeCap int64
+		expectedGasTipCap int64
+	}{
+		{
+			name:              "both bumped - the fee cap covers the base fee plus the new tip",
+			gasFeeCap:         1000,
+			baseFee:           100,
+			expectedGasFeeCap: 1100,
+			expectedGasTipCap: 11,
+		},
+		{
+			name:              "both bumped - the fee cap is raised to cover the base fee plus the new tip",
+			gasFeeCap:         100,
+			baseFee:           195,
+			expectedGasFeeCap: 206,
+			expectedGasTipCap: 11,
+		},
+		{
+			name:              "limited by the max gas tip cap and the max gas price",
+			gasFeeCap:         1000,
+			baseFee:           100,
+			maxGasTipCap:      10,
+			maxGasPriceLimit:  1050,
+			expectedGasFeeCap: 1050,
+			expectedGasTipCap: 10,
+		},
+		{
+			name:              "limited by the gas price sanity max",
+			gasFeeCap:         1000,
+			baseFee:           100,
+			gasPriceSanityMax: 1020,
+			expectedGasFeeCap: 1020,
+			expectedGasTipCap: 11,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testData := newTestData(t, true)
+			testData.sut.cfg.GasPriceMarginFactor = 1
+			testData.sut.cfg.BumpOwnDynamicFees = true
+			testData.sut.cfg.MaxGasTipCap = tt.maxGasTipCap
+			testData.sut.cfg.MaxGasPriceLimit = tt.maxGasPriceLimit
+			testData.sut.cfg.GasPriceSanityMax = tt.gasPriceSanityMax
+
+			mTx := &monitoredTxnIteration{
+				MonitoredTx: &types.MonitoredTx{
+					ID:        common.HexToHash("0x123"),
+					From:      common.HexToAddress("0x456"),
+					To:        &to,
+					Status:    types.MonitoredTxStatusSent,
+					Value:     big.NewInt(1),
+					Gas:       21000,
+					GasPrice:  big.NewInt(tt.gasFeeCap),
+					GasTipCap: big.NewInt(10),
+					History:   make(map[common.Hash]bool),
+				},
+			}
+
+			header := &ethtypes.Header{Number: big.NewInt(10), BaseFee: big.NewInt(tt.baseFee)}
+			testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(50), nil).Once()
+			testData.ethermanMock.EXPECT().GetHeaderByNumber(testData.ctx, mock.Anything).Return(header, nil).Once()
+			testData.ethermanMock.EXPECT().GetSuggestGasTipCap(testData.ctx).Return(big.NewInt(5), nil).Once()
+
+			logger := createMonitoredTxLogger(*mTx.MonitoredTx)
+			require.NoError(t, testData.sut.reviewMonitoredTxGas(testData.ctx, mTx, logger))
+			require.Equal(t, big.NewInt(tt.expectedGasFeeCap), mTx.GasPrice)
+			require.Equal(t, big.NewInt(tt.expectedGasTipCap), mTx.GasTipCap)
+		})
+	}
+}
+
+func TestGasPriceSanityMax(t *testing.T) {
+	to := common.HexToAddress("0x1")
+	insaneGasPrice := new(big.Int).Mul(big.NewInt(10000), big.NewInt(params.GWei))
+
+	t.Run("add rejects the suggestion", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.sut.cfg.GasPriceMarginFactor = 1
+		testData.sut.cfg.MaxGasPriceLimit = insaneGasPrice.Uint64()
+		testData.sut.cfg.GasPriceSanityMax = 500 * params.GWei
+
+		testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(insaneGasPrice, nil).Once()
+
+		_, err := testData.sut.Add(testData.ctx, &to, big.NewInt(1), []byte{}, 0, nil)
+		require.ErrorIs(t, err, ErrGasPriceAboveSanityMax)
+	})
+
+	t.Run("review rejects the suggestion", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.sut.cfg.GasPriceMarginFactor = 1
+		testData.sut.cfg.GasPriceSanityMax = 500 * params.GWei
+
+		mTx := &monitoredTxnIteration{
+			MonitoredTx: &types.MonitoredTx{
+				ID:       common.HexToHash("0x123"),
+				To:       &to,
+				Status:   types.MonitoredTxStatusSent,
+				Gas:      21000,
+				GasPrice: big.NewInt(params.GWei),
+				History:  make(map[common.Hash]bool),
+			},
+		}
+		testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(insaneGasPrice, nil).Once()
+
+		err := testData.sut.reviewMonitoredTxGas(testData.ctx, mTx, createMonitoredTxLogger(*mTx.MonitoredTx))
+		require.ErrorIs(t, err, ErrGasPriceAboveSanityMax)
+		require.Equal(t, big.NewInt(params.GWei), mTx.GasPrice)
+	})
+
+	t.Run("suggestion within the sanity max", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.sut.cfg.GasPriceMarginFactor = 1
+		testData.sut.cfg.GasPriceSanityMax = 500 * params.GWei
+
+		testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(params.GWei), nil).Once()
+
+		gasPrice, err := testData.sut.suggestedGasPrice(testData.ctx)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(params.GWei), gasPrice)
+	})
+}
+
+func TestSenderSelection(t *testing.T) {
+	to := common.HexToAddress("0x1")
+	senders := []common.Address{
+		common.HexToAddress("0xa"),
+		common.HexToAddress("0xb"),
+		common.HexToAddress("0xc"),
+	}
+
+	addTx := func(t *testing.T, testData *testEthTxManagerData, i int) types.MonitoredTx {
+		t.Helper()
+		id, err := testData.sut.AddWithGas(testData.ctx, &to, big.NewInt(1), []byte{byte(i)}, 0, nil, 21000)
+		require.NoError(t, err)
+		mTx, err := testData.sut.storage.Get(testData.ctx, id)
+		require.NoError(t, err)
+		return mTx
+	}
+
+	t.Run("round-robin", func(t *testing.T) {
+		testData := newTestData(t, false)
+		testData.sut.cfg.GasPriceMarginFactor = 1
+		testData.sut.cfg.SenderSelection = SenderSelectionRoundRobin
+		testData.ethermanMock.EXPECT().PublicAddress().Return(senders, nil)
+		testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(1), nil)
+
+		perSender := make(map[common.Address]int)
+		for i := 0; i < 6; i++ {
+			mTx := addTx(t, testData, i)
+			require.Equal(t, senders[i%len(senders)], mTx.From)
+			perSender[mTx.From]++
+		}
+		for _, sender := range senders {
+			require.Equal(t, 2, perSender[sender])
+		}
+	})
+
+	t.Run("least-in-flight", func(t *testing.T) {
+		testData := newTestData(t, false)
+		testData.sut.cfg.GasPriceMarginFactor = 1
+		testData.sut.cfg.SenderSelection = SenderSelectionLeastInFlight
+		testData.ethermanMock.EXPECT().PublicAddress().Return(senders, nil)
+		testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(1), nil)
+
+		inFlight := []struct {
+			from   common.Address
+			status types.MonitoredTxStatus
+		}{
+			{senders[0], types.MonitoredTxStatusSent},
+			{senders[0], types.MonitoredTxStatusCreated},
+			{senders[1], types.MonitoredTxStatusSent},
+			// the txs not in flight are not considered
+			{senders[2], types.MonitoredTxStatusMined},
+			{senders[2], types.MonitoredTxStatusFinalized},
+		}
+		for i, tx := range inFlight {
+			require.NoError(t, testData.sut.storage.Add(testData.ctx, types.MonitoredTx{
+				ID:     common.BigToHash(big.NewInt(int64(i + 100))),
+				From:   tx.from,
+				To:     &to,
+				Nonce:  uint64(i),
+				Status: tx.status,
+			}))
+		}
+
+		// in flight: a=2, b=1, c=0
+		require.Equal(t, senders[2], addTx(t, testData, 0).From)
+		// in flight: a=2, b=1, c=1, the tie is won by the first signer
+		require.Equal(t, senders[1], addTx(t, testData, 1).From)
+		// in flight: a=2, b=2, c=1
+		require.Equal(t, senders[2], addTx(t, testData, 2).From)
+		// in flight: a=2, b=2, c=2
+		require.Equal(t, senders[0], addTx(t, testData, 3).From)
+	})
+
+	t.Run("fixed", func(t *testing.T) {
+		testData := newTestData(t, false)
+		testData.sut.from = senders[1]
+		testData.sut.cfg.GasPriceMarginFactor = 1
+		testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(1), nil)
+
+		require.Equal(t, senders[1], addTx(t, testData, 0).From)
+		require.Equal(t, senders[1], addTx(t, testData, 1).From)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.sut.cfg.SenderSelection = "random"
+		testData.ethermanMock.EXPECT().PublicAddress().Return(senders, nil)
+
+		_, err := testData.sut.selectSender(testData.ctx)
+		require.ErrorIs(t, err, ErrUnknownSenderSelection)
+	})
+}
+
+func TestClampGasTipCap(t *testing.T) {
+	sut := &Client{cfg: Config{MinGasTipCap: 10, MaxGasTipCap: 100}}
+
+	require.Equal(t, big.NewInt(10), sut.clampGasTipCap(big.NewInt(1)))
+	require.Equal(t, big.NewInt(100), sut.clampGasTipCap(big.NewInt(1000)))
+	require.Equal(t, big.NewInt(50), sut.clampGasTipCap(big.NewInt(50)))
+
+	// no bounds configured
+	sut = &Client{}
+	require.Equal(t, big.NewInt(1000), sut.clampGasTipCap(big.NewInt(1000)))
+}
+
+func TestAddWithNonce(t *testing.T) {
+	testData := newTestData(t, false)
+	testData.sut.cfg.GasPriceMarginFactor = 1
+	testData.sut.from = common.HexToAddress("0x456")
+	to := common.HexToAddress("0x1")
+
+	testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(1), nil)
+	testData.ethermanMock.EXPECT().EstimateGas(testData.ctx, testData.sut.from, &to, big.NewInt(1), []byte{}).
+		Return(uint64(21000), nil)
+
+	// the same payload can fill several nonces
+	id, err := testData.sut.AddWithNonce(testData.ctx, &to, big.NewInt(1), []byte{}, 0, nil, 7)
+	require.NoError(t, err)
+	otherID, err := testData.sut.AddWithNonce(testData.ctx, &to, big.NewInt(1), []byte{}, 0, nil, 8)
+	require.NoError(t, err)
+	require.NotEqual(t, id, otherID)
+	require.NoError(t, testData.sut.storage.Remove(testData.ctx, otherID))
+
+	mTx, err := testData.sut.storage.Get(testData.ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), mTx.Nonce)
+	require.True(t, mTx.FixedNonce)
+	require.Equal(t, testData.sut.from, mTx.From)
+
+	// the pending nonce is never requested, so the fixed nonce survives the cycles while created
+	for i := 0; i < 2; i++ {
+		iterations, err := testData.sut.getMonitoredTxnIteration(testData.ctx)
+		require.NoError(t, err)
+		require.Len(t, iterations, 1)
+		require.Equal(t, uint64(7), iterations[0].Nonce)
+	}
+
+	// once sent, all its txs mined with failed receipts don't trigger a nonce review either
+	signedTx := ethtypes.NewTx(&ethtypes.LegacyTx{Nonce: 7})
+	mTx.Status = types.MonitoredTxStatusSent
+	mTx.History = map[common.Hash]bool{signedTx.Hash(): true}
+	require.NoError(t, testData.sut.storage.Update(testData.ctx, mTx))
+	failedReceipt := &ethtypes.Receipt{Status: ethtypes.ReceiptStatusFailed, BlockNumber: big.NewInt(10)}
+	testData.ethermanMock.EXPECT().CheckTxWasMined(testData.ctx, signedTx.Hash()).Return(true, failedReceipt, nil).Once()
+
+	iterations, err := testData.sut.getMonitoredTxnIteration(testData.ctx)
+	require.NoError(t, err)
+	require.Len(t, iterations, 1)
+	require.Equal(t, uint64(7), iterations[0].Nonce)
+
+	stored, err := testData.sut.storage.Get(testData.ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), stored.Nonce)
+	require.True(t, stored.FixedNonce)
+}
+
+func TestSendOnAdd(t *testing.T) {
+	testData := newTestData(t, false)
+	testData.sut.cfg.GasPriceMarginFactor = 1
+	testData.sut.cfg.SendOnAdd = true
+	testData.sut.from = common.HexToAddress("0x456")
+	to := common.HexToAddress("0x1")
+
+	testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(1), nil)
+	// the node doesn't know the first tx yet when the second one is added
+	testData.ethermanMock.EXPECT().PendingNonce(testData.ctx, testData.sut.from).Return(uint64(5), nil).Twice()
+	testData.ethermanMock.EXPECT().SignTx(testData.ctx, testData.sut.from, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ common.Address, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+			// the nonce is reserved before signing and the lock is not held while the tx is signed and sent
+			require.True(t, testData.sut.nonceMu.TryLock())
+			testData.sut.nonceMu.Unlock()
+			created, err := testData.sut.storage.GetByStatus(testData.ctx,
+				[]types.MonitoredTxStatus{types.MonitoredTxStatusCreated})
+			require.NoError(t, err)
+			require.Len(t, created, 1)
+			require.Equal(t, tx.Nonce(), created[0].Nonce)
+			return tx, nil
+		})
+
+	sentNonces := make([]uint64, 0)
+	testData.ethermanMock.EXPECT().SendTxIdempotent(testData.ctx, mock.Anything).
+		RunAndReturn(func(_ context.Context, tx *ethtypes.Transaction) error {
+			sentNonces = append(sentNonces, tx.Nonce())
+			return nil
+		}).Twice()
+
+	for i := 0; i < 2; i++ {
+		id, err := testData.sut.AddWithGas(testData.ctx, &to, big.NewInt(1), []byte{byte(i)}, 0, nil, 21000)
+		require.NoError(t, err)
+
+		mTx, err := testData.sut.storage.Get(testData.ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, types.MonitoredTxStatusSent, mTx.Status)
+		require.Equal(t, uint64(5+i), mTx.Nonce)
+		require.Len(t, mTx.History, 1)
+	}
+	require.Equal(t, []uint64{5, 6}, sentNonces)
+
+	// a tx that can't be sent on add is kept as created for the monitoring loop
+	testData.ethermanMock.EXPECT().PendingNonce(testData.ctx, testData.sut.from).Return(uint64(5), nil).Once()
+	testData.ethermanMock.EXPECT().SendTxIdempotent(testData.ctx, mock.Anything).Return(errors.New("boom")).Once()
+	id, err := testData.sut.AddWithGas(testData.ctx, &to, big.NewInt(1), []byte{2}, 0, nil, 21000)
+	require.NoError(t, err)
+	mTx, err := testData.sut.storage.Get(testData.ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, types.MonitoredTxStatusCreated, mTx.Status)
+}
+
+func TestAddWithStateOverrides(t *testing.T) {
+	testData := newTestData(t, false)
+	testData.sut.cfg.GasPriceMarginFactor = 1
+	testData.sut.from = common.HexToAddress("0x456")
+	to := common.HexToAddress("0x1")
+	overrides := map[common.Address]gethclient.OverrideAccount{
+		to: {StateDiff: map[common.Hash]common.Hash{{0x1}: {0x2}}},
+	}
+
+	testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(1), nil).Once()
+	testData.ethermanMock.EXPECT().
+		EstimateGasWithStateOverrides(testData.ctx, testData.sut.from, &to, big.NewInt(1), []byte{0x1}, overrides).
+		Return(uint64(50000), nil).Once()
+
+	id, err := testData.sut.AddWithStateOverrides(testData.ctx, &to, big.NewInt(1), []byte{0x1}, 0, overrides)
+	require.NoError(t, err)
+
+	mTx, err := testData.sut.storage.Get(testData.ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, uint64(50000), mTx.Gas)
+	require.True(t, mTx.EstimateGas)
+	testData.ethermanMock.AssertNotCalled(t, "EstimateGas", mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything)
+}
+
+func TestGetMonitoredTxnIterationDuplicatedNonce(t *testing.T) {
+	testData := newTestData(t, false)
+	from := common.HexToAddress("0x1")
+	createdAt := time.Now().Add(-time.Minute)
+
+	// two active monitored txs of the same sender using the same nonce
+	first := types.MonitoredTx{
+		ID: common.HexToHash("0x1"), From: from, Nonce: 5, Status: types.MonitoredTxStatusSent,
+		History: make(map[common.Hash]bool), CreatedAt: createdAt,
+	}
+	duplicated := first
+	duplicated.ID = common.HexToHash("0x2")
+	duplicated.CreatedAt = createdAt.Add(time.Second)
+	// same nonce but another sender
+	otherSender := first
+	otherSender.ID = common.HexToHash("0x3")
+	otherSender.From = common.HexToAddress("0x2")
+	otherSender.CreatedAt = createdAt.Add(2 * time.Second)
+	for _, mTx := range []types.MonitoredTx{first, duplicated, otherSender} {
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+	}
+
+	iterations, err := testData.sut.getMonitoredTxnIteration(testData.ctx)
+	require.NoError(t, err)
+	require.Len(t, iterations, 2)
+	require.Equal(t, first.ID, iterations[0].ID)
+	require.Equal(t, otherSender.ID, iterations[1].ID)
+
+	activeNonces := map[common.Address]map[uint64]common.Hash{}
+	require.NoError(t, assignActiveNonce(activeNonces, first))
+	require.ErrorIs(t, assignActiveNonce(activeNonces, duplicated), ErrNonceAlreadyAssigned)
+}
+
+func TestGetMonitoredTxnIterationSkipsUsedNonces(t *testing.T) {
+	testData := newTestData(t, false)
+	from := common.HexToAddress("0x1")
+	createdAt := time.Now().Add(-time.Minute)
+
+	// the created txs are before the sent tx using the nonce of the network
+	created := types.MonitoredTx{
+		ID: common.HexToHash("0x1"), From: from, Status: types.MonitoredTxStatusCreated,
+		History: make(map[common.Hash]bool), CreatedAt: createdAt,
+	}
+	nextCreated := created
+	nextCreated.ID = common.HexToHash("0x2")
+	nextCreated.CreatedAt = createdAt.Add(time.Second)
+	sent := types.MonitoredTx{
+		ID: common.HexToHash("0x3"), From: from, Nonce: 5, Status: types.MonitoredTxStatusSent,
+		History: make(map[common.Hash]bool), CreatedAt: createdAt.Add(2 * time.Second),
+	}
+	for _, mTx := range []types.MonitoredTx{created, nextCreated, sent} {
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+	}
+	testData.ethermanMock.EXPECT().PendingNonce(testData.ctx, from).Return(uint64(5), nil).Once()
+
+	iterations, err := testData.sut.getMonitoredTxnIteration(testData.ctx)
+	require.NoError(t, err)
+	require.Len(t, iterations, 3)
+
+	// the nonce used by the sent tx is skipped instead of leaving the created txs without a nonce
+	nonces := make(map[common.Hash]uint64, len(iterations))
+	for _, iteration := range iterations {
+		nonces[iteration.ID] = iteration.Nonce
+	}
+	require.Equal(t, map[common.Hash]uint64{created.ID: 6, nextCreated.ID: 7, sent.ID: 5}, nonces)
+}
+
+func TestResultCache(t *testing.T) {
+	testData := newTestData(t, false)
+	testData.sut.cfg.ResultCacheTTL = configTypes.NewDuration(time.Minute)
+	testData.sut.cfg.ResultCacheTerminalTTL = configTypes.NewDuration(time.Hour)
+	now := time.Now()
+	testData.sut.resultCache.now = func() time.Time { return now }
+
+	to := common.HexToAddress("0x1")
+	tx := ethtypes.NewTransaction(1, to, big.NewInt(1), 21000, big.NewInt(1), nil)
+	receipt := &ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful, TxHash: tx.Hash(), BlockNumber: big.NewInt(10)}
+	mTx := types.MonitoredTx{
+		ID:        common.HexToHash("0x123"),
+		To:        &to,
+		Status:    types.MonitoredTxStatusSent,
+		History:   map[common.Hash]bool{tx.Hash(): true},
+		CreatedAt: now,
+	}
+	require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+
+	expectResultCalls := func() {
+		testData.ethermanMock.EXPECT().GetTx(testData.ctx, tx.Hash()).Return(tx, false, nil).Once()
+		testData.ethermanMock.EXPECT().GetTxReceipt(testData.ctx, tx.Hash()).Return(receipt, nil).Once()
+		testData.ethermanMock.EXPECT().GetRevertMessage(testData.ctx, tx).Return("", nil).Once()
+	}
+	assertResultCalls := func(expected int) {
+		testData.ethermanMock.AssertNumberOfCalls(t, "GetTx", expected)
+		testData.ethermanMock.AssertNumberOfCalls(t, "GetTxReceipt", expected)
+		testData.ethermanMock.AssertNumberOfCalls(t, "GetRevertMessage", expected)
+	}
+
+	expectResultCalls()
+	first, err := testData.sut.Result(testData.ctx, mTx.ID)
+	require.NoError(t, err)
+	assertResultCalls(1)
+
+	// the second call within the TTL is served from the cache
+	now = now.Add(30 * time.Second)
+	second, err := testData.sut.Result(testData.ctx, mTx.ID)
+	require.NoError(t, err)
+	require.Equal(t, first, second)
+	results, err := testData.sut.ResultsByStatus(testData.ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, []types.MonitoredTxResult{first}, results)
+	assertResultCalls(1)
+
+	// the cached result expires
+	now = now.Add(time.Minute)
+	expectResultCalls()
+	_, err = testData.sut.Result(testData.ctx, mTx.ID)
+	require.NoError(t, err)
+	assertResultCalls(2)
+
+	// a status change invalidates the cached result
+	mTx.Status = types.MonitoredTxStatusFinalized
+	mTx.BlockNumber = big.NewInt(10)
+	require.NoError(t, testData.sut.storage.Update(testData.ctx, mTx))
+	expectResultCalls()
+	result, err := testData.sut.Result(testData.ctx, mTx.ID)
+	require.NoError(t, err)
+	require.Equal(t, types.MonitoredTxStatusFinalized, result.Status)
+	assertResultCalls(3)
+
+	// the terminal results are cached longer
+	now = now.Add(30 * time.Minute)
+	_, err = testData.sut.Result(testData.ctx, mTx.ID)
+	require.NoError(t, err)
+	assertResultCalls(3)
+
+	// a block number change keeping the status (e.g. a reorg) invalidates the cached result
+	mTx.BlockNumber = big.NewInt(11)
+	require.NoError(t, testData.sut.storage.Update(testData.ctx, mTx))
+	expectResultCalls()
+	_, err = testData.sut.Result(testData.ctx, mTx.ID)
+	require.NoError(t, err)
+	assertResultCalls(4)
+}
+
+func TestResultConfirmations(t *testing.T) {
+	testData := newTestData(t, false)
+	testData.sut.cfg.IncludeConfirmations = true
+	to := common.HexToAddress("0x1")
+
+	for _, mTx := range []types.MonitoredTx{
+		{ID: common.HexToHash("0x1"), To: &to, Status: types.MonitoredTxStatusMined, BlockNumber: big.NewInt(95)},
+		{ID: common.HexToHash("0x2"), To: &to, Nonce: 1, Status: types.MonitoredTxStatusSafe, BlockNumber: big.NewInt(100)},
+		{ID: common.HexToHash("0x3"), To: &to, Nonce: 2, Status: types.MonitoredTxStatusSent},
+	} {
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+	}
+
+	// the latest block number is requested once for all the results
+	testData.ethermanMock.EXPECT().GetLatestBlockNumber(testData.ctx).Return(uint64(100), nil).Once()
+	results, err := testData.sut.ResultsByStatus(testData.ctx, nil)
+	require.NoError(t, err)
+	confirmations := make(map[common.Hash]uint64, len(results))
+	for _, result := range results {
+		confirmations[result.ID] = result.Confirmations
+	}
+	require.Equal(t, map[common.Hash]uint64{
+		common.HexToHash("0x1"): 6,
+		common.HexToHash("0x2"): 1,
+		common.HexToHash("0x3"): 0,
+	}, confirmations)
+
+	testData.ethermanMock.EXPECT().GetLatestBlockNumber(testData.ctx).Return(uint64(104), nil).Once()
+	result, err := testData.sut.Result(testData.ctx, common.HexToHash("0x1"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), result.Confirmations)
+
+	// the results not mined don't request the latest block number
+	result, err = testData.sut.Result(testData.ctx, common.HexToHash("0x3"))
+	require.NoError(t, err)
+	require.Zero(t, result.Confirmations)
+}
+
+func TestVerifyHistory(t *testing.T) {
+	testData := newTestData(t, false)
+	to := common.HexToAddress("0x1")
+
+	mTx := types.MonitoredTx{
+		ID: common.HexToHash("0x1"), From: common.HexToAddress("0x2"), To: &to, Nonce: 1,
+		Value: big.NewInt(1), Data: []byte("data"), Gas: 21000, GasPrice: big.NewInt(10),
+		Status: types.MonitoredTxStatusSent, History: make(map[common.Hash]bool), CreatedAt: time.Now(),
+	}
+	_, err := mTx.AddHistory(mTx.Tx())
+	require.NoError(t, err)
+	require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+
+	testData.ethermanMock.EXPECT().SignTx(testData.ctx, mTx.From, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ common.Address, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+			return tx, nil
+		})
+
+	require.NoError(t, testData.sut.VerifyHistory(testData.ctx, mTx.ID))
+
+	// the gas price is changed without sending the tx again
+	mTx.GasPrice = big.NewInt(20)
+	require.NoError(t, testData.sut.storage.Update(testData.ctx, mTx))
+	require.ErrorIs(t, testData.sut.VerifyHistory(testData.ctx, mTx.ID), ErrHistoryMismatch)
+
+	require.ErrorIs(t, testData.sut.VerifyHistory(testData.ctx, common.HexToHash("0x3")), ErrNotFound)
+}
+
+func TestPendingInfo(t *testing.T) {
+	testData := newTestData(t, false)
+	to := common.HexToAddress("0x1")
+
+	mTx := types.MonitoredTx{
+		ID: common.HexToHash("0x1"), From: common.HexToAddress("0x2"), To: &to, Nonce: 1,
+		Value: big.NewInt(1), Gas: 21000, GasPrice: big.NewInt(10),
+		Status: types.MonitoredTxStatusCreated, History: make(map[common.Hash]bool), CreatedAt: time.Now(),
+	}
+	require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+
+	// no tx was sent yet
+	info, err := testData.sut.PendingInfo(testData.ctx, mTx.ID)
+	require.NoError(t, err)
+	require.Equal(t, types.PendingInfo{ID: mTx.ID}, info)
+
+	_, err = mTx.AddHistory(mTx.Tx())
+	require.NoError(t, err)
+	mTx.Status = types.MonitoredTxStatusSent
+	require.NoError(t, testData.sut.storage.Update(testData.ctx, mTx))
+	txHash := mTx.Tx().Hash()
+
+	testData.ethermanMock.EXPECT().SignTx(testData.ctx, mTx.From, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ common.Address, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+			return tx, nil
+		})
+
+	testCases := []struct {
+		name      string
+		isPending bool
+		err       error
+		expected  types.PendingInfo
+	}{
+		{"pending", true, nil, types.PendingInfo{ID: mTx.ID, TxHash: txHash, Found: true, IsPending: true}},
+		{"mined", false, nil, types.PendingInfo{ID: mTx.ID, TxHash: txHash, Found: true}},
+		{"dropped", false, ethereum.NotFound, types.PendingInfo{ID: mTx.ID, TxHash: txHash}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testData.ethermanMock.EXPECT().GetTx(testData.ctx, txHash).Return(nil, tc.isPending, tc.err).Once()
+
+			info, err := testData.sut.PendingInfo(testData.ctx, mTx.ID)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, info)
+		})
+	}
+
+	_, err = testData.sut.PendingInfo(testData.ctx, common.HexToHash("0x3"))
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestFailureReason(t *testing.T) {
+	testData := newTestData(t, false)
+	to := common.HexToAddress("0x1")
+	droppedTxHash := common.HexToHash("0xa1")
+	failedTxHash := common.HexToHash("0xa2")
+	failedTx := ethtypes.NewTx(&ethtypes.LegacyTx{Nonce: 1, To: &to})
+
+	mTx := types.MonitoredTx{
+		ID: common.HexToHash("0x1"), From: common.HexToAddress("0x2"), To: &to, Nonce: 1,
+		Status: types.MonitoredTxStatusSent, History: map[common.Hash]bool{droppedTxHash: true, failedTxHash: true},
+		CreatedAt: time.Now(),
+	}
+	require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+
+	// only failed monitored txs have a failure reason
+	_, err := testData.sut.FailureReason(testData.ctx, mTx.ID)
+	require.ErrorIs(t, err, ErrInvalidStatus)
+
+	mTx.Status = types.MonitoredTxStatusFailed
+	require.NoError(t, testData.sut.storage.Update(testData.ctx, mTx))
+
+	testData.ethermanMock.EXPECT().GetTxReceipt(testData.ctx, droppedTxHash).Return(nil, ethereum.NotFound).Once()
+	testData.ethermanMock.EXPECT().GetTxReceipt(testData.ctx, failedTxHash).Return(&ethtypes.Receipt{
+		TxHash: failedTxHash, Status: ethtypes.ReceiptStatusFailed, BlockNumber: big.NewInt(10),
+	}, nil).Once()
+	testData.ethermanMock.EXPECT().GetTx(testData.ctx, failedTxHash).Return(failedTx, false, nil).Once()
+	testData.ethermanMock.EXPECT().GetRevertMessage(testData.ctx, failedTx).Return("contract paused", nil).Once()
+
+	reason, err := testData.sut.FailureReason(testData.ctx, mTx.ID)
+	require.NoError(t, err)
+	require.Equal(t, "contract paused", reason)
+
+	// a receipt per tx of the history, and the tx and revert message of the failed one
+	testData.ethermanMock.AssertNumberOfCalls(t, "GetTxReceipt", 2)
+	testData.ethermanMock.AssertNumberOfCalls(t, "GetTx", 1)
+	testData.ethermanMock.AssertNumberOfCalls(t, "GetRevertMessage", 1)
+	testData.ethermanMock.AssertNotCalled(t, "CheckTxWasMined", mock.Anything, mock.Anything)
+
+	_, err = testData.sut.FailureReason(testData.ctx, common.HexToHash("0x3"))
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSuggestedGasTipCap(t *testing.T) {
+	t.Run("suggested by the node by default", func(t *testing.T) {
+		testData := newTestData(t, false)
+		testData.ethermanMock.EXPECT().GetSuggestGasTipCap(testData.ctx).Return(big.NewInt(3), nil).Once()
+
+		tip, err := testData.sut.suggestedGasTipCap(testData.ctx)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(3), tip)
+	})
+
+	t.Run("fee history", func(t *testing.T) {
+		testData := newTestData(t, false)
+		testData.sut.cfg.TipCapFeeHistoryBlocks = 20
+		testData.ethermanMock.EXPECT().SuggestTipCapFromHistory(testData.ctx, uint64(20), float64(50)).
+			Return(big.NewInt(5), nil).Once()
+
+		tip, err := testData.sut.suggestedGasTipCap(testData.ctx)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(5), tip)
+
+		testData.sut.cfg.TipCapFeeHistoryPercentile = 90
+		testData.ethermanMock.EXPECT().SuggestTipCapFromHistory(testData.ctx, uint64(20), float64(90)).
+			Return(big.NewInt(8), nil).Once()
+
+		tip, err = testData.sut.suggestedGasTipCap(testData.ctx)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(8), tip)
+		testData.ethermanMock.AssertNotCalled(t, "GetSuggestGasTipCap", mock.Anything)
+	})
+}
+
+func TestDrain(t *testing.T) {
+	testData := newTestData(t, false)
+	to := common.HexToAddress("0x1")
+
+	require.NoError(t, testData.sut.storage.Add(testData.ctx, types.MonitoredTx{
+		ID: common.HexToHash("0x1"), From: common.HexToAddress("0x2"), To: &to,
+		Status: types.MonitoredTxStatusSent, History: make(map[common.Hash]bool),
+	}))
+	require.NoError(t, testData.sut.storage.Add(testData.ctx, types.MonitoredTx{
+		ID: common.HexToHash("0x2"), From: common.HexToAddress("0x2"), To: &to,
+		Status: types.MonitoredTxStatusFailed, History: make(map[common.Hash]bool),
+	}))
+
+	// not draining yet
+	drained := testData.sut.Drained()
+	require.NoError(t, testData.sut.checkDrained(testData.ctx))
+	requireNotClosed(t, drained)
+
+	testData.sut.Drain()
+
+	_, err := testData.sut.Add(testData.ctx, &to, big.NewInt(1), []byte{}, 0, nil)
+	require.ErrorIs(t, err, ErrDraining)
+	_, err = testData.sut.AddWithGas(testData.ctx, &to, big.NewInt(1), []byte{}, 0, nil, 21000)
+	require.ErrorIs(t, err, ErrDraining)
+
+	// the sent tx is still in flight
+	require.NoError(t, testData.sut.checkDrained(testData.ctx))
+	requireNotClosed(t, drained)
+
+	mTx, err := testData.sut.storage.Get(testData.ctx, common.HexToHash("0x1"))
+	require.NoError(t, err)
+	mTx.Status = types.MonitoredTxStatusFinalized
+	require.NoError(t, testData.sut.storage.Update(testData.ctx, mTx))
+
+	require.NoError(t, testData.sut.checkDrained(testData.ctx))
+	select {
+	case <-drained:
+	default:
+		t.Fatal("drained channel not closed")
+	}
+	// checking it again doesn't close the channel twice
+	require.NoError(t, testData.sut.checkDrained(testData.ctx))
+}
+
+func requireNotClosed(t *testing.T, ch <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-ch:
+		t.Fatal("channel closed")
+	default:
+	}
+}
+
+func TestMaxBlobsPerTx(t *testing.T) {
+	testData := newTestData(t, false)
+	to := common.HexToAddress("0x1")
+
+	// the default maximum of EIP-4844
+	sidecar := &ethtypes.BlobTxSidecar{Blobs: make([]kzg4844.Blob, 7)}
+	_, err := testData.sut.Add(testData.ctx, &to, big.NewInt(0), nil, 0, sidecar)
+	require.ErrorIs(t, err, ErrTooManyBlobs)
+	require.ErrorContains(t, err, "the blob sidecar has 7 blobs and a tx can carry up to 6")
+
+	testData.sut.cfg.MaxBlobsPerTx = 2
+	sidecar = &ethtypes.BlobTxSidecar{Blobs: make([]kzg4844.Blob, 3)}
+	_, err = testData.sut.AddWithGas(testData.ctx, &to, big.NewInt(0), nil, 0, sidecar, 21000)
+	require.ErrorIs(t, err, ErrTooManyBlobs)
+
+	// nothing was stored
+	mTxs, err := testData.sut.storage.GetByStatus(testData.ctx, nil)
+	require.NoError(t, err)
+	require.Empty(t, mTxs)
+}
+
+func TestRepairBlockNumbers(t *testing.T) {
+	testData := newTestData(t, false)
+	to := common.HexToAddress("0x1")
+	minedTxHash := common.HexToHash("0xa1")
+	unknownTxHash := common.HexToHash("0xa2")
+
+	newTx := func(id string, status types.MonitoredTxStatus, blockNumber *big.Int, txHash common.Hash) types.MonitoredTx {
+		return types.MonitoredTx{
+			ID: common.HexToHash(id), From: common.HexToAddress("0x2"), To: &to, Status: status,
+			BlockNumber: blockNumber, History: map[common.Hash]bool{txHash: true},
+		}
+	}
+	require.NoError(t, testData.sut.storage.Add(testData.ctx,
+		newTx("0x1", types.MonitoredTxStatusMined, nil, minedTxHash)))
+	require.NoError(t, testData.sut.storage.Add(testData.ctx,
+		newTx("0x2", types.MonitoredTxStatusSafe, nil, unknownTxHash)))
+	require.NoError(t, testData.sut.storage.Add(testData.ctx,
+		newTx("0x3", types.MonitoredTxStatusMined, big.NewInt(10), common.HexToHash("0xa3"))))
+
+	t.Run("nil block numbers are skipped by the promotion", func(t *testing.T) {
+		testData.sut.cfg.SafeStatusL1NumberOfBlocks = 5
+		testData.sut.cfg.FinalizedStatusL1NumberOfBlocks = 5
+		testData.ethermanMock.EXPECT().GetLatestBlockNumber(testData.ctx).Return(uint64(100), nil).Twice()
+
+		require.NoError(t, testData.sut.waitMinedTxToBeSafe(testData.ctx))
+		require.NoError(t, testData.sut.waitSafeTxToBeFinalized(testData.ctx))
+
+		mTx, err := testData.sut.storage.Get(testData.ctx, common.HexToHash("0x1"))
+		require.NoError(t, err)
+		require.Equal(t, types.MonitoredTxStatusMined, mTx.Status)
+		mTx, err = testData.sut.storage.Get(testData.ctx, common.HexToHash("0x2"))
+		require.NoError(t, err)
+		require.Equal(t, types.MonitoredTxStatusSafe, mTx.Status)
+		// the tx with block number is promoted
+		mTx, err = testData.sut.storage.Get(testData.ctx, common.HexToHash("0x3"))
+		require.NoError(t, err)
+		require.Equal(t, types.MonitoredTxStatusFinalized, mTx.Status)
+	})
+
+	t.Run("backfill", func(t *testing.T) {
+		testData.ethermanMock.EXPECT().CheckTxWasMined(testData.ctx, minedTxHash).Return(true,
+			&ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful, TxHash: minedTxHash, BlockNumber: big.NewInt(15)},
+			nil).Once()
+		testData.ethermanMock.EXPECT().CheckTxWasMined(testData.ctx, unknownTxHash).Return(false, nil, nil).Once()
+
+		require.NoError(t, testData.sut.RepairBlockNumbers(testData.ctx))
+
+		mTx, err := testData.sut.storage.Get(testData.ctx, common.HexToHash("0x1"))
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(15), mTx.BlockNumber)
+		require.Equal(t, types.MonitoredTxStatusMined, mTx.Status)
+
+		// the tx whose receipt is not found is kept as it is
+		mTx, err = testData.sut.storage.Get(testData.ctx, common.HexToHash("0x2"))
+		require.NoError(t, err)
+		require.Nil(t, mTx.BlockNumber)
+	})
+}
+
+func TestFramedBlobDataRoundTrip(t *testing.T) {
+	sut := &Client{}
+	elemPayload := params.BlobTxBytesPerFieldElement - 1
+
+	sizes := []int{
+		0,
+		1,
+		elemPayload - blobFramingHeaderLen,     // header and data fill exactly the first field element
+		elemPayload - blobFramingHeaderLen + 1, // data spills into the second field element
+		elemPayload,
+		2 * elemPayload,
+		1000,
+		MaxFramedBlobDataLen - 1,
+		MaxFramedBlobDataLen,
+	}
+
+	for _, size := range sizes {
+		t.Run(fmt.Sprintf("size %d", size), func(t *testing.T) {
+			data := make([]byte, size)
+			for i := range data {
+				data[i] = byte(i%255) + 1
+			}
+
+			blob, err := sut.EncodeFramedBlobData(data)
+			require.NoError(t, err)
+
+			decoded, err := sut.DecodeBlobData(blob)
+			require.NoError(t, err)
+			require.Equal(t, data, decoded)
+		})
+	}
+
+	t.Run("data longer than allowed", func(t *testing.T) {
+		_, err := sut.EncodeFramedBlobData(make([]byte, MaxFramedBlobDataLen+1))
+		require.Error(t, err)
+	})
+
+	t.Run("blob not framed", func(t *testing.T) {
+		blob, err := sut.EncodeBlobData([]byte{1, 2, 3})
+		require.NoError(t, err)
+
+		_, err = sut.DecodeBlobData(blob)
+		require.ErrorIs(t, err, ErrBlobDataNotFramed)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		blob, err := sut.EncodeFramedBlobData([]byte{1, 2, 3})
+		require.NoError(t, err)
+		blob[1+len(blobFramingMagic)] = blobFramingVersion + 1
+
+		_, err = sut.DecodeBlobData(blob)
+		require.ErrorIs(t, err, ErrUnsupportedBlobFramingVersion)
+	})
+
+	t.Run("invalid length", func(t *testing.T) {
+		blob, err := sut.EncodeFramedBlobData([]byte{1, 2, 3})
+		require.NoError(t, err)
+		blob[1+len(blobFramingMagic)+1] = 0xff
+
+		_, err = sut.DecodeBlobData(blob)
+		require.ErrorIs(t, err, ErrInvalidBlobFraming)
+	})
+
+	t.Run("field element not starting with zero", func(t *testing.T) {
+		blob, err := sut.EncodeFramedBlobData([]byte{1, 2, 3})
+		require.NoError(t, err)
+		blob[params.BlobTxBytesPerFieldElement] = 1
+
+		_, err = sut.DecodeBlobData(blob)
+		require.ErrorIs(t, err, ErrInvalidBlobFraming)
+	})
+}
+
+func TestAddWithDeadline(t *testing.T) {
+	testData := newTestData(t, false)
+	testData.sut.cfg.GasPriceMarginFactor = 1
+	testData.sut.from = common.HexToAddress("0x456")
+	to := common.HexToAddress("0x1")
+
+	_, err := testData.sut.AddWithDeadline(testData.ctx, &to, big.NewInt(1), []byte{}, 0, nil, 0)
+	require.Error(t, err)
+
+	testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(1), nil)
+	testData.ethermanMock.EXPECT().EstimateGas(testData.ctx, testData.sut.from, &to, big.NewInt(1), []byte{}).
+		Return(uint64(21000), nil)
+	testData.ethermanMock.EXPECT().PendingNonce(testData.ctx, testData.sut.from).Return(uint64(1), nil)
+
+	id, err := testData.sut.AddWithDeadline(testData.ctx, &to, big.NewInt(1), []byte{}, 0, nil, 100)
+	require.NoError(t, err)
+
+	mTx, err := testData.sut.storage.Get(testData.ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), mTx.ValidUntilBlock)
+
+	// while the chain doesn't pass the deadline the tx keeps being sent
+	signErr := errors.New("sign failed")
+	testData.ethermanMock.EXPECT().GetLatestBlockNumber(testData.ctx).Return(uint64(100), nil).Once()
+	testData.ethermanMock.EXPECT().SignTx(testData.ctx, mTx.From, mock.Anything).Return(nil, signErr).Once()
+
+	iterations, err := testData.sut.getMonitoredTxnIteration(testData.ctx)
+	require.NoError(t, err)
+	require.Len(t, iterations, 1)
+	testData.sut.monitorTx(testData.ctx, iterations[0], createMonitoredTxLogger(*iterations[0].MonitoredTx))
+
+	stored, err := testData.sut.storage.Get(testData.ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, types.MonitoredTxStatusCreated, stored.Status)
+
+	// once the chain passes the deadline the unmined tx is evicted without being sent again
+	testData.ethermanMock.EXPECT().GetLatestBlockNumber(testData.ctx).Return(uint64(101), nil).Once()
+
+	iterations, err = testData.sut.getMonitoredTxnIteration(testData.ctx)
+	require.NoError(t, err)
+	require.Len(t, iterations, 1)
+	testData.sut.monitorTx(testData.ctx, iterations[0], createMonitoredTxLogger(*iterations[0].MonitoredTx))
+
+	stored, err = testData.sut.storage.Get(testData.ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, types.MonitoredTxStatusEvicted, stored.Status)
+	testData.ethermanMock.AssertNumberOfCalls(t, "SignTx", 1)
+}
+
+func TestResultRawTx(t *testing.T) {
+	testData := newTestData(t, false)
+	to := common.HexToAddress("0x1")
+
+	tx := ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+		ChainID: big.NewInt(1), Nonce: 1, To: &to, Value: big.NewInt(1), Data: []byte("data"),
+		Gas: 21000, GasFeeCap: big.NewInt(10), GasTipCap: big.NewInt(1),
+	})
+	notFoundHash := common.HexToHash("0x2")
+	mTx := types.MonitoredTx{
+		ID: common.HexToHash("0x1"), To: &to, Nonce: 1, Status: types.MonitoredTxStatusSent,
+		History: map[common.Hash]bool{tx.Hash(): true, notFoundHash: true},
+	}
+	require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+
+	testData.ethermanMock.EXPECT().GetTx(testData.ctx, tx.Hash()).Return(tx, true, nil).Once()
+	testData.ethermanMock.EXPECT().GetTxReceipt(testData.ctx, tx.Hash()).Return(nil, ethereum.NotFound).Once()
+	testData.ethermanMock.EXPECT().GetRevertMessage(testData.ctx, tx).Return("", nil).Once()
+	testData.ethermanMock.EXPECT().GetTx(testData.ctx, notFoundHash).Return(nil, false, ethereum.NotFound).Once()
+	testData.ethermanMock.EXPECT().GetTxReceipt(testData.ctx, notFoundHash).Return(nil, ethereum.NotFound).Once()
+	testData.ethermanMock.EXPECT().GetRevertMessage(testData.ctx, (*ethtypes.Transaction)(nil)).Return("", nil).Once()
+
+	result, err := testData.sut.Result(testData.ctx, mTx.ID)
+	require.NoError(t, err)
+
+	// the raw bytes decode back to the same tx
+	rawTx, err := hexutil.Decode(result.Txs[tx.Hash()].RawTx)
+	require.NoError(t, err)
+	decodedTx := new(ethtypes.Transaction)
+	require.NoError(t, decodedTx.UnmarshalBinary(rawTx))
+	require.Equal(t, tx.Hash(), decodedTx.Hash())
+
+	// a tx not found has no raw bytes
+	require.Empty(t, result.Txs[notFoundHash].RawTx)
+}
+
+func TestNonceSource(t *testing.T) {
+	from := common.HexToAddress("0x456")
+	to := common.HexToAddress("0x1")
+
+	newCreatedTx := func(t *testing.T, testData *testEthTxManagerData) {
+		t.Helper()
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, types.MonitoredTx{
+			ID: common.HexToHash("0x1"), From: from, To: &to, Status: types.MonitoredTxStatusCreated,
+			History: make(map[common.Hash]bool),
+		}))
+	}
+
+	t.Run("pending by default", func(t *testing.T) {
+		testData := newTestData(t, false)
+		newCreatedTx(t, testData)
+
+		testData.ethermanMock.EXPECT().PendingNonce(testData.ctx, from).Return(uint64(7), nil).Once()
+
+		iterations, err := testData.sut.getMonitoredTxnIteration(testData.ctx)
+		require.NoError(t, err)
+		require.Len(t, iterations, 1)
+		require.Equal(t, uint64(7), iterations[0].Nonce)
+		testData.ethermanMock.AssertNotCalled(t, "CurrentNonce", mock.Anything, mock.Anything)
+	})
+
+	t.Run("latest", func(t *testing.T) {
+		testData := newTestData(t, false)
+		testData.sut.cfg.NonceSource = NonceSourceLatest
+		newCreatedTx(t, testData)
+
+		testData.ethermanMock.EXPECT().CurrentNonce(testData.ctx, from).Return(uint64(5), nil).Once()
+
+		iterations, err := testData.sut.getMonitoredTxnIteration(testData.ctx)
+		require.NoError(t, err)
+		require.Len(t, iterations, 1)
+		require.Equal(t, uint64(5), iterations[0].Nonce)
+		testData.ethermanMock.AssertNotCalled(t, "PendingNonce", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		testData := newTestData(t, false)
+		testData.sut.cfg.NonceSource = "safe"
+		newCreatedTx(t, testData)
+
+		_, err := testData.sut.getMonitoredTxnIteration(testData.ctx)
+		require.ErrorIs(t, err, ErrUnknownNonceSource)
+	})
+}
+
+type fakeNonceProvider struct {
+	nonces map[common.Address]uint64
+	err    error
+	calls  int
+}
+
+// Nonce hands out the next nonce of the sender, as a nonce allocator does
+func (f *fakeNonceProvider) Nonce(_ context.Context, sender common.Address) (uint64, error) {
+	f.calls++
+	nonce := f.nonces[sender]
+	f.nonces[sender]++
+	return nonce, f.err
+}
+
+func TestNonceProvider(t *testing.T) {
+	from := common.HexToAddress("0x456")
+	to := common.HexToAddress("0x1")
+
+	t.Run("assigns the provided nonces", func(t *testing.T) {
+		testData := newTestData(t, false)
+		provider := &fakeNonceProvider{nonces: map[common.Address]uint64{from: 42}}
+		testData.sut.SetNonceProvider(provider)
+
+		for i := 1; i <= 2; i++ {
+			require.NoError(t, testData.sut.storage.Add(testData.ctx, types.MonitoredTx{
+				ID: common.BigToHash(big.NewInt(int64(i))), From: from, To: &to, Status: types.MonitoredTxStatusCreated,
+				History: make(map[common.Hash]bool),
+			}))
+		}
+
+		iterations, err := testData.sut.getMonitoredTxnIteration(testData.ctx)
+		require.NoError(t, err)
+		require.Len(t, iterations, 2)
+		require.Equal(t, uint64(42), iterations[0].Nonce)
+		require.Equal(t, uint64(43), iterations[1].Nonce)
+		// the provider is asked for the nonce of each tx
+		require.Equal(t, 2, provider.calls)
+		testData.ethermanMock.AssertNotCalled(t, "PendingNonce", mock.Anything, mock.Anything)
+	})
+
+	t.Run("provider failure", func(t *testing.T) {
+		testData := newTestData(t, false)
+		providerErr := errors.New("allocator unavailable")
+		testData.sut.SetNonceProvider(&fakeNonceProvider{nonces: map[common.Address]uint64{}, err: providerErr})
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, types.MonitoredTx{
+			ID: common.HexToHash("0x1"), From: from, To: &to, Status: types.MonitoredTxStatusCreated,
+			History: make(map[common.Hash]bool),
+		}))
+
+		_, err := testData.sut.getMonitoredTxnIteration(testData.ctx)
+		require.ErrorIs(t, err, providerErr)
+	})
+}
+
+// failingTxStorage fails the updates of a monitored tx done inside the storage transactions
+type failingTxStorage struct {
+	types.StorageInterface
+	failID common.Hash
+}
+
+func (f *failingTxStorage) WithTx(ctx context.Context, fn func(types.StorageInterface) error) error {
+	return f.StorageInterface.WithTx(ctx, func(storage types.StorageInterface) error {
+		return fn(&failingTxStorage{StorageInterface: storage, failID: f.failID})
+	})
+}
+
+func (f *failingTxStorage) Update(ctx context.Context, mTx types.MonitoredTx) error {
+	if mTx.ID == f.failID {
+		return errors.New("simulated failure")
+	}
+	return f.StorageInterface.Update(ctx, mTx)
+}
+
+func TestGetMonitoredTxnIterationAtomicNonces(t *testing.T) {
+	testData := newTestData(t, false)
+	from := common.HexToAddress("0x456")
+	to := common.HexToAddress("0x1")
+	ids := []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2"), common.HexToHash("0x3")}
+	for _, id := range ids {
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, types.MonitoredTx{
+			ID: id, From: from, To: &to, Status: types.MonitoredTxStatusCreated, History: make(map[common.Hash]bool),
+		}))
+	}
+	testData.ethermanMock.EXPECT().PendingNonce(testData.ctx, from).Return(uint64(7), nil).Twice()
+
+	requireNonces := func(t *testing.T, expected ...uint64) {
+		t.Helper()
+		for i, id := range ids {
+			mTx, err := testData.sut.storage.Get(testData.ctx, id)
+			require.NoError(t, err)
+			require.Equal(t, expected[i], mTx.Nonce, id.String())
+		}
+	}
+
+	// the update of the second tx fails, so none of the nonces of the cycle are persisted
+	storage := testData.sut.storage
+	testData.sut.storage = &failingTxStorage{StorageInterface: storage, failID: ids[1]}
+	_, err := testData.sut.getMonitoredTxnIteration(testData.ctx)
+	require.ErrorContains(t, err, "simulated failure")
+	requireNonces(t, 0, 0, 0)
+
+	testData.sut.storage = storage
+	iterations, err := testData.sut.getMonitoredTxnIteration(testData.ctx)
+	require.NoError(t, err)
+	require.Len(t, iterations, 3)
+	requireNonces(t, 7, 8, 9)
+}
+
+func TestPriority(t *testing.T) {
+	testData := newTestData(t, false)
+	from := common.HexToAddress("0x456")
+	to := common.HexToAddress("0x1")
+	data := []byte("data")
+
+	testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(1), nil).Once()
+	testData.ethermanMock.EXPECT().EstimateGas(testData.ctx, mock.Anything, &to, big.NewInt(1), data).
+		Return(uint64(21000), nil).Once()
+	id, err := testData.sut.AddWithPriority(testData.ctx, &to, big.NewInt(1), data, 0, nil, 5)
+	require.NoError(t, err)
+	mTx, err := testData.sut.storage.Get(testData.ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 5, mTx.Priority)
+	require.NoError(t, testData.sut.storage.Remove(testData.ctx, id))
+
+	// bulk txs added before and after a critical one
+	priorities := []int{0, 10, -1, 0}
+	for i, priority := range priorities {
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, types.MonitoredTx{
+			ID: common.BigToHash(big.NewInt(int64(i + 1))), From: from, To: &to, Status: types.MonitoredTxStatusCreated,
+			History: make(map[common.Hash]bool), Priority: priority,
+		}))
+	}
+
+	testData.ethermanMock.EXPECT().PendingNonce(testData.ctx, from).Return(uint64(7), nil).Once()
+
+	iterations, err := testData.sut.getMonitoredTxnIteration(testData.ctx)
+	require.NoError(t, err)
+	require.Len(t, iterations, len(priorities))
+
+	// the higher priority goes first, the txs with the same priority keep the order they were added
+	expectedIDs := []int64{2, 1, 4, 3}
+	for i, iteration := range iterations {
+		require.Equal(t, common.BigToHash(big.NewInt(expectedIDs[i])), iteration.ID)
+		require.Equal(t, uint64(7+i), iteration.Nonce)
+	}
+}
+
+func TestRemoveByStatus(t *testing.T) {
+	testData := newTestData(t, false)
+	to := common.HexToAddress("0x1")
+
+	for i, status := range []types.MonitoredTxStatus{
+		types.MonitoredTxStatusFailed, types.MonitoredTxStatusFailed, types.MonitoredTxStatusSent,
+		types.MonitoredTxStatusEvicted,
+	} {
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, types.MonitoredTx{
+			ID: common.HexToHash(fmt.Sprintf("0x%x", i+1)), To: &to, Nonce: uint64(i), Status: status,
+		}))
+	}
+
+	// the txs in flight are not removed unless forced
+	_, err := testData.sut.RemoveByStatus(testData.ctx,
+		[]types.MonitoredTxStatus{types.MonitoredTxStatusFailed, types.MonitoredTxStatusSent}, false)
+	require.ErrorIs(t, err, ErrNonTerminalStatus)
+
+	removed, err := testData.sut.RemoveByStatus(testData.ctx, []types.MonitoredTxStatus{types.MonitoredTxStatusFailed}, false)
+	require.NoError(t, err)
+	require.Equal(t, 2, removed)
+
+	counts, err := testData.sut.storage.CountByStatus(testData.ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[types.MonitoredTxStatus]int{
+		types.MonitoredTxStatusSent:    1,
+		types.MonitoredTxStatusEvicted: 1,
+	}, counts)
+
+	removed, err = testData.sut.RemoveByStatus(testData.ctx, []types.MonitoredTxStatus{types.MonitoredTxStatusSent}, true)
+	require.NoError(t, err)
+	require.Equal(t, 1, removed)
+}
+
+func TestReviewMonitoredTxBumpSchedule(t *testing.T) {
+	testData := newTestData(t, true)
+	testData.sut.cfg.GasPriceMarginFactor = 1
+	testData.sut.cfg.BumpScheduleBasePercentage = 12.5
+	testData.sut.cfg.MaxGasPriceLimit = 5000
+	to := common.HexToAddress("0x1")
+
+	mTx := &monitoredTxnIteration{
+		MonitoredTx: &types.MonitoredTx{
+			ID: common.HexToHash("0x123"), To: &to, Status: types.MonitoredTxStatusSent,
+			Gas: 21000, GasPrice: big.NewInt(800), GasTipCap: big.NewInt(80),
+			History: make(map[common.Hash]bool),
+		},
+	}
+	testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(1), nil).Times(5)
+
+	// the increments grow from 12.5% doubling on each review up to 100%, limited by the max gas price
+	expected := []struct {
+		gasPrice  int64
+		gasTipCap int64
+	}{
+		{900, 90},   // +12.5%
+		{1125, 112}, // +25%
+		{1687, 168}, // +50%
+		{3374, 336}, // +100%
+		{5000, 672}, // +100%, gas price limited
+	}
+	for i, e := range expected {
+		err := testData.sut.reviewMonitoredTxGas(testData.ctx, mTx, createMonitoredTxLogger(*mTx.MonitoredTx))
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(e.gasPrice), mTx.GasPrice, "review %d", i)
+		require.Equal(t, big.NewInt(e.gasTipCap), mTx.GasTipCap, "review %d", i)
+		// the review doesn't count as a resend until the replacement is sent
+		require.Equal(t, uint64(i), mTx.ResendCount)
+		mTx.ResendCount++
+	}
+
+	// a suggestion above the bumped fees is used as it is
+	testData.sut.cfg.MaxGasPriceLimit = 0
+	testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(20000), nil).Once()
+	err := testData.sut.reviewMonitoredTxGas(testData.ctx, mTx, createMonitoredTxLogger(*mTx.MonitoredTx))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(20000), mTx.GasPrice)
+}
+
+func TestReviewMonitoredTxBumpScheduleTipCap(t *testing.T) {
+	testData := newTestData(t, true)
+	testData.sut.cfg.GasPriceMarginFactor = 1
+	testData.sut.cfg.BumpScheduleBasePercentage = 100
+	testData.sut.cfg.MaxGasPriceLimit = 1000
+	to := common.HexToAddress("0x1")
+
+	mTx := &monitoredTxnIteration{
+		MonitoredTx: &types.MonitoredTx{
+			ID: common.HexToHash("0x123"), To: &to, Status: types.MonitoredTxStatusSent,
+			Gas: 21000, GasPrice: big.NewInt(800), GasTipCap: big.NewInt(700),
+			History: make(map[common.Hash]bool),
+		},
+	}
+	testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(1), nil).Once()
+
+	// the fee cap is limited by the max gas price and the bumped tip by the fee cap
+	err := testData.sut.reviewMonitoredTxGas(testData.ctx, mTx, createMonitoredTxLogger(*mTx.MonitoredTx))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1000), mTx.GasPrice)
+	require.Equal(t, big.NewInt(1000), mTx.GasTipCap)
+}
+
+func TestReviewMonitoredTxGasOffset(t *testing.T) {
+	to := common.HexToAddress("0x1")
+	newIteration := func() *monitoredTxnIteration {
+		return &monitoredTxnIteration{
+			MonitoredTx: &types.MonitoredTx{
+				ID: common.HexToHash("0x123"), From: common.HexToAddress("0x456"), To: &to,
+				Status: types.MonitoredTxStatusSent, Value: big.NewInt(0),
+				Gas: 21000, GasOffset: 5000, GasPrice: big.NewInt(100), EstimateGas: true,
+				History: make(map[common.Hash]bool),
+			},
+		}
+	}
+
+	t.Run("re-estimations replace the gas and keep the offset on top", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.sut.cfg.ReestimateGasOnReview = true
+		mTx := newIteration()
+
+		testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(100), nil).Times(3)
+		testData.ethermanMock.EXPECT().EstimateGas(testData.ctx, mTx.From, mTx.To, mTx.Value, mTx.Data).
+			Return(uint64(30000), nil).Twice()
+		testData.ethermanMock.EXPECT().EstimateGas(testData.ctx, mTx.From, mTx.To, mTx.Value, mTx.Data).
+			Return(uint64(25000), nil).Once()
+		testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(nil).Times(3)
+
+		logger := createMonitoredTxLogger(*mTx.MonitoredTx)
+		for i := 0; i < 3; i++ {
+			require.NoError(t, testData.sut.reviewMonitoredTxGas(testData.ctx, mTx, logger))
+			// the offset is neither lost nor applied twice across the reviews
+			require.Equal(t, uint64(30000), mTx.Gas)
+			require.Equal(t, uint64(5000), mTx.GasOffset)
+			require.Equal(t, uint64(35000), mTx.Tx().Gas())
+		}
+	})
+
+	t.Run("ran out of gas multiplies the gas limit", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.sut.cfg.OutOfGasMultiplier = 1.2
+		mTx := newIteration()
+		mTx.lastReceipt = &ethtypes.Receipt{Status: ethtypes.ReceiptStatusFailed, GasUsed: mTx.GasLimit()}
+
+		testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(100), nil).Once()
+		testData.ethermanMock.EXPECT().EstimateGas(testData.ctx, mTx.From, mTx.To, mTx.Value, mTx.Data).
+			Return(uint64(21000), nil).Once()
+		testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(nil).Once()
+
+		require.NoError(t, testData.sut.reviewMonitoredTxGas(testData.ctx, mTx, createMonitoredTxLogger(*mTx.MonitoredTx)))
+		require.Equal(t, uint64(26200), mTx.Gas)
+		require.Equal(t, uint64(31200), mTx.Tx().Gas())
+	})
+}
+
+func TestGasEstimationMargin(t *testing.T) {
+	to := common.HexToAddress("0x1")
+	data := []byte("data")
+
+	t.Run("add", func(t *testing.T) {
+		testData := newTestData(t, false)
+		testData.sut.cfg.GasEstimationMargin = 10
+
+		testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(1), nil).Once()
+		testData.ethermanMock.EXPECT().EstimateGas(testData.ctx, mock.Anything, &to, big.NewInt(1), data).
+			Return(uint64(100000), nil).Once()
+		id, err := testData.sut.Add(testData.ctx, &to, big.NewInt(1), data, 5000, nil)
+		require.NoError(t, err)
+
+		// the margin is applied to the estimation and the offset is added on top of it
+		mTx, err := testData.sut.storage.Get(testData.ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, uint64(110000), mTx.Gas)
+		require.Equal(t, uint64(115000), mTx.GasLimit())
+	})
+
+	newReviewedTx := func() *monitoredTxnIteration {
+		return &monitoredTxnIteration{
+			MonitoredTx: &types.MonitoredTx{
+				ID: common.HexToHash("0x123"), From: common.HexToAddress("0x456"), To: &to,
+				Status: types.MonitoredTxStatusSent, Value: big.NewInt(0),
+				Gas: 21000, GasOffset: 5000, GasPrice: big.NewInt(100), EstimateGas: true,
+				History: make(map[common.Hash]bool),
+			},
+		}
+	}
+
+	t.Run("review", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.sut.cfg.GasEstimationMargin = 25
+		testData.sut.cfg.ReestimateGasOnReview = true
+		mTx := newReviewedTx()
+
+		testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(100), nil).Once()
+		testData.ethermanMock.EXPECT().EstimateGas(testData.ctx, mTx.From, mTx.To, mTx.Value, mTx.Data).
+			Return(uint64(40000), nil).Once()
+		testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(nil).Once()
+
+		require.NoError(t, testData.sut.reviewMonitoredTxGas(testData.ctx, mTx, createMonitoredTxLogger(*mTx.MonitoredTx)))
+		require.Equal(t, uint64(50000), mTx.Gas)
+		require.Equal(t, uint64(55000), mTx.Tx().Gas())
+	})
+
+	t.Run("review reusing the last estimation", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.sut.cfg.GasEstimationMargin = 25
+		mTx := newReviewedTx()
+
+		// the reused gas already has the margin of its estimation, so it's not applied again
+		testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(100), nil).Once()
+		testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(nil).Once()
+
+		require.NoError(t, testData.sut.reviewMonitoredTxGas(testData.ctx, mTx, createMonitoredTxLogger(*mTx.MonitoredTx)))
+		require.Equal(t, uint64(21000), mTx.Gas)
+		require.Equal(t, uint64(26000), mTx.Tx().Gas())
+	})
+
+	t.Run("no margin by default", func(t *testing.T) {
+		testData := newTestData(t, true)
+		require.Equal(t, uint64(21000), testData.sut.withGasEstimationMargin(21000))
+	})
+}
+
+func TestCheckOrphanedTxs(t *testing.T) {
+	signer := common.HexToAddress("0x456")
+	removedSigner := common.HexToAddress("0x789")
+	to := common.HexToAddress("0x1")
+
+	newTestDataWithTxs := func(t *testing.T) *testEthTxManagerData {
+		t.Helper()
+		testData := newTestData(t, false)
+		for _, mTx := range []types.MonitoredTx{
+			{ID: common.HexToHash("0x1"), From: signer, Status: types.MonitoredTxStatusCreated},
+			{ID: common.HexToHash("0x2"), From: removedSigner, Status: types.MonitoredTxStatusSent},
+			{ID: common.HexToHash("0x3"), From: removedSigner, Status: types.MonitoredTxStatusMined},
+		} {
+			mTx.To = &to
+			mTx.History = make(map[common.Hash]bool)
+			require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+		}
+		testData.ethermanMock.EXPECT().PublicAddress().Return([]common.Address{signer}, nil).Once()
+		return testData
+	}
+	requireStatus := func(t *testing.T, testData *testEthTxManagerData, id string, status types.MonitoredTxStatus) {
+		t.Helper()
+		mTx, err := testData.sut.storage.Get(testData.ctx, common.HexToHash(id))
+		require.NoError(t, err)
+		require.Equal(t, status, mTx.Status)
+	}
+
+	t.Run("kept by default", func(t *testing.T) {
+		testData := newTestDataWithTxs(t)
+
+		orphaned, err := testData.sut.checkOrphanedTxs(testData.ctx)
+		require.NoError(t, err)
+		require.Equal(t, []common.Hash{common.HexToHash("0x2")}, orphaned)
+		requireStatus(t, testData, "0x2", types.MonitoredTxStatusSent)
+	})
+
+	t.Run("evicted", func(t *testing.T) {
+		testData := newTestDataWithTxs(t)
+		testData.sut.cfg.OrphanedTxPolicy = OrphanedTxPolicyEvict
+
+		orphaned, err := testData.sut.checkOrphanedTxs(testData.ctx)
+		require.NoError(t, err)
+		require.Equal(t, []common.Hash{common.HexToHash("0x2")}, orphaned)
+		requireStatus(t, testData, "0x1", types.MonitoredTxStatusCreated)
+		requireStatus(t, testData, "0x2", types.MonitoredTxStatusEvicted)
+		// only the pending txs are orphaned
+		requireStatus(t, testData, "0x3", types.MonitoredTxStatusMined)
+	})
+}
+
+func TestReviewMonitoredTxGasFeeHistory(t *testing.T) {
+	testData := newTestData(t, false)
+	testData.sut.cfg.GasPriceMarginFactor = 1
+	to := common.HexToAddress("0x1")
+	mTx := types.MonitoredTx{
+		ID: common.HexToHash("0x123"), From: common.HexToAddress("0x456"), To: &to,
+		Status: types.MonitoredTxStatusSent, Value: big.NewInt(0), Gas: 21000, GasPrice: big.NewInt(50),
+		EstimateGas: true, History: make(map[common.Hash]bool),
+	}
+	require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+
+	for _, suggested := range []int64{100, 200, 200} {
+		testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(suggested), nil).Once()
+
+		stored, err := testData.sut.storage.Get(testData.ctx, mTx.ID)
+		require.NoError(t, err)
+		iteration := &monitoredTxnIteration{MonitoredTx: &stored}
+		require.NoError(t, testData.sut.reviewMonitoredTxGas(testData.ctx, iteration, createMonitoredTxLogger(stored)))
+	}
+
+	// two bumps, the last review didn't change the fees
+	stored, err := testData.sut.storage.Get(testData.ctx, mTx.ID)
+	require.NoError(t, err)
+	require.Len(t, stored.FeeHistory, 2)
+	require.Equal(t, big.NewInt(100), stored.FeeHistory[0].GasPrice)
+	require.Equal(t, big.NewInt(200), stored.FeeHistory[1].GasPrice)
+	require.Nil(t, stored.FeeHistory[0].GasTipCap)
+	require.False(t, stored.FeeHistory[1].At.Before(stored.FeeHistory[0].At))
+
+	result, err := testData.sut.Result(testData.ctx, mTx.ID)
+	require.NoError(t, err)
+	require.Equal(t, stored.FeeHistory, result.FeeHistory)
+}
+
+// revertDataError is a reverted call error carrying the revert data, as returned by the nodes
+type revertDataError struct {
+	data string
+}
+
+func (e revertDataError) Error() string          { return "execution reverted: boom" }
+func (e revertDataError) ErrorData() interface{} { return e.data }
+
+func TestSimulateBeforeSend(t *testing.T) {
+	// Error("boom") ABI encoded
+	revertErr := revertDataError{data: "0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000004" +
+		"626f6f6d00000000000000000000000000000000000000000000000000000000"}
+	from := common.HexToAddress("0x456")
+	to := common.HexToAddress("0x1")
+
+	newCreatedTx := func(t *testing.T, testData *testEthTxManagerData) types.MonitoredTx {
+		t.Helper()
+		mTx := types.MonitoredTx{
+			ID: common.HexToHash("0x1"), From: from, To: &to, Value: big.NewInt(1), Data: []byte{0x1},
+			Gas: 21000, GasPrice: big.NewInt(1), Status: types.MonitoredTxStatusCreated,
+			History: make(map[common.Hash]bool),
+		}
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+		return mTx
+	}
+
+	t.Run("Simulate returns the revert reason", func(t *testing.T) {
+		testData := newTestData(t, false)
+		mTx := newCreatedTx(t, testData)
+
+		testData.ethermanMock.EXPECT().CallContract(testData.ctx, from, &to, big.NewInt(1), []byte{0x1}).
+			Return(nil, revertErr).Once()
+
+		err := testData.sut.Simulate(testData.ctx, mTx.ID)
+		require.ErrorIs(t, err, types.ErrReverted)
+		var revertedErr *types.RevertedError
+		require.ErrorAs(t, err, &revertedErr)
+		require.Equal(t, "boom", revertedErr.Reason)
+		require.Equal(t, mTx.ID, revertedErr.ID)
+
+		testData.ethermanMock.EXPECT().CallContract(testData.ctx, from, &to, big.NewInt(1), []byte{0x1}).
+			Return([]byte{}, nil).Once()
+		require.NoError(t, testData.sut.Simulate(testData.ctx, mTx.ID))
+	})
+
+	t.Run("a tx that would revert is set as failed without sending it", func(t *testing.T) {
+		testData := newTestData(t, false)
+		testData.sut.cfg.SimulateBeforeSend = true
+		mTx := newCreatedTx(t, testData)
+
+		nextTx := mTx
+		nextTx.ID = common.HexToHash("0x2")
+		nextTx.Data = []byte{0x2}
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, nextTx))
+
+		testData.ethermanMock.EXPECT().PendingNonce(testData.ctx, from).Return(uint64(1), nil).Once()
+		testData.ethermanMock.EXPECT().CallContract(testData.ctx, from, &to, big.NewInt(1), []byte{0x1}).
+			Return(nil, revertErr).Once()
+		testData.ethermanMock.EXPECT().CallContract(testData.ctx, from, &to, big.NewInt(1), []byte{0x2}).
+			Return([]byte{}, nil).Once()
+
+		// the tx that would revert fails before getting a nonce, which is assigned to the next tx instead
+		iterations, err := testData.sut.getMonitoredTxnIteration(testData.ctx)
+		require.NoError(t, err)
+		require.Len(t, iterations, 1)
+		require.Equal(t, nextTx.ID, iterations[0].ID)
+		require.Equal(t, uint64(1), iterations[0].Nonce)
+
+		stored, err := testData.sut.storage.Get(testData.ctx, mTx.ID)
+		require.NoError(t, err)
+		require.Equal(t, types.MonitoredTxStatusFailed, stored.Status)
+		testData.ethermanMock.AssertNotCalled(t, "SignTx", mock.Anything, mock.Anything, mock.Anything)
+		testData.ethermanMock.AssertNotCalled(t, "SendTx", mock.Anything, mock.Anything)
+		testData.ethermanMock.AssertNotCalled(t, "SendTxIdempotent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("a tx that would succeed is sent", func(t *testing.T) {
+		testData := newTestData(t, false)
+		testData.sut.cfg.SimulateBeforeSend = true
+		mTx := newCreatedTx(t, testData)
+
+		testData.ethermanMock.EXPECT().PendingNonce(testData.ctx, from).Return(uint64(1), nil).Once()
+		testData.ethermanMock.EXPECT().CallContract(testData.ctx, from, &to, big.NewInt(1), []byte{0x1}).
+			Return([]byte{}, nil).Once()
+		testData.ethermanMock.EXPECT().SignTx(testData.ctx, from, mock.Anything).Return(nil, errors.New("sign failed")).Once()
+
+		iterations, err := testData.sut.getMonitoredTxnIteration(testData.ctx)
+		require.NoError(t, err)
+		require.Len(t, iterations, 1)
+		testData.sut.monitorTx(testData.ctx, iterations[0], createMonitoredTxLogger(*iterations[0].MonitoredTx))
+
+		stored, err := testData.sut.storage.Get(testData.ctx, mTx.ID)
+		require.NoError(t, err)
+		require.Equal(t, types.MonitoredTxStatusCreated, stored.Status)
+	})
+	t.Run("the simulation doesn't hold the nonces and the later txs wait for the next cycle", func(t *testing.T) {
+		testData := newTestData(t, false)
+		testData.sut.cfg.SimulateBeforeSend = true
+		mTx := newCreatedTx(t, testData)
+
+		lateTx := mTx
+		lateTx.ID = common.HexToHash("0x2")
+		lateTx.Data = []byte{0x2}
+
+		testData.ethermanMock.EXPECT().PendingNonce(testData.ctx, from).Return(uint64(1), nil).Once()
+		testData.ethermanMock.EXPECT().CallContract(testData.ctx, from, &to, big.NewInt(1), []byte{0x1}).
+			RunAndReturn(func(context.Context, common.Address, *common.Address, *big.Int, []byte) ([]byte, error) {
+				// the txs sent on add can get their nonces while the loop simulates
+				require.True(t, testData.sut.nonceMu.TryLock())
+				testData.sut.nonceMu.Unlock()
+				require.NoError(t, testData.sut.storage.Add(testData.ctx, lateTx))
+				return []byte{}, nil
+			}).Once()
+
+		iterations, err := testData.sut.getMonitoredTxnIteration(testData.ctx)
+		require.NoError(t, err)
+		require.Len(t, iterations, 1)
+		require.Equal(t, mTx.ID, iterations[0].ID)
+
+		// the late tx is simulated by the next cycle before getting a nonce
+		testData.ethermanMock.EXPECT().CallContract(testData.ctx, from, &to, big.NewInt(1), []byte{0x1}).
+			Return([]byte{}, nil).Once()
+		testData.ethermanMock.EXPECT().CallContract(testData.ctx, from, &to, big.NewInt(1), []byte{0x2}).
+			Return([]byte{}, nil).Once()
+		testData.ethermanMock.EXPECT().PendingNonce(testData.ctx, from).Return(uint64(1), nil).Once()
+
+		iterations, err = testData.sut.getMonitoredTxnIteration(testData.ctx)
+		require.NoError(t, err)
+		require.Len(t, iterations, 2)
+	})
+}
+
+func TestMinConfirmationsForMined(t *testing.T) {
+	testData := newTestData(t, false)
+	testData.sut.cfg.MinConfirmationsForMined = 3
+	to := common.HexToAddress("0x1")
+	mTx := types.MonitoredTx{
+		ID: common.HexToHash("0x123"), From: common.HexToAddress("0x456"), To: &to,
+		Status: types.MonitoredTxStatusSent, Value: big.NewInt(0), Gas: 21000, GasPrice: big.NewInt(1),
+		History: make(map[common.Hash]bool),
+	}
+	require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+	receipt := &ethtypes.Receipt{
+		Status: ethtypes.ReceiptStatusSuccessful, TxHash: common.HexToHash("0x1"), BlockNumber: big.NewInt(10),
+	}
+
+	monitor := func(latestBlockNumber uint64) types.MonitoredTx {
+		t.Helper()
+		testData.ethermanMock.EXPECT().GetLatestBlockNumber(testData.ctx).Return(latestBlockNumber, nil).Once()
+		stored, err := testData.sut.storage.Get(testData.ctx, mTx.ID)
+		require.NoError(t, err)
+		iteration := &monitoredTxnIteration{MonitoredTx: &stored, confirmed: true, lastReceipt: receipt}
+		testData.sut.monitorTx(testData.ctx, iteration, createMonitoredTxLogger(stored))
+
+		stored, err = testData.sut.storage.Get(testData.ctx, mTx.ID)
+		require.NoError(t, err)
+		return stored
+	}
+
+	// the block the tx was mined in and the next one are 2 confirmations
+	stored := monitor(11)
+	require.Equal(t, types.MonitoredTxStatusSent, stored.Status)
+	require.Nil(t, stored.BlockNumber)
+
+	stored = monitor(12)
+	require.Equal(t, types.MonitoredTxStatusMined, stored.Status)
+	require.Equal(t, big.NewInt(10), stored.BlockNumber)
+
+	// 1 confirmation doesn't need the latest block
+	testData.sut.cfg.MinConfirmationsForMined = 1
+	hasConfirmations, err := testData.sut.hasMinConfirmationsForMined(testData.ctx,
+		&ethtypes.Receipt{BlockNumber: big.NewInt(20)})
+	require.NoError(t, err)
+	require.True(t, hasConfirmations)
+}
+
+func TestPreflight(t *testing.T) {
+	// the storage transaction runs the preflight function, which adds the preflight tx
+	expectPreflightStorage := func(t *testing.T, testData *testEthTxManagerData, addErr error) {
+		t.Helper()
+		testData.storageMock.EXPECT().WithTx(testData.ctx, mock.Anything).
+			RunAndReturn(func(_ context.Context, fn func(types.StorageInterface) error) error {
+				return fn(testData.storageMock)
+			}).Once()
+		testData.storageMock.EXPECT().Add(testData.ctx, mock.MatchedBy(func(mTx types.MonitoredTx) bool {
+			return mTx.ID == preflightTxID && mTx.Status == types.MonitoredTxStatusCreated
+		})).Return(addErr).Once()
+	}
+	newPreflightTestData := func(t *testing.T, chainID uint64) *testEthTxManagerData {
+		t.Helper()
+		testData := newTestData(t, true)
+		testData.sut.cfg.Etherman.L1ChainID = 1337
+		testData.ethermanMock.EXPECT().GetLatestBlockNumber(testData.ctx).Return(uint64(10), nil).Once()
+		testData.ethermanMock.EXPECT().GetChainID(testData.ctx).Return(chainID, nil).Once()
+		testData.ethermanMock.EXPECT().PublicAddress().Return([]common.Address{common.HexToAddress("0x1")}, nil).Once()
+		expectPreflightStorage(t, testData, nil)
+		return testData
+	}
+
+	t.Run("all checks pass", func(t *testing.T) {
+		testData := newPreflightTestData(t, 1337)
+
+		require.NoError(t, testData.sut.Preflight(testData.ctx))
+	})
+
+	t.Run("chain ID mismatch", func(t *testing.T) {
+		testData := newPreflightTestData(t, 1)
+
+		err := testData.sut.Preflight(testData.ctx)
+		require.ErrorContains(t, err, "chain ID check failed: the node chain ID is 1, expected 1337")
+		// the other checks are run anyway
+		require.NotContains(t, err.Error(), "signers check failed")
+		require.NotContains(t, err.Error(), "storage check failed")
+	})
+
+	t.Run("storage not writable", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.ethermanMock.EXPECT().GetLatestBlockNumber(testData.ctx).Return(uint64(10), nil).Once()
+		testData.ethermanMock.EXPECT().GetChainID(testData.ctx).Return(uint64(1337), nil).Once()
+		testData.ethermanMock.EXPECT().PublicAddress().Return([]common.Address{common.HexToAddress("0x1")}, nil).Once()
+		expectPreflightStorage(t, testData, errors.New("readonly database"))
+
+		require.ErrorContains(t, testData.sut.Preflight(testData.ctx), "storage check failed: readonly database")
+	})
+}
+
+func TestMarkFinalized(t *testing.T) {
+	to := common.HexToAddress("0x1")
+	addTx := func(t *testing.T, testData *testEthTxManagerData, status types.MonitoredTxStatus) types.MonitoredTx {
+		t.Helper()
+		mTx := types.MonitoredTx{
+			ID: common.HexToHash("0x123"), From: common.HexToAddress("0x456"), To: &to,
+			Status: status, History: make(map[common.Hash]bool),
+		}
+		if status == types.MonitoredTxStatusMined {
+			mTx.BlockNumber = big.NewInt(10)
+		}
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+		return mTx
+	}
+
+	t.Run("mined tx is set as finalized", func(t *testing.T) {
+		testData := newTestData(t, false)
+		mTx := addTx(t, testData, types.MonitoredTxStatusMined)
+		testData.ethermanMock.EXPECT().GetLatestBlockNumber(testData.ctx).Return(uint64(20), nil).Once()
+
+		require.NoError(t, testData.sut.MarkFinalized(testData.ctx, mTx.ID, 10))
+
+		stored, err := testData.sut.storage.Get(testData.ctx, mTx.ID)
+		require.NoError(t, err)
+		require.Equal(t, types.MonitoredTxStatusFinalized, stored.Status)
+		require.Equal(t, big.NewInt(10), stored.BlockNumber)
+	})
+
+	t.Run("created tx is rejected", func(t *testing.T) {
+		testData := newTestData(t, false)
+		mTx := addTx(t, testData, types.MonitoredTxStatusCreated)
+
+		err := testData.sut.MarkFinalized(testData.ctx, mTx.ID, 10)
+		require.ErrorIs(t, err, ErrInvalidStatus)
+
+		stored, err := testData.sut.storage.Get(testData.ctx, mTx.ID)
+		require.NoError(t, err)
+		require.Equal(t, types.MonitoredTxStatusCreated, stored.Status)
+	})
+
+	t.Run("block ahead of the latest one is rejected", func(t *testing.T) {
+		testData := newTestData(t, false)
+		mTx := addTx(t, testData, types.MonitoredTxStatusMined)
+		testData.ethermanMock.EXPECT().GetLatestBlockNumber(testData.ctx).Return(uint64(20), nil).Once()
+
+		require.ErrorIs(t, testData.sut.MarkFinalized(testData.ctx, mTx.ID, 21), ErrImplausibleBlockNumber)
+		require.ErrorIs(t, testData.sut.MarkFinalized(testData.ctx, mTx.ID, 0), ErrImplausibleBlockNumber)
+	})
+
+	t.Run("tx being processed is rejected", func(t *testing.T) {
+		testData := newTestData(t, false)
+		mTx := addTx(t, testData, types.MonitoredTxStatusMined)
+		testData.sut.processingTxs.Store(mTx.ID, struct{}{})
+
+		require.ErrorIs(t, testData.sut.MarkFinalized(testData.ctx, mTx.ID, 10), ErrMonitoredTxProcessing)
+
+		stored, err := testData.sut.storage.Get(testData.ctx, mTx.ID)
+		require.NoError(t, err)
+		require.Equal(t, types.MonitoredTxStatusMined, stored.Status)
+	})
+}
+
+func TestResultBatchCalls(t *testing.T) {
+	to := common.HexToAddress("0x1")
+	minedTx := ethtypes.NewTransaction(1, to, big.NewInt(1), 21000, big.NewInt(1), nil)
+	replacedTx := ethtypes.NewTransaction(1, to, big.NewInt(1), 21000, big.NewInt(2), nil)
+	failedReceipt := &ethtypes.Receipt{
+		Status: ethtypes.ReceiptStatusFailed, TxHash: minedTx.Hash(), BlockNumber: big.NewInt(10),
+	}
+
+	newBatchTestData := func(t *testing.T) (*testEthTxManagerData, types.MonitoredTx) {
+		t.Helper()
+		testData := newTestData(t, false)
+		testData.sut.cfg.BatchResultCalls = true
+		mTx := types.MonitoredTx{
+			ID: common.HexToHash("0x123"), To: &to, Nonce: 1, Status: types.MonitoredTxStatusFailed,
+			BlockNumber: big.NewInt(10), History: map[common.Hash]bool{minedTx.Hash(): true, replacedTx.Hash(): true},
+		}
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+		return testData, mTx
+	}
+
+	t.Run("history got in a single batch call", func(t *testing.T) {
+		testData, mTx := newBatchTestData(t)
+		testData.ethermanMock.EXPECT().GetTxsAndReceipts(testData.ctx, mock.Anything).
+			RunAndReturn(func(_ context.Context, hashes []common.Hash) ([]*ethtypes.Transaction, []*ethtypes.Receipt, error) {
+				require.ElementsMatch(t, []common.Hash{minedTx.Hash(), replacedTx.Hash()}, hashes)
+				txs := make([]*ethtypes.Transaction, len(hashes))
+				receipts := make([]*ethtypes.Receipt, len(hashes))
+				for i, hash := range hashes {
+					if hash == minedTx.Hash() {
+						txs[i], receipts[i] = minedTx, failedReceipt
+					}
+				}
+				return txs, receipts, nil
+			}).Once()
+		// only the failed receipt needs the revert message
+		testData.ethermanMock.EXPECT().GetRevertMessage(testData.ctx, minedTx).Return("invalid batch", nil).Once()
+
+		result, err := testData.sut.Result(testData.ctx, mTx.ID)
+		require.NoError(t, err)
+		require.Len(t, result.Txs, 2)
+		require.Equal(t, failedReceipt, result.Txs[minedTx.Hash()].Receipt)
+		require.Equal(t, "invalid batch", result.Txs[minedTx.Hash()].RevertMessage)
+		require.Nil(t, result.Txs[replacedTx.Hash()].Tx)
+		testData.ethermanMock.AssertNotCalled(t, "GetTx", mock.Anything, mock.Anything)
+		testData.ethermanMock.AssertNotCalled(t, "GetTxReceipt", mock.Anything, mock.Anything)
+	})
+
+	t.Run("history got one by one when the node doesn't support batch calls", func(t *testing.T) {
+		testData, mTx := newBatchTestData(t)
+		testData.ethermanMock.EXPECT().GetTxsAndReceipts(testData.ctx, mock.Anything).
+			Return(nil, nil, etherman.ErrBatchCallsNotSupported).Once()
+		testData.ethermanMock.EXPECT().GetTx(testData.ctx, minedTx.Hash()).Return(minedTx, false, nil).Once()
+		testData.ethermanMock.EXPECT().GetTxReceipt(testData.ctx, minedTx.Hash()).Return(failedReceipt, nil).Once()
+		testData.ethermanMock.EXPECT().GetRevertMessage(testData.ctx, minedTx).Return("invalid batch", nil).Once()
+		testData.ethermanMock.EXPECT().GetTx(testData.ctx, replacedTx.Hash()).
+			Return(nil, false, ethereum.NotFound).Once()
+		testData.ethermanMock.EXPECT().GetTxReceipt(testData.ctx, replacedTx.Hash()).
+			Return(nil, ethereum.NotFound).Once()
+		testData.ethermanMock.EXPECT().GetRevertMessage(testData.ctx, (*ethtypes.Transaction)(nil)).
+			Return("", nil).Once()
+
+		result, err := testData.sut.Result(testData.ctx, mTx.ID)
+		require.NoError(t, err)
+		require.Len(t, result.Txs, 2)
+		require.Equal(t, "invalid batch", result.Txs[minedTx.Hash()].RevertMessage)
+	})
+}
+
+func TestNoReplaceTx(t *testing.T) {
+	from := common.HexToAddress("0x456")
+	to := common.HexToAddress("0x1")
+	sentTx := ethtypes.NewTransaction(1, to, big.NewInt(1), 21000, big.NewInt(1), nil)
+
+	newSentNoReplaceTx := func(t *testing.T, testData *testEthTxManagerData) types.MonitoredTx {
+		t.Helper()
+		mTx := types.MonitoredTx{
+			ID: common.HexToHash("0x123"), From: from, To: &to, Nonce: 1, Value: big.NewInt(1),
+			Gas: 21000, GasPrice: big.NewInt(1), Status: types.MonitoredTxStatusSent, NoReplace: true,
+			History: map[common.Hash]bool{sentTx.Hash(): true},
+		}
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+		return mTx
+	}
+	monitor := func(t *testing.T, testData *testEthTxManagerData, mTx types.MonitoredTx) types.MonitoredTx {
+		t.Helper()
+		iterations, err := testData.sut.getMonitoredTxnIteration(testData.ctx)
+		require.NoError(t, err)
+		require.Len(t, iterations, 1)
+		testData.sut.monitorTx(testData.ctx, iterations[0], createMonitoredTxLogger(*iterations[0].MonitoredTx))
+
+		stored, err := testData.sut.storage.Get(testData.ctx, mTx.ID)
+		require.NoError(t, err)
+		return stored
+	}
+
+	t.Run("sent tx is not sent again while waiting to be mined", func(t *testing.T) {
+		testData := newTestData(t, false)
+		mTx := newSentNoReplaceTx(t, testData)
+		testData.ethermanMock.EXPECT().CheckTxWasMined(testData.ctx, sentTx.Hash()).Return(false, nil, nil).Twice()
+
+		for i := 0; i < 2; i++ {
+			stored := monitor(t, testData, mTx)
+			require.Equal(t, types.MonitoredTxStatusSent, stored.Status)
+			require.Len(t, stored.History, 1)
+			require.Equal(t, big.NewInt(1), stored.GasPrice)
+		}
+		testData.ethermanMock.AssertNotCalled(t, "SuggestedGasPrice", mock.Anything)
+		testData.ethermanMock.AssertNotCalled(t, "SignTx", mock.Anything, mock.Anything, mock.Anything)
+		testData.ethermanMock.AssertNotCalled(t, "SendTx", mock.Anything, mock.Anything)
+		testData.ethermanMock.AssertNotCalled(t, "SendTxIdempotent", mock.Anything, mock.Anything)
+
+		err := testData.sut.ForceResend(testData.ctx, mTx.ID, big.NewInt(10))
+		require.ErrorIs(t, err, ErrNoReplace)
+	})
+
+	t.Run("failed receipt doesn't send it with a new nonce", func(t *testing.T) {
+		testData := newTestData(t, false)
+		mTx := newSentNoReplaceTx(t, testData)
+		receipt := &ethtypes.Receipt{
+			Status: ethtypes.ReceiptStatusFailed, TxHash: sentTx.Hash(), BlockNumber: big.NewInt(10),
+		}
+		testData.ethermanMock.EXPECT().CheckTxWasMined(testData.ctx, sentTx.Hash()).Return(true, receipt, nil).Once()
+
+		stored := monitor(t, testData, mTx)
+		require.Equal(t, types.MonitoredTxStatusFailed, stored.Status)
+		require.Equal(t, uint64(1), stored.Nonce)
+		require.Len(t, stored.History, 1)
+		testData.ethermanMock.AssertNotCalled(t, "PendingNonce", mock.Anything, mock.Anything)
+		testData.ethermanMock.AssertNotCalled(t, "SignTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("evicted once the TTL passes", func(t *testing.T) {
+		testData := newTestData(t, false)
+		testData.sut.cfg.NoReplaceTxTTL = configTypes.NewDuration(time.Millisecond)
+		mTx := newSentNoReplaceTx(t, testData)
+		testData.ethermanMock.EXPECT().CheckTxWasMined(testData.ctx, sentTx.Hash()).Return(false, nil, nil).Once()
+		time.Sleep(10 * time.Millisecond)
+
+		stored := monitor(t, testData, mTx)
+		require.Equal(t, types.MonitoredTxStatusEvicted, stored.Status)
+		require.Len(t, stored.History, 1)
+	})
+}
+
+func TestBlockHashReorgDetection(t *testing.T) {
+	to := common.HexToAddress("0x1")
+	minedHeader := &ethtypes.Header{Number: big.NewInt(10), Extra: []byte("mined")}
+	reorgedHeader := &ethtypes.Header{Number: big.NewInt(10), Extra: []byte("reorged")}
+
+	newMinedTestData := func(t *testing.T) (*testEthTxManagerData, types.MonitoredTx) {
+		t.Helper()
+		testData := newTestData(t, false)
+		testData.sut.cfg.SafeStatusL1NumberOfBlocks = 5
+		minedBlockHash := minedHeader.Hash()
+		mTx := types.MonitoredTx{
+			ID: common.HexToHash("0x123"), From: common.HexToAddress("0x2"), To: &to,
+			Status: types.MonitoredTxStatusMined, BlockNumber: big.NewInt(10), BlockHash: &minedBlockHash,
+			History: map[common.Hash]bool{common.HexToHash("0xa1"): true}, MinedAt: time.Now(),
+		}
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+		testData.ethermanMock.EXPECT().GetLatestBlockNumber(testData.ctx).Return(uint64(100), nil).Once()
+		return testData, mTx
+	}
+
+	t.Run("canonical block keeps the hash", func(t *testing.T) {
+		testData, mTx := newMinedTestData(t)
+		testData.ethermanMock.EXPECT().HeaderByNumber(testData.ctx, big.NewInt(10)).Return(minedHeader, nil).Once()
+
+		require.NoError(t, testData.sut.waitMinedTxToBeSafe(testData.ctx))
+
+		stored, err := testData.sut.storage.Get(testData.ctx, mTx.ID)
+		require.NoError(t, err)
+		require.Equal(t, types.MonitoredTxStatusSafe, stored.Status)
+		require.Equal(t, minedHeader.Hash(), *stored.BlockHash)
+	})
+
+	t.Run("canonical block hash changed at the mined height", func(t *testing.T) {
+		testData, mTx := newMinedTestData(t)
+		testData.ethermanMock.EXPECT().HeaderByNumber(testData.ctx, big.NewInt(10)).Return(reorgedHeader, nil).Once()
+
+		require.NoError(t, testData.sut.waitMinedTxToBeSafe(testData.ctx))
+
+		// the tx is monitored again to find where it's mined now
+		stored, err := testData.sut.storage.Get(testData.ctx, mTx.ID)
+		require.NoError(t, err)
+		require.Equal(t, types.MonitoredTxStatusSent, stored.Status)
+		require.Nil(t, stored.BlockNumber)
+		require.Nil(t, stored.BlockHash)
+		require.True(t, stored.MinedAt.IsZero())
+	})
+}
+
+func TestMaxSendAttempts(t *testing.T) {
+	testData := newTestData(t, false)
+	testData.sut.cfg.MaxSendAttempts = 2
+	from := common.HexToAddress("0x456")
+	to := common.HexToAddress("0x1")
+	mTx := types.MonitoredTx{
+		ID: common.HexToHash("0x123"), From: from, To: &to, Nonce: 1, FixedNonce: true, Value: big.NewInt(1),
+		Gas: 21000, GasPrice: big.NewInt(1), Status: types.MonitoredTxStatusCreated,
+		History: make(map[common.Hash]bool),
+	}
+	require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+	signedTx := ethtypes.NewTransaction(1, to, big.NewInt(1), 21000, big.NewInt(1), nil)
+
+	testData.ethermanMock.EXPECT().SignTx(testData.ctx, from, mock.Anything).Return(signedTx, nil).Times(3)
+	testData.ethermanMock.EXPECT().GetTx(testData.ctx, signedTx.Hash()).Return(nil, false, ethereum.NotFound).Times(3)
+	// the network keeps rejecting the tx
+	testData.ethermanMock.EXPECT().SendTxIdempotent(testData.ctx, signedTx).Return(errors.New("rejected")).Twice()
+
+	monitor := func() types.MonitoredTx {
+		t.Helper()
+		iterations, err := testData.sut.getMonitoredTxnIteration(testData.ctx)
+		require.NoError(t, err)
+		require.Len(t, iterations, 1)
+		testData.sut.monitorTx(testData.ctx, iterations[0], createMonitoredTxLogger(*iterations[0].MonitoredTx))
+
+		stored, err := testData.sut.storage.Get(testData.ctx, mTx.ID)
+		require.NoError(t, err)
+		return stored
+	}
+
+	stored := monitor()
+	require.Equal(t, types.MonitoredTxStatusCreated, stored.Status)
+	require.Equal(t, uint64(1), stored.SendAttempts)
+
+	stored = monitor()
+	require.Equal(t, types.MonitoredTxStatusCreated, stored.Status)
+	require.Equal(t, uint64(2), stored.SendAttempts)
+
+	// the limit is reached, so it's evicted instead of being sent again
+	stored = monitor()
+	require.Equal(t, types.MonitoredTxStatusEvicted, stored.Status)
+	require.Equal(t, uint64(2), stored.SendAttempts)
+}
+
+func TestStreamResultsByStatus(t *testing.T) {
+	testData := newTestData(t, false)
+	to := common.HexToAddress("0x1")
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, types.MonitoredTx{
+			ID: common.BigToHash(big.NewInt(int64(i))), From: common.HexToAddress("0x456"), To: &to,
+			Status: types.MonitoredTxStatusCreated, History: make(map[common.Hash]bool),
+		}))
+	}
+
+	t.Run("all the results are streamed", func(t *testing.T) {
+		ids := make([]common.Hash, 0, 5)
+		for item := range testData.sut.StreamResultsByStatus(testData.ctx, nil) {
+			require.NoError(t, item.Err)
+			ids = append(ids, item.Result.ID)
+		}
+		require.Len(t, ids, 5)
+	})
+
+	t.Run("cancellation stops the stream early", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(testData.ctx)
+		stream := testData.sut.StreamResultsByStatus(ctx, []types.MonitoredTxStatus{types.MonitoredTxStatusCreated})
+
+		item := <-stream
+		require.NoError(t, item.Err)
+		cancel()
+
+		received := 1
+		for range stream {
+			received++
+		}
+		// at most the result being sent when the context was canceled is received
+		require.LessOrEqual(t, received, 2)
+	})
+
+	t.Run("storage errors end the stream", func(t *testing.T) {
+		testData := newTestData(t, true)
+		testData.storageMock.EXPECT().GetByStatus(testData.ctx, mock.Anything).Return(nil, ErrStorageUnavailable).Once()
+
+		items := make([]types.MonitoredTxResultOrError, 0, 1)
+		for item := range testData.sut.StreamResultsByStatus(testData.ctx, nil) {
+			items = append(items, item)
+		}
+		require.Len(t, items, 1)
+		require.ErrorIs(t, items[0].Err, ErrStorageUnavailable)
+	})
+}
+
+func TestBlobFeeTooLow(t *testing.T) {
+	testData := newTestData(t, true)
+	from := common.HexToAddress("0x456")
+	to := common.HexToAddress("0x1")
+	mTx := &monitoredTxnIteration{
+		MonitoredTx: &types.MonitoredTx{
+			ID: common.HexToHash("0x123"), From: from, To: &to, Nonce: 1, FixedNonce: true, Value: big.NewInt(1),
+			Gas: 21000, GasPrice: big.NewInt(100), GasTipCap: big.NewInt(1), FixedFees: true,
+			BlobSidecar: &ethtypes.BlobTxSidecar{}, BlobGasPrice: big.NewInt(10),
+			Status: types.MonitoredTxStatusCreated, History: make(map[common.Hash]bool),
+		},
+	}
+	logger := createMonitoredTxLogger(*mTx.MonitoredTx)
+	signedTx := ethtypes.NewTransaction(1, to, big.NewInt(1), 21000, big.NewInt(100), nil)
+
+	testData.ethermanMock.EXPECT().SignTx(testData.ctx, from, mock.Anything).Return(signedTx, nil).Once()
+	testData.ethermanMock.EXPECT().GetTx(testData.ctx, signedTx.Hash()).Return(nil, false, ethereum.NotFound).Once()
+	testData.ethermanMock.EXPECT().SendTxIdempotent(testData.ctx, signedTx).
+		Return(errors.New("max fee per blob gas less than block blob gas fee: address 0x456 blobGasFeeCap: 10")).Once()
+	testData.storageMock.EXPECT().Update(testData.ctx, mock.Anything).Return(nil).Twice()
+
+	testData.sut.monitorTx(testData.ctx, mTx, logger)
+	require.Equal(t, types.MonitoredTxStatusCreated, mTx.Status)
+	_, blobFeeTooLow := testData.sut.blobFeeTooLow.Load(mTx.ID)
+	require.True(t, blobFeeTooLow)
+
+	// the blob base fee of the latest block is above the bumped blob fee cap
+	excessBlobGas := uint64(20_000_000)
+	header := &ethtypes.Header{Number: big.NewInt(10), ExcessBlobGas: &excessBlobGas}
+	blobBaseFee := eip4844.CalcBlobFee(testData.sut.blobChainConfig(), header)
+	require.Equal(t, 1, blobBaseFee.Cmp(big.NewInt(11)))
+	testData.ethermanMock.EXPECT().GetHeaderByNumber(testData.ctx, (*big.Int)(nil)).Return(header, nil).Once()
+
+	require.NoError(t, testData.sut.reviewMonitoredTxGas(testData.ctx, mTx, logger))
+	require.Equal(t, blobBaseFee, mTx.BlobGasPrice)
+	_, blobFeeTooLow = testData.sut.blobFeeTooLow.Load(mTx.ID)
+	require.False(t, blobFeeTooLow)
+
+	// without the blob base fee of the latest block, it's bumped by the replacement percentage
+	testData.sut.blobFeeTooLow.Store(mTx.ID, struct{}{})
+	testData.ethermanMock.EXPECT().GetHeaderByNumber(testData.ctx, (*big.Int)(nil)).
+		Return(&ethtypes.Header{Number: big.NewInt(11)}, nil).Once()
+	require.NoError(t, testData.sut.reviewMonitoredTxGas(testData.ctx, mTx, logger))
+	require.Equal(t, bumpByPercentage(blobBaseFee, testData.sut.replacementBumpPercentage()), mTx.BlobGasPrice)
+}
+
+func TestWaitSafeTxToBeFinalizedCheckpoint(t *testing.T) {
+	testData := newTestData(t, false)
+	testData.sut.cfg.PersistCheckpoint = true
+	testData.sut.cfg.SafeStatusL1NumberOfBlocks = 5
+	testData.sut.cfg.FinalizedStatusL1NumberOfBlocks = 10
+	to := common.HexToAddress("0x1")
+	lastCycleAt := time.Now().Add(-time.Hour)
+
+	newSafeTx := func(id string, blockNumber int64, updatedAt time.Time) types.MonitoredTx {
+		return types.MonitoredTx{
+			ID: common.HexToHash(id), From: common.HexToAddress("0x2"), To: &to, Status: types.MonitoredTxStatusSafe,
+			BlockNumber: big.NewInt(blockNumber), History: make(map[common.Hash]bool),
+			CreatedAt: updatedAt, UpdatedAt: updatedAt,
+		}
+	}
+	// already checked by the cycle of the checkpoint, so it's out of the scan window
+	checkedTx := newSafeTx("0x1", 50, lastCycleAt.Add(-time.Hour))
+	// set as safe after the cycle of the checkpoint
+	lateTx := newSafeTx("0x2", 80, time.Now())
+	// mined after the last finalized block processed
+	newTx := newSafeTx("0x3", 150, lastCycleAt.Add(-time.Hour))
+	for _, mTx := range []types.MonitoredTx{checkedTx, lateTx, newTx} {
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+	}
+	require.NoError(t, testData.sut.storage.SetCheckpoint(testData.ctx,
+		types.Checkpoint{LastCycleAt: lastCycleAt, LastFinalizedBlock: 100}))
+
+	testData.ethermanMock.EXPECT().GetLatestBlockNumber(testData.ctx).Return(uint64(210), nil).Once()
+	require.NoError(t, testData.sut.waitSafeTxToBeFinalized(testData.ctx))
+
+	requireStatus := func(id common.Hash, status types.MonitoredTxStatus) {
+		t.Helper()
+		mTx, err := testData.sut.storage.Get(testData.ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, status, mTx.Status)
+	}
+	requireStatus(checkedTx.ID, types.MonitoredTxStatusSafe)
+	requireStatus(lateTx.ID, types.MonitoredTxStatusFinalized)
+	requireStatus(newTx.ID, types.MonitoredTxStatusFinalized)
+
+	// the checkpoint moves to the finalized block processed by the cycle
+	checkpoint, err := testData.sut.storage.GetCheckpoint(testData.ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(200), checkpoint.LastFinalizedBlock)
+	require.True(t, checkpoint.LastCycleAt.After(lastCycleAt))
+
+	// without checkpoint all the safe txs are checked
+	testData.sut.cfg.PersistCheckpoint = false
+	testData.ethermanMock.EXPECT().GetLatestBlockNumber(testData.ctx).Return(uint64(210), nil).Once()
+	require.NoError(t, testData.sut.waitSafeTxToBeFinalized(testData.ctx))
+	requireStatus(checkedTx.ID, types.MonitoredTxStatusFinalized)
+}
+
+func TestRemoveAfterFinalized(t *testing.T) {
+	testData := newTestData(t, false)
+	testData.sut.cfg.RemoveAfterFinalized = true
+	testData.sut.cfg.SafeStatusL1NumberOfBlocks = 5
+	testData.sut.cfg.FinalizedStatusL1NumberOfBlocks = 10
+	to := common.HexToAddress("0x1")
+
+	newTx := func(id string, status types.MonitoredTxStatus, blockNumber int64) types.MonitoredTx {
+		return types.MonitoredTx{
+			ID: common.HexToHash(id), From: common.HexToAddress("0x2"), To: &to, Status: status,
+			BlockNumber: big.NewInt(blockNumber), History: make(map[common.Hash]bool),
+		}
+	}
+	finalizedTx := newTx("0x1", types.MonitoredTxStatusSafe, 50)
+	safeTx := newTx("0x2", types.MonitoredTxStatusSafe, 95)
+	minedTx := newTx("0x3", types.MonitoredTxStatusMined, 96)
+	markedTx := newTx("0x4", types.MonitoredTxStatusMined, 60)
+	for _, mTx := range []types.MonitoredTx{finalizedTx, safeTx, minedTx, markedTx} {
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+	}
+
+	// the hooks of the finalized status still find the tx in the storage
+	hooked := make([]common.Hash, 0)
+	testData.sut.OnStatus(types.MonitoredTxStatusFinalized, func(ctx context.Context, mTx types.MonitoredTx) {
+		if stored, err := testData.sut.storage.Get(ctx, mTx.ID); err == nil &&
+			stored.Status == types.MonitoredTxStatusFinalized {
+			hooked = append(hooked, mTx.ID)
+		}
+	})
+
+	testData.ethermanMock.EXPECT().GetLatestBlockNumber(testData.ctx).Return(uint64(100), nil).Once()
+	require.NoError(t, testData.sut.waitSafeTxToBeFinalized(testData.ctx))
+	require.Equal(t, []common.Hash{finalizedTx.ID}, hooked)
+
+	_, err := testData.sut.storage.Get(testData.ctx, finalizedTx.ID)
+	require.ErrorIs(t, err, types.ErrNotFound)
+	// the txs not finalized yet are kept
+	for _, id := range []common.Hash{safeTx.ID, minedTx.ID, markedTx.ID} {
+		_, err := testData.sut.storage.Get(testData.ctx, id)
+		require.NoError(t, err)
+	}
+
+	// the txs marked as finalized are removed too
+	testData.ethermanMock.EXPECT().GetLatestBlockNumber(testData.ctx).Return(uint64(100), nil).Once()
+	require.NoError(t, testData.sut.MarkFinalized(testData.ctx, markedTx.ID, 60))
+	require.Equal(t, []common.Hash{finalizedTx.ID, markedTx.ID}, hooked)
+	_, err = testData.sut.storage.Get(testData.ctx, markedTx.ID)
+	require.ErrorIs(t, err, types.ErrNotFound)
+}
+
+func TestPredictedTxHash(t *testing.T) {
+	testData := newTestData(t, false)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	to := common.HexToAddress("0x1")
+	signer := ethtypes.LatestSignerForChainID(big.NewInt(1))
+	testData.ethermanMock.EXPECT().SignTx(testData.ctx, from, mock.Anything).RunAndReturn(
+		func(_ context.Context, _ common.Address, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+			return ethtypes.SignTx(tx, signer, key)
+		})
+
+	newTx := func(id string, fixedNonce bool) types.MonitoredTx {
+		return types.MonitoredTx{
+			ID: common.HexToHash(id), From: from, To: &to, Nonce: 1, FixedNonce: fixedNonce, Value: big.NewInt(1),
+			Gas: 21000, GasPrice: big.NewInt(1), Status: types.MonitoredTxStatusCreated,
+			History: make(map[common.Hash]bool), CreatedAt: time.Now(),
+		}
+	}
+
+	t.Run("nonce not assigned", func(t *testing.T) {
+		mTx := newTx("0x1", false)
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+
+		_, err := testData.sut.PredictedTxHash(testData.ctx, mTx.ID)
+		require.ErrorIs(t, err, ErrNonceNotAssigned)
+	})
+
+	t.Run("predicted hash is the sent hash", func(t *testing.T) {
+		mTx := newTx("0x2", true)
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+
+		predicted, err := testData.sut.PredictedTxHash(testData.ctx, mTx.ID)
+		require.NoError(t, err)
+		require.NotEqual(t, mTx.ID, predicted)
+
+		var sent common.Hash
+		testData.ethermanMock.EXPECT().GetTx(testData.ctx, mock.Anything).Return(nil, false, ethereum.NotFound).Once()
+		testData.ethermanMock.EXPECT().SendTxIdempotent(testData.ctx, mock.Anything).RunAndReturn(
+			func(_ context.Context, tx *ethtypes.Transaction) error {
+				sent = tx.Hash()
+				return nil
+			}).Once()
+		testData.ethermanMock.EXPECT().WaitTxToBeMined(testData.ctx, mock.Anything, mock.Anything).Return(false, nil).Once()
+
+		iteration := &monitoredTxnIteration{MonitoredTx: &mTx}
+		testData.sut.monitorTx(testData.ctx, iteration, createMonitoredTxLogger(mTx))
+		require.Equal(t, predicted, sent)
+
+		stored, err := testData.sut.storage.Get(testData.ctx, mTx.ID)
+		require.NoError(t, err)
+		require.Equal(t, types.MonitoredTxStatusSent, stored.Status)
+		require.NotNil(t, stored.LastTxHash)
+		require.Equal(t, sent, *stored.LastTxHash)
+
+		// once sent, it's the stored hash of the last tx sent without signing it again
+		calls := len(testData.ethermanMock.Calls)
+		predicted, err = testData.sut.PredictedTxHash(testData.ctx, mTx.ID)
+		require.NoError(t, err)
+		require.Equal(t, sent, predicted)
+		require.Len(t, testData.ethermanMock.Calls, calls)
+	})
+}
+
+func TestWaitTxReceipt(t *testing.T) {
+	txHash := common.HexToHash("0x1")
+
+	t.Run("not found keeps polling", func(t *testing.T) {
+		testData := newTestData(t, false)
+		testData.sut.cfg.GetReceiptMaxTime = configTypes.NewDuration(time.Minute)
+		testData.sut.cfg.GetReceiptErrorMaxTime = configTypes.NewDuration(time.Nanosecond)
+		testData.sut.cfg.GetReceiptWaitInterval = configTypes.NewDuration(time.Millisecond)
+
+		receipt := &ethtypes.Receipt{TxHash: txHash, Status: ethtypes.ReceiptStatusSuccessful}
+		testData.ethermanMock.EXPECT().GetTxReceipt(testData.ctx, txHash).Return(nil, ethereum.NotFound).Times(3)
+		testData.ethermanMock.EXPECT().GetTxReceipt(testData.ctx, txHash).Return(receipt, nil).Once()
+
+		received, err := testData.sut.waitTxReceipt(testData.ctx, txHash)
+		require.NoError(t, err)
+		require.Equal(t, receipt, received)
+	})
+
+	t.Run("node errors bail before the receipt timeout", func(t *testing.T) {
+		testData := newTestData(t, false)
+		testData.sut.cfg.GetReceiptMaxTime = configTypes.NewDuration(time.Minute)
+		testData.sut.cfg.GetReceiptErrorMaxTime = configTypes.NewDuration(20 * time.Millisecond)
+		testData.sut.cfg.GetReceiptWaitInterval = configTypes.NewDuration(time.Millisecond)
+
+		rpcErr := errors.New("connection refused")
+		testData.ethermanMock.EXPECT().GetTxReceipt(testData.ctx, txHash).Return(nil, rpcErr)
+
+		startedAt := time.Now()
+		_, err := testData.sut.waitTxReceipt(testData.ctx, txHash)
+		require.ErrorIs(t, err, rpcErr)
+		require.Less(t, time.Since(startedAt), time.Minute)
+	})
+
+	t.Run("not found gives up after the receipt timeout", func(t *testing.T) {
+		testData := newTestData(t, false)
+		testData.sut.cfg.GetReceiptMaxTime = configTypes.NewDuration(20 * time.Millisecond)
+		testData.sut.cfg.GetReceiptWaitInterval = configTypes.NewDuration(time.Millisecond)
+
+		testData.ethermanMock.EXPECT().GetTxReceipt(testData.ctx, txHash).Return(nil, ethereum.NotFound)
+
+		_, err := testData.sut.waitTxReceipt(testData.ctx, txHash)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestAllowedDestinations(t *testing.T) {
+	testData := newTestData(t, false)
+	testData.sut.cfg.GasPriceMarginFactor = 1
+	testData.sut.from = common.HexToAddress("0x456")
+	allowed := common.HexToAddress("0x1")
+	notAllowed := common.HexToAddress("0x2")
+	testData.sut.cfg.AllowedDestinations = []common.Address{allowed}
+
+	// the rejected txs don't reach the node
+	_, err := testData.sut.AddWithGas(testData.ctx, &notAllowed, big.NewInt(1), []byte{}, 0, nil, 21000)
+	require.ErrorIs(t, err, ErrDestinationNotAllowed)
+	_, err = testData.sut.AddWithGas(testData.ctx, nil, big.NewInt(1), []byte{}, 0, nil, 21000)
+	require.ErrorIs(t, err, ErrDestinationNotAllowed)
+
+	testData.ethermanMock.EXPECT().SuggestedGasPrice(testData.ctx).Return(big.NewInt(1), nil)
+
+	_, err = testData.sut.AddWithGas(testData.ctx, &allowed, big.NewInt(1), []byte{}, 0, nil, 21000)
+	require.NoError(t, err)
+
+	testData.sut.cfg.AllowContractCreation = true
+	_, err = testData.sut.AddWithGas(testData.ctx, nil, big.NewInt(1), []byte{}, 0, nil, 21000)
+	require.NoError(t, err)
+
+	// an empty list allows any destination
+	testData.sut.cfg.AllowedDestinations = nil
+	_, err = testData.sut.AddWithGas(testData.ctx, &notAllowed, big.NewInt(1), []byte{}, 0, nil, 21000)
+	require.NoError(t, err)
+}
+
+func TestStatusTimestamps(t *testing.T) {
+	testData := newTestData(t, false)
+	testData.sut.cfg.SafeStatusL1NumberOfBlocks = 5
+	testData.sut.cfg.FinalizedStatusL1NumberOfBlocks = 5
+	from := common.HexToAddress("0x456")
+	to := common.HexToAddress("0x1")
+	mTx := types.MonitoredTx{
+		ID: common.HexToHash("0x123"), From: from, To: &to, Nonce: 1, FixedNonce: true, Value: big.NewInt(1),
+		Gas: 21000, GasPrice: big.NewInt(1), Status: types.MonitoredTxStatusCreated,
+		History: make(map[common.Hash]bool), CreatedAt: time.Now().Add(-time.Hour),
+	}
+	require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+	signedTx := ethtypes.NewTransaction(1, to, big.NewInt(1), 21000, big.NewInt(1), nil)
+	receipt := &ethtypes.Receipt{
+		Status: ethtypes.ReceiptStatusSuccessful, TxHash: signedTx.Hash(), BlockNumber: big.NewInt(10),
+	}
+
+	testData.ethermanMock.EXPECT().SignTx(testData.ctx, from, mock.Anything).Return(signedTx, nil).Once()
+	testData.ethermanMock.EXPECT().GetTx(testData.ctx, signedTx.Hash()).Return(nil, false, ethereum.NotFound).Once()
+	testData.ethermanMock.EXPECT().SendTxIdempotent(testData.ctx, signedTx).Return(nil).Once()
+	testData.ethermanMock.EXPECT().WaitTxToBeMined(testData.ctx, signedTx, mock.Anything).Return(true, nil).Once()
+	testData.ethermanMock.EXPECT().GetTxReceipt(testData.ctx, signedTx.Hash()).Return(receipt, nil).Once()
+
+	// the tx is sent and mined in the same cycle
+	iterations, err := testData.sut.getMonitoredTxnIteration(testData.ctx)
+	require.NoError(t, err)
+	require.Len(t, iterations, 1)
+	testData.sut.monitorTx(testData.ctx, iterations[0], createMonitoredTxLogger(*iterations[0].MonitoredTx))
+
+	stored, err := testData.sut.storage.Get(testData.ctx, mTx.ID)
+	require.NoError(t, err)
+	require.Equal(t, types.MonitoredTxStatusMined, stored.Status)
+	require.False(t, stored.SentAt.IsZero())
+	require.False(t, stored.MinedAt.IsZero())
+	require.False(t, stored.MinedAt.Before(stored.SentAt))
+	require.True(t, stored.FinalizedAt.IsZero())
+	timeToMine, ok := stored.TimeToMine()
+	require.True(t, ok)
+	require.GreaterOrEqual(t, timeToMine, time.Hour-time.Second)
+	_, ok = stored.TimeToFinalize()
+	require.False(t, ok)
+
+	// becoming safe doesn't change them, being finalized stamps it
+	testData.ethermanMock.EXPECT().GetLatestBlockNumber(testData.ctx).Return(uint64(100), nil).Twice()
+	require.NoError(t, testData.sut.waitMinedTxToBeSafe(testData.ctx))
+	require.NoError(t, testData.sut.waitSafeTxToBeFinalized(testData.ctx))
+
+	finalized, err := testData.sut.storage.Get(testData.ctx, mTx.ID)
+	require.NoError(t, err)
+	require.Equal(t, types.MonitoredTxStatusFinalized, finalized.Status)
+	require.Equal(t, stored.SentAt, finalized.SentAt)
+	require.Equal(t, stored.MinedAt, finalized.MinedAt)
+	require.False(t, finalized.FinalizedAt.Before(finalized.MinedAt))
+
+	// the result gets the history from the network
+	testData.ethermanMock.EXPECT().GetTx(testData.ctx, signedTx.Hash()).Return(signedTx, false, nil).Once()
+	testData.ethermanMock.EXPECT().GetTxReceipt(testData.ctx, signedTx.Hash()).Return(receipt, nil).Once()
+	testData.ethermanMock.EXPECT().GetRevertMessage(testData.ctx, signedTx).Return("", nil).Once()
+	result, err := testData.sut.Result(testData.ctx, mTx.ID)
+	require.NoError(t, err)
+	require.Equal(t, finalized.SentAt, result.SentAt)
+	require.Equal(t, finalized.MinedAt, result.MinedAt)
+	require.Equal(t, finalized.FinalizedAt, result.FinalizedAt)
+}
+
+func TestLatencies(t *testing.T) {
+	testData := newTestData(t, false)
+	testData.sut.cfg.LatencyBuckets = []configTypes.Duration{
+		configTypes.NewDuration(time.Minute), configTypes.NewDuration(10 * time.Minute),
+	}
+	to := common.HexToAddress("0x1")
+	createdAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	addTx := func(id int64, status types.MonitoredTxStatus, minedAfter, finalizedAfter time.Duration) {
+		t.Helper()
+		mTx := types.MonitoredTx{
+			ID: common.BigToHash(big.NewInt(id)), From: common.HexToAddress("0x456"), To: &to,
+			Status: status, History: make(map[common.Hash]bool), CreatedAt: createdAt,
+		}
+		if minedAfter > 0 {
+			mTx.MinedAt = createdAt.Add(minedAfter)
+		}
+		if finalizedAfter > 0 {
+			mTx.FinalizedAt = createdAt.Add(finalizedAfter)
+		}
+		require.NoError(t, testData.sut.storage.Add(testData.ctx, mTx))
+	}
+	addTx(1, types.MonitoredTxStatusFinalized, 30*time.Second, 15*time.Minute)
+	addTx(2, types.MonitoredTxStatusFinalized, 2*time.Minute, 20*time.Minute)
+	addTx(3, types.MonitoredTxStatusMined, 5*time.Minute, 0)
+	// neither the txs not mined yet nor the ones mined before the timestamps were recorded are counted
+	addTx(4, types.MonitoredTxStatusSent, 0, 0)
+	addTx(5, types.MonitoredTxStatusFinalized, 0, 0)
+
+	latencies, err := testData.sut.Latencies(testData.ctx, types.MonitoredTxFilter{})
+	require.NoError(t, err)
+
+	timeToMine := latencies.TimeToMine
+	require.Equal(t, []time.Duration{time.Minute, 10 * time.Minute}, timeToMine.Buckets)
+	require.Equal(t, []uint64{1, 2, 0}, timeToMine.Counts)
+	require.Equal(t, uint64(3), timeToMine.Count)
+	require.Equal(t, 30*time.Second, timeToMine.Min)
+	require.Equal(t, 5*time.Minute, timeToMine.Max)
+	require.Equal(t, (30*time.Second+7*time.Minute)/3, timeToMine.Mean())
+
+	timeToFinalize := latencies.TimeToFinalize
+	require.Equal(t, []uint64{0, 0, 2}, timeToFinalize.Counts)
+	require.Equal(t, 35*time.Minute, timeToFinalize.Sum)
+
+	// the filter narrows the txs of the distributions
+	latencies, err = testData.sut.Latencies(testData.ctx, types.MonitoredTxFilter{
+		Statuses: []types.MonitoredTxStatus{types.MonitoredTxStatusMined},
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), latencies.TimeToMine.Count)
+	require.Equal(t, uint64(0), latencies.TimeToFinalize.Count)
+	require.Equal(t, time.Duration(0), latencies.TimeToFinalize.Mean())
+
+	// the default buckets can't be changed through the returned histograms
+	testData.sut.cfg.LatencyBuckets = nil
+	latencies, err = testData.sut.Latencies(testData.ctx, types.MonitoredTxFilter{})
+	require.NoError(t, err)
+	require.Equal(t, defaultLatencyBuckets, latencies.TimeToMine.Buckets)
+	latencies.TimeToMine.Buckets[0] = 0
+	require.Equal(t, 15*time.Second, defaultLatencyBuckets[0])
+	require.Equal(t, 15*time.Second, latencies.TimeToFinalize.Buckets[0])
+}
@@ -0,0 +1,123 @@
+package ethtxmanager
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/0xPolygon/zkevm-ethtx-manager/types"
+)
+
+// defaultLatencyBuckets are the upper bounds of the latency histograms when LatencyBuckets is not configured,
+// they cover from a tx mined in the next block to a tx finalized after several epochs
+var defaultLatencyBuckets = []time.Duration{
+	15 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	2 * time.Minute,
+	5 * time.Minute,
+	10 * time.Minute,
+	15 * time.Minute,
+	20 * time.Minute,
+	30 * time.Minute,
+	time.Hour,
+}
+
+// LatencyHistogram is the distribution of the durations of the monitored txs to reach a status
+type LatencyHistogram struct {
+	// Buckets are the upper bounds (inclusive) of the buckets, ascending
+	Buckets []time.Duration
+
+	// Counts are the number of durations of each bucket, it has an extra last count with the durations
+	// above the last bucket
+	Counts []uint64
+
+	// Count is the number of durations of the histogram
+	Count uint64
+
+	// Sum, Min and Max are the total, the shortest and the longest of the durations
+	Sum time.Duration
+	Min time.Duration
+	Max time.Duration
+}
+
+// newLatencyHistogram returns an empty histogram with a copy of the given buckets, so the caller can't
+// change the buckets of the other histograms
+func newLatencyHistogram(buckets []time.Duration) LatencyHistogram {
+	return LatencyHistogram{
+		Buckets: slices.Clone(buckets),
+		Counts:  make([]uint64, len(buckets)+1),
+	}
+}
+
+// observe adds a duration to the histogram
+func (h *LatencyHistogram) observe(d time.Duration) {
+	i := 0
+	for i < len(h.Buckets) && d > h.Buckets[i] {
+		i++
+	}
+	h.Counts[i]++
+
+	if h.Count == 0 || d < h.Min {
+		h.Min = d
+	}
+	if h.Count == 0 || d > h.Max {
+		h.Max = d
+	}
+	h.Count++
+	h.Sum += d
+}
+
+// Mean returns the average of the durations of the histogram, 0 if it's empty
+func (h LatencyHistogram) Mean() time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	return h.Sum / time.Duration(h.Count)
+}
+
+// Latencies are the distributions of the time the monitored txs took from their creation to be mined
+// and to be finalized
+type Latencies struct {
+	TimeToMine     LatencyHistogram
+	TimeToFinalize LatencyHistogram
+}
+
+// Latencies computes the distributions of the time to be mined and to be finalized of the monitored txs
+// matching the filter from their persisted timestamps. The txs that didn't reach a status yet, or reached it
+// before the timestamps were recorded, are not part of its distribution
+func (c *Client) Latencies(ctx context.Context, filter types.MonitoredTxFilter) (Latencies, error) {
+	mTxs, err := c.storage.Query(ctx, filter)
+	if err != nil {
+		return Latencies{}, fmt.Errorf("failed to query monitored txs: %w", translateError(err))
+	}
+
+	buckets := c.latencyBuckets()
+	latencies := Latencies{
+		TimeToMine:     newLatencyHistogram(buckets),
+		TimeToFinalize: newLatencyHistogram(buckets),
+	}
+	for _, mTx := range mTxs {
+		if d, ok := mTx.TimeToMine(); ok {
+			latencies.TimeToMine.observe(d)
+		}
+		if d, ok := mTx.TimeToFinalize(); ok {
+			latencies.TimeToFinalize.observe(d)
+		}
+	}
+	return latencies, nil
+}
+
+// latencyBuckets returns the configured buckets of the latency histograms or the default ones
+func (c *Client) latencyBuckets() []time.Duration {
+	if len(c.cfg.LatencyBuckets) == 0 {
+		return defaultLatencyBuckets
+	}
+
+	buckets := make([]time.Duration, 0, len(c.cfg.LatencyBuckets))
+	for _, bucket := range c.cfg.LatencyBuckets {
+		buckets = append(buckets, bucket.Duration)
+	}
+	return buckets
+}
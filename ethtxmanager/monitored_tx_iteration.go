@@ -11,13 +11,21 @@ type monitoredTxnIteration struct {
 	*types.MonitoredTx
 	confirmed   bool
 	lastReceipt *ethtypes.Receipt
+	// failedReceipts is the number of txs of the history mined with a failed receipt, counted
+	// when the history is checked by shouldUpdateNonce so it's not requested again
+	failedReceipts int
+	// inCycle is set for the monitored txs processed by a monitoring cycle, only their broadcasts count
+	// towards the circuit breaker
+	inCycle bool
+	// broadcastBlocked is set while the circuit breaker is open, so the monitored tx is not sent
+	broadcastBlocked bool
 }
 
 func (m *monitoredTxnIteration) shouldUpdateNonce(ctx context.Context, etherman types.EthermanInterface) bool {
 	if m.Status == types.MonitoredTxStatusCreated {
 		// transaction was not sent, so no need to check if it was mined
-		// we need to update the nonce in this case
-		return true
+		// we need to update the nonce in this case, unless it was provided by the caller
+		return !m.FixedNonce
 	}
 
 	// check if any of the txs in the history was confirmed
@@ -27,6 +35,7 @@ func (m *monitoredTxnIteration) shouldUpdateNonce(ctx context.Context, etherman
 	// monitored tx doesn't have a failed receipt until we find a failed receipt for any
 	// tx in the monitored tx history
 	hasFailedReceipts := false
+	failedReceipts := 0
 	// all history txs are considered mined until we can't find a receipt for any
 	// tx in the monitored tx history
 	allHistoryTxsWereMined := true
@@ -37,28 +46,32 @@ func (m *monitoredTxnIteration) shouldUpdateNonce(ctx context.Context, etherman
 		}
 
 		// if the tx is not mined yet, check that not all the tx were mined and go to the next
-		if !mined {
+		if !mined || receipt == nil {
 			allHistoryTxsWereMined = false
 			continue
 		}
 
-		lastReceiptChecked = receipt
+		// more than one tx of the history can be mined after a replacement race, the
+		// canonical receipt is selected deterministically regardless of the history order
+		if isCanonicalReceipt(receipt, lastReceiptChecked) {
+			lastReceiptChecked = receipt
+		}
 
-		// if the tx was mined successfully we can set it as confirmed and break the loop
-		if lastReceiptChecked.Status == ethtypes.ReceiptStatusSuccessful {
+		// if the tx was mined successfully we can set it as confirmed
+		if receipt.Status == ethtypes.ReceiptStatusSuccessful {
 			confirmed = true
-			break
+			continue
 		}
 
-		// if the tx was mined but failed, we continue to consider it was not confirmed
-		// and set that we have found a failed receipt. This info will be used later
-		// to check if nonce needs to be reviewed
-		confirmed = false
+		// if the tx was mined but failed, we set that we have found a failed receipt.
+		// This info will be used later to check if nonce needs to be reviewed
 		hasFailedReceipts = true
+		failedReceipts++
 	}
 
 	m.confirmed = confirmed
 	m.lastReceipt = lastReceiptChecked
+	m.failedReceipts = failedReceipts
 
 	// we need to check if we need to review the nonce carefully, to avoid sending
 	// duplicated data to the roll-up and causing an unnecessary trusted state reorg.
@@ -71,5 +84,42 @@ func (m *monitoredTxnIteration) shouldUpdateNonce(ctx context.Context, etherman
 	//
 	// in case of the monitored tx is not confirmed yet, all tx were mined and none of them were
 	// mined successfully, we need to review the nonce
-	return !confirmed && hasFailedReceipts && allHistoryTxsWereMined
+	//
+	// the nonces provided by the caller are never reviewed, neither the ones of the no replace txs
+	// because sending them with a new nonce would be a replacement
+	return !m.FixedNonce && !m.NoReplace && !confirmed && hasFailedReceipts && allHistoryTxsWereMined
+}
+
+// ranOutOfGas checks if the last receipt found for the monitored tx history
+// failed consuming all the gas provided to the tx
+func (m *monitoredTxnIteration) ranOutOfGas() bool {
+	return m.lastReceipt != nil &&
+		m.lastReceipt.Status == ethtypes.ReceiptStatusFailed &&
+		m.lastReceipt.GasUsed >= m.GasLimit()
+}
+
+// isCanonicalReceipt reports whether the candidate receipt must be used instead of the current one
+// as the authoritative receipt of a monitored tx with several mined txs in its history.
+// Successful receipts are preferred over failed ones, then the receipt mined at the highest block,
+// and finally the highest tx hash breaks the ties so the selection doesn't depend on the history order
+func isCanonicalReceipt(candidate, current *ethtypes.Receipt) bool {
+	if current == nil {
+		return true
+	}
+
+	candidateSuccessful := candidate.Status == ethtypes.ReceiptStatusSuccessful
+	currentSuccessful := current.Status == ethtypes.ReceiptStatusSuccessful
+	if candidateSuccessful != currentSuccessful {
+		return candidateSuccessful
+	}
+
+	if candidate.BlockNumber != nil && current.BlockNumber != nil {
+		if cmp := candidate.BlockNumber.Cmp(current.BlockNumber); cmp != 0 {
+			return cmp == 1
+		}
+	} else if candidate.BlockNumber != nil || current.BlockNumber != nil {
+		return candidate.BlockNumber != nil
+	}
+
+	return candidate.TxHash.Cmp(current.TxHash) == 1
 }
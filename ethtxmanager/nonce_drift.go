@@ -0,0 +1,58 @@
+package ethtxmanager
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// NonceDrift is the difference found by the last nonce reconciliation of a sender between the txs
+// pending in the network and the sent monitored txs
+type NonceDrift struct {
+	Sender common.Address
+
+	// ConfirmedNonce and PendingNonce are the latest and the pending nonces of the sender in the network
+	ConfirmedNonce uint64
+	PendingNonce   uint64
+
+	// SentTxs is the number of sent monitored txs of the sender
+	SentTxs uint64
+
+	// Drift is the number of txs pending in the network minus the sent monitored txs, negative when
+	// the network lost sent txs and positive when the sender has txs not sent by this client
+	Drift int64
+
+	// ReconciledAt is the time of the reconciliation
+	ReconciledAt time.Time
+}
+
+// newNonceDrift returns the drift of a sender from its nonces in the network and its sent monitored txs
+func newNonceDrift(sender common.Address, confirmedNonce, pendingNonce, sentTxs uint64) NonceDrift {
+	drift := NonceDrift{
+		Sender:         sender,
+		ConfirmedNonce: confirmedNonce,
+		PendingNonce:   pendingNonce,
+		SentTxs:        sentTxs,
+		ReconciledAt:   time.Now(),
+	}
+	if pendingNonce >= confirmedNonce {
+		drift.Drift = int64(pendingNonce-confirmedNonce) - int64(sentTxs) //nolint:gosec
+	}
+	return drift
+}
+
+// NonceDrifts returns the nonce drift of each sender found by the last nonce reconciliation, ordered by sender.
+// The drifts are kept in memory, so they are empty until the first reconciliation after a restart
+func (c *Client) NonceDrifts() []NonceDrift {
+	drifts := make([]NonceDrift, 0)
+	c.nonceDrifts.Range(func(_, value any) bool {
+		drifts = append(drifts, value.(NonceDrift)) //nolint:forcetypeassert
+		return true
+	})
+	sort.Slice(drifts, func(i, j int) bool {
+		return bytes.Compare(drifts[i].Sender.Bytes(), drifts[j].Sender.Bytes()) < 0
+	})
+	return drifts
+}
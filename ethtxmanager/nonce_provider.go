@@ -0,0 +1,44 @@
+package ethtxmanager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0xPolygon/zkevm-ethtx-manager/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ types.NonceProvider = (*NetworkNonceProvider)(nil)
+
+// NetworkNonceProvider provides the nonces of the senders from the network, using the nonce
+// given by the NonceSource
+type NetworkNonceProvider struct {
+	etherman types.EthermanInterface
+	source   NonceSource
+}
+
+// NewNetworkNonceProvider creates a nonce provider getting the nonces from the network through the provided etherman
+func NewNetworkNonceProvider(etherman types.EthermanInterface, source NonceSource) *NetworkNonceProvider {
+	return &NetworkNonceProvider{etherman: etherman, source: source}
+}
+
+// Nonce returns the pending or the latest nonce of the sender according to the NonceSource,
+// the pending one if the source is not set
+func (p *NetworkNonceProvider) Nonce(ctx context.Context, sender common.Address) (uint64, error) {
+	switch p.source {
+	case "", NonceSourcePending:
+		nonce, err := p.etherman.PendingNonce(ctx, sender)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get pending nonce for sender: %s. Error: %w", sender, err)
+		}
+		return nonce, nil
+	case NonceSourceLatest:
+		nonce, err := p.etherman.CurrentNonce(ctx, sender)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get latest nonce for sender: %s. Error: %w", sender, err)
+		}
+		return nonce, nil
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrUnknownNonceSource, p.source)
+	}
+}
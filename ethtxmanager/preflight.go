@@ -0,0 +1,83 @@
+package ethtxmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/zkevm-ethtx-manager/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// preflightTxID is the id of the monitored tx written to check the storage is writable
+	preflightTxID = crypto.Keccak256Hash([]byte("ethtxmanager-preflight"))
+	// errPreflightRollback discards the monitored tx written to check the storage is writable
+	errPreflightRollback = errors.New("preflight rollback")
+)
+
+// Preflight checks the configuration works end to end without sending any tx: the node is reachable,
+// its chain ID matches the configured one, the signers are loaded and the storage is writable.
+// All the checks are run, the returned error joins the ones that failed
+func (c *Client) Preflight(ctx context.Context) error {
+	return errors.Join(
+		c.preflightNode(ctx),
+		c.preflightSigners(),
+		c.preflightStorage(ctx),
+	)
+}
+
+// preflightNode checks the node is reachable and it's connected to the configured chain
+func (c *Client) preflightNode(ctx context.Context) error {
+	if _, err := c.etherman.GetLatestBlockNumber(ctx); err != nil {
+		return fmt.Errorf("node check failed: %w", translateError(err))
+	}
+
+	chainID, err := c.etherman.GetChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("chain ID check failed: %w", translateError(err))
+	}
+	if c.cfg.Etherman.L1ChainID != 0 && chainID != c.cfg.Etherman.L1ChainID {
+		return fmt.Errorf("chain ID check failed: the node chain ID is %d, expected %d",
+			chainID, c.cfg.Etherman.L1ChainID)
+	}
+
+	return nil
+}
+
+// preflightSigners checks there are signers loaded to sign the txs
+func (c *Client) preflightSigners() error {
+	addresses, err := c.etherman.PublicAddress()
+	if err != nil {
+		return fmt.Errorf("signers check failed: %w", err)
+	}
+	if len(addresses) == 0 {
+		return errors.New("signers check failed: no signer loaded")
+	}
+
+	return nil
+}
+
+// preflightStorage checks the storage is writable adding a monitored tx inside a storage transaction
+// that is rolled back, so nothing is left behind
+func (c *Client) preflightStorage(ctx context.Context) error {
+	err := c.storage.WithTx(ctx, func(storage types.StorageInterface) error {
+		mTx := types.MonitoredTx{
+			ID:      preflightTxID,
+			From:    c.from,
+			To:      &c.from,
+			Status:  types.MonitoredTxStatusCreated,
+			History: make(map[common.Hash]bool),
+		}
+		if err := storage.Add(ctx, mTx); err != nil {
+			return err
+		}
+		return errPreflightRollback
+	})
+	if err != nil && !errors.Is(err, errPreflightRollback) {
+		return fmt.Errorf("storage check failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package ethtxmanager
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/zkevm-ethtx-manager/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// cachedResult is a result built for a monitored tx with the state it had when it was built
+type cachedResult struct {
+	result        types.MonitoredTxResult
+	status        types.MonitoredTxStatus
+	blockNumber   *big.Int
+	historyLen    int
+	feeHistoryLen int
+	expiresAt     time.Time
+}
+
+// resultCache keeps the results built for the monitored txs for a while, so the repeated requests
+// of the same result don't hit the network. A cached result is discarded as soon as the status, the
+// block number (e.g. after a reorg), the history or the fee history of its monitored tx change
+type resultCache struct {
+	mu      sync.Mutex
+	entries map[common.Hash]cachedResult
+
+	// now returns the current time, it's replaced by the tests
+	now func() time.Time
+}
+
+func (rc *resultCache) currentTime() time.Time {
+	if rc.now != nil {
+		return rc.now()
+	}
+	return time.Now()
+}
+
+// get returns the cached result of the monitored tx if it didn't expire and the monitored tx didn't change
+func (rc *resultCache) get(mTx types.MonitoredTx) (types.MonitoredTxResult, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	entry, found := rc.entries[mTx.ID]
+	if !found {
+		return types.MonitoredTxResult{}, false
+	}
+	if entry.status != mTx.Status || !sameBlockNumber(entry.blockNumber, mTx.BlockNumber) ||
+		entry.historyLen != len(mTx.History) ||
+		entry.feeHistoryLen != len(mTx.FeeHistory) || !rc.currentTime().Before(entry.expiresAt) {
+		delete(rc.entries, mTx.ID)
+		return types.MonitoredTxResult{}, false
+	}
+	return entry.result, true
+}
+
+// set caches the result of the monitored tx for the provided ttl, the expired results are purged
+func (rc *resultCache) set(mTx types.MonitoredTx, result types.MonitoredTxResult, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	now := rc.currentTime()
+	if rc.entries == nil {
+		rc.entries = make(map[common.Hash]cachedResult)
+	}
+	for id, entry := range rc.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(rc.entries, id)
+		}
+	}
+	rc.entries[mTx.ID] = cachedResult{
+		result:        result,
+		status:        mTx.Status,
+		blockNumber:   copyBlockNumber(mTx.BlockNumber),
+		historyLen:    len(mTx.History),
+		feeHistoryLen: len(mTx.FeeHistory),
+		expiresAt:     now.Add(ttl),
+	}
+}
+
+// sameBlockNumber tells whether both block numbers are unknown or equal
+func sameBlockNumber(a, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
+
+// copyBlockNumber returns a copy of the block number, so the cached entry is not changed with the monitored tx
+func copyBlockNumber(blockNumber *big.Int) *big.Int {
+	if blockNumber == nil {
+		return nil
+	}
+	return new(big.Int).Set(blockNumber)
+}
+
+// resultCacheTTL returns the time the result of a monitored tx with the provided status is cached
+func (c *Client) resultCacheTTL(status types.MonitoredTxStatus) time.Duration {
+	if status.IsTerminal() && c.cfg.ResultCacheTerminalTTL.Duration > 0 {
+		return c.cfg.ResultCacheTerminalTTL.Duration
+	}
+	return c.cfg.ResultCacheTTL.Duration
+}
@@ -0,0 +1,110 @@
+package ethtxmanager
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/zkevm-ethtx-manager/log"
+)
+
+const (
+	// defaultRevertReasonsWindow is the time the failures are aggregated by revert reason when
+	// RevertReasonsWindow is not configured
+	defaultRevertReasonsWindow = time.Hour
+
+	// unknownRevertReason aggregates the failures whose revert reason couldn't be retrieved
+	unknownRevertReason = "unknown"
+)
+
+// RevertReasonCount is the number of monitored txs that recently failed with the same revert reason
+type RevertReasonCount struct {
+	Reason   string
+	Count    uint64
+	LastSeen time.Time
+}
+
+// revertFailure is a monitored tx that failed with a revert reason
+type revertFailure struct {
+	reason string
+	at     time.Time
+}
+
+// revertReasons aggregates the revert reasons of the monitored txs that failed recently, so a
+// deterministic failure affecting many txs (e.g. a paused contract) is reported as a single signal
+type revertReasons struct {
+	mu       sync.Mutex
+	failures []revertFailure
+
+	// now returns the current time, it's replaced by the tests
+	now func() time.Time
+}
+
+func (r *revertReasons) currentTime() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
+
+// record records a failure with the given revert reason, alerting when the failures with the
+// same reason inside the window reach the threshold. 0 threshold means no alert
+func (r *revertReasons) record(reason string, window time.Duration, threshold uint64) {
+	if reason == "" {
+		reason = unknownRevertReason
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.currentTime()
+	r.prune(now, window)
+	r.failures = append(r.failures, revertFailure{reason: reason, at: now})
+
+	if threshold == 0 {
+		return
+	}
+	var count uint64
+	for _, failure := range r.failures {
+		if failure.reason == reason {
+			count++
+		}
+	}
+	if count == threshold {
+		log.Errorf("REPEATED REVERTS: %d monitored txs failed with the revert reason %q in the last %v",
+			count, reason, window)
+	}
+}
+
+// counts returns the number of failures by revert reason inside the window, the most repeated first
+func (r *revertReasons) counts(window time.Duration) []RevertReasonCount {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prune(r.currentTime(), window)
+
+	byReason := make(map[string]int)
+	result := make([]RevertReasonCount, 0)
+	for _, failure := range r.failures {
+		i, found := byReason[failure.reason]
+		if !found {
+			i = len(result)
+			byReason[failure.reason] = i
+			result = append(result, RevertReasonCount{Reason: failure.reason})
+		}
+		result[i].Count++
+		result[i].LastSeen = failure.at
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Count > result[j].Count
+	})
+	return result
+}
+
+// prune drops the failures out of the window, the failures are kept in the order they were recorded
+func (r *revertReasons) prune(now time.Time, window time.Duration) {
+	i := 0
+	for i < len(r.failures) && now.Sub(r.failures[i].at) > window {
+		i++
+	}
+	r.failures = r.failures[i:]
+}
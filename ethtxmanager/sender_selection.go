@@ -0,0 +1,63 @@
+package ethtxmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/zkevm-ethtx-manager/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrUnknownSenderSelection when the configured SenderSelection is not supported
+var ErrUnknownSenderSelection = errors.New("unknown sender selection")
+
+// selectSender picks the sender of a new monitored tx among the configured signers
+// according to the SenderSelection configuration
+func (c *Client) selectSender(ctx context.Context) (common.Address, error) {
+	if c.cfg.SenderSelection == "" || c.cfg.SenderSelection == SenderSelectionFixed {
+		return c.from, nil
+	}
+
+	senders, err := c.etherman.PublicAddress()
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get the public addresses of the signers: %w", err)
+	}
+	if len(senders) == 0 {
+		return c.from, nil
+	}
+
+	switch c.cfg.SenderSelection {
+	case SenderSelectionRoundRobin:
+		next := c.nextSender.Add(1) - 1
+		return senders[next%uint64(len(senders))], nil
+	case SenderSelectionLeastInFlight:
+		return c.leastInFlightSender(ctx, senders)
+	default:
+		return common.Address{}, fmt.Errorf("%w: %s", ErrUnknownSenderSelection, c.cfg.SenderSelection)
+	}
+}
+
+// leastInFlightSender returns the sender with fewer created or sent monitored txs,
+// the first one in the signers order wins the ties
+func (c *Client) leastInFlightSender(ctx context.Context, senders []common.Address) (common.Address, error) {
+	mTxs, err := c.storage.GetByStatus(ctx,
+		[]types.MonitoredTxStatus{types.MonitoredTxStatusCreated, types.MonitoredTxStatusSent})
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get the in-flight monitored txs: %w", translateError(err))
+	}
+
+	inFlight := make(map[common.Address]int, len(senders))
+	for _, mTx := range mTxs {
+		inFlight[mTx.From]++
+	}
+
+	selected := senders[0]
+	for _, sender := range senders[1:] {
+		if inFlight[sender] < inFlight[selected] {
+			selected = sender
+		}
+	}
+
+	return selected, nil
+}
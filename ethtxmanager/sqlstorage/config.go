@@ -0,0 +1,34 @@
+package sqlstorage
+
+import "github.com/0xPolygon/zkevm-ethtx-manager/config/types"
+
+// Config represents the configuration of the SQL storage
+type Config struct {
+	// BusyTimeout is the time a connection waits for a lock held by another connection before
+	// failing with "database is locked", 0 means the default of the driver (5s)
+	BusyTimeout types.Duration `mapstructure:"BusyTimeout"`
+
+	// MaxOpenConns is the maximum number of open connections to the database, 0 means no limit.
+	// SQLite allows a single writer, so 1 serializes the writes instead of making them wait for the lock
+	MaxOpenConns int `mapstructure:"MaxOpenConns"`
+
+	// MaxIdleConns is the maximum number of idle connections kept in the pool, 0 means the default of 2
+	MaxIdleConns int `mapstructure:"MaxIdleConns"`
+
+	// RepairMigrations re-syncs the schema when a previous migration was partially applied
+	// (e.g. the process was killed mid-migration) instead of failing, see RepairMigrations
+	RepairMigrations bool `mapstructure:"RepairMigrations"`
+
+	// TableName is the name of the table persisting the monitored txs, empty means monitored_txs.
+	// Several tx managers can share a database using different table names, each table gets its own
+	// indexes, triggers and migration records (<TableName>_migrations)
+	TableName string `mapstructure:"TableName"`
+
+	// ReadReplicas are the DSNs of the read replicas of the database, the database path being the primary.
+	// Only the reads tolerating the replication lag are spread among them, the ones done through the storage
+	// returned by ReadReplica (the tx manager uses it to build the results of the monitored txs), while the
+	// monitoring, the writes and the reads followed by a write keep going to the primary. The replicas are not
+	// migrated, they must get the schema and the data replicated from the primary. Empty means all the queries
+	// go to the primary
+	ReadReplicas []string `mapstructure:"ReadReplicas"`
+}
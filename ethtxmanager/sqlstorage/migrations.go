@@ -1,8 +1,16 @@
 package sqlstorage
 
 import (
+	"bytes"
 	"database/sql"
 	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/0xPolygon/zkevm-ethtx-manager/log"
 	migrate "github.com/rubenv/sql-migrate"
@@ -11,18 +19,198 @@ import (
 //go:embed migrations/*
 var dbMigrations embed.FS
 
-// RunMigrations applies database migrations in the specified direction (up or down).
-func RunMigrations(driverName string, db *sql.DB, direction migrate.MigrationDirection) error {
-	migrations := migrate.EmbedFileSystemMigrationSource{
-		FileSystem: dbMigrations,
-		Root:       "migrations",
+// ErrInconsistentMigrations is returned when the migrations recorded in the database don't match
+// the schema or the migrations known by this version, e.g. after a process killed mid-migration
+var ErrInconsistentMigrations = errors.New("inconsistent database migrations")
+
+const (
+	// migrationsRoot is the directory of the embedded migrations
+	migrationsRoot = "migrations"
+
+	// defaultMigrationsTable is the table recording the migrations applied to the default monitored txs table
+	defaultMigrationsTable = "gorp_migrations"
+)
+
+var (
+	// ErrInvalidTableName is returned when the configured table name is not a valid SQL identifier
+	ErrInvalidTableName = errors.New("invalid table name")
+
+	// tableNameRegexp matches the table names accepted by the storage, they are interpolated into the queries
+	tableNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// migrations groups the migrations of a monitored txs table and the set recording them
+type migrations struct {
+	set          migrate.MigrationSet
+	source       migrate.MigrationSource
+	recordsTable string
+}
+
+// tableNameOrDefault returns the name of the monitored txs table, monitored_txs if empty
+func tableNameOrDefault(tableName string) string {
+	if tableName == "" {
+		return monitoredTxsTable
+	}
+	return tableName
+}
+
+// validateTableName checks the table name can be safely used in the queries
+func validateTableName(tableName string) error {
+	if !tableNameRegexp.MatchString(tableName) {
+		return fmt.Errorf("%w: %q", ErrInvalidTableName, tableName)
+	}
+	return nil
+}
+
+// newMigrations returns the migrations of the given monitored txs table. The default table uses the
+// embedded migrations as they are, any other table gets them rewritten for its name (including the
+// names of its indexes and triggers) and its own migration records table, so several tables can
+// live in the same database without interfering
+func newMigrations(tableName string) (*migrations, error) {
+	tableName = tableNameOrDefault(tableName)
+	if err := validateTableName(tableName); err != nil {
+		return nil, err
+	}
+
+	if tableName == monitoredTxsTable {
+		return &migrations{
+			set: migrate.MigrationSet{TableName: defaultMigrationsTable},
+			source: migrate.EmbedFileSystemMigrationSource{
+				FileSystem: dbMigrations,
+				Root:       migrationsRoot,
+			},
+			recordsTable: defaultMigrationsTable,
+		}, nil
+	}
+
+	entries, err := fs.ReadDir(dbMigrations, migrationsRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	source := &migrate.MemoryMigrationSource{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		content, err := dbMigrations.ReadFile(path.Join(migrationsRoot, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		content = bytes.ReplaceAll(content, []byte(monitoredTxsTable), []byte(tableName))
+		migration, err := migrate.ParseMigration(entry.Name(), bytes.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse migration %s: %w", entry.Name(), err)
+		}
+		source.Migrations = append(source.Migrations, migration)
 	}
 
-	migrationsCount, err := migrate.Exec(db, driverName, migrations, direction)
+	recordsTable := tableName + "_migrations"
+	return &migrations{
+		set:          migrate.MigrationSet{TableName: recordsTable},
+		source:       source,
+		recordsTable: recordsTable,
+	}, nil
+}
+
+// RunMigrations applies database migrations of the default monitored txs table in the specified
+// direction (up or down).
+func RunMigrations(driverName string, db *sql.DB, direction migrate.MigrationDirection) error {
+	return RunTableMigrations(driverName, db, direction, monitoredTxsTable)
+}
+
+// RunTableMigrations applies database migrations of the given monitored txs table in the specified
+// direction (up or down).
+func RunTableMigrations(driverName string, db *sql.DB, direction migrate.MigrationDirection, tableName string) error {
+	m, err := newMigrations(tableName)
 	if err != nil {
 		return err
 	}
 
-	log.Infof("Successfully ran %d migrations in direction: %v", migrationsCount, direction)
+	migrationsCount, err := m.set.Exec(db, driverName, m.source, direction)
+	if err != nil {
+		return describeMigrationErr(err)
+	}
+
+	log.Infof("Successfully ran %d migrations of table %s in direction: %v",
+		migrationsCount, tableNameOrDefault(tableName), direction)
 	return nil
 }
+
+// RepairMigrations applies the pending up migrations re-syncing the migration records with the schema.
+// A migration failing because its changes already exist (a partially applied migration) is applied
+// statement by statement skipping the changes already present, and then recorded as applied.
+// It returns the number of repaired migrations.
+func RepairMigrations(driverName string, db *sql.DB) (int, error) {
+	return RepairTableMigrations(driverName, db, monitoredTxsTable)
+}
+
+// RepairTableMigrations is RepairMigrations for the given monitored txs table.
+func RepairTableMigrations(driverName string, db *sql.DB, tableName string) (int, error) {
+	m, err := newMigrations(tableName)
+	if err != nil {
+		return 0, err
+	}
+
+	repaired := 0
+	for {
+		planned, _, err := m.set.PlanMigration(db, driverName, m.source, migrate.Up, 1)
+		if err != nil {
+			return repaired, describeMigrationErr(err)
+		}
+		if len(planned) == 0 {
+			return repaired, nil
+		}
+		migration := planned[0]
+
+		_, err = m.set.ExecMax(db, driverName, m.source, migrate.Up, 1)
+		if err == nil {
+			continue
+		}
+		if !isAlreadyAppliedErr(err) {
+			return repaired, describeMigrationErr(err)
+		}
+
+		log.Warnf("migration %s is partially applied, applying the missing changes: %v", migration.Id, err)
+		for _, stmt := range migration.Queries {
+			if _, err := db.Exec(stmt); err != nil && !isAlreadyAppliedErr(err) {
+				return repaired, fmt.Errorf("failed to repair migration %s: %w", migration.Id, err)
+			}
+		}
+		// the migration set has no way to skip a migration, so it's recorded directly
+		_, err = db.Exec("INSERT INTO "+m.recordsTable+" (id, applied_at) VALUES (?, ?)", migration.Id, time.Now())
+		if err != nil {
+			return repaired, fmt.Errorf("failed to record repaired migration %s: %w", migration.Id, err)
+		}
+		log.Infof("migration %s repaired", migration.Id)
+		repaired++
+	}
+}
+
+// describeMigrationErr logs the reason of a migration failure and wraps the errors caused by
+// an inconsistent migration state with ErrInconsistentMigrations
+func describeMigrationErr(err error) error {
+	var planErr *migrate.PlanError
+	if errors.As(err, &planErr) {
+		log.Errorf("the database contains migrations unknown by this version (%s), "+
+			"it was probably migrated by a newer version: %v", planErr.Migration.Id, err)
+		return fmt.Errorf("%w: %w", ErrInconsistentMigrations, err)
+	}
+
+	var txErr *migrate.TxError
+	if errors.As(err, &txErr) && isAlreadyAppliedErr(err) {
+		log.Errorf("migration %s is partially applied, probably because a previous run was interrupted, "+
+			"RepairMigrations can re-sync the schema: %v", txErr.Migration.Id, err)
+		return fmt.Errorf("%w: %w", ErrInconsistentMigrations, err)
+	}
+
+	return err
+}
+
+// isAlreadyAppliedErr checks if the error returned by a migration statement means its changes already exist
+func isAlreadyAppliedErr(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "duplicate column name") || strings.Contains(msg, "already exists")
+}
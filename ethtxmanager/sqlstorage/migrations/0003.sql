@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE monitored_txs ADD COLUMN fixed_fees INTEGER DEFAULT 0 NOT NULL; -- 0 = FALSE, 1 = TRUE
+
+-- +migrate Down
+ALTER TABLE monitored_txs DROP COLUMN fixed_fees;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE monitored_txs ADD COLUMN private_relay INTEGER DEFAULT 0 NOT NULL; -- 0 = FALSE, 1 = TRUE
+
+-- +migrate Down
+ALTER TABLE monitored_txs DROP COLUMN private_relay;
@@ -0,0 +1,6 @@
+-- +migrate Up
+-- the dates are compared normalized with datetime(), so the index is built over the same expression
+CREATE INDEX idx_monitored_txs_updated_at ON monitored_txs(datetime(updated_at));
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_monitored_txs_updated_at;
@@ -0,0 +1,17 @@
+-- +migrate Up
+-- seq keeps the insertion order of the monitored txs, used to break the ties between txs created at the same time
+ALTER TABLE monitored_txs ADD COLUMN seq INTEGER;
+UPDATE monitored_txs SET seq = rowid;
+CREATE UNIQUE INDEX idx_monitored_txs_seq ON monitored_txs(seq);
+
+-- +migrate StatementBegin
+CREATE TRIGGER monitored_txs_seq AFTER INSERT ON monitored_txs
+BEGIN
+    UPDATE monitored_txs SET seq = (SELECT COALESCE(MAX(seq), 0) + 1 FROM monitored_txs) WHERE id = NEW.id;
+END;
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP TRIGGER IF EXISTS monitored_txs_seq;
+DROP INDEX IF EXISTS idx_monitored_txs_seq;
+ALTER TABLE monitored_txs DROP COLUMN seq;
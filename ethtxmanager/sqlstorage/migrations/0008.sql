@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE monitored_txs ADD COLUMN valid_until_block INTEGER DEFAULT 0 NOT NULL; -- 0 = no deadline
+
+-- +migrate Down
+ALTER TABLE monitored_txs DROP COLUMN valid_until_block;
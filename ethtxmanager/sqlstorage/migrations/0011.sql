@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE monitored_txs ADD COLUMN fee_history JSONB DEFAULT 'null' NOT NULL;
+
+-- +migrate Down
+ALTER TABLE monitored_txs DROP COLUMN fee_history;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE monitored_txs ADD COLUMN no_replace INTEGER DEFAULT 0 NOT NULL; -- 0 = FALSE, 1 = TRUE
+
+-- +migrate Down
+ALTER TABLE monitored_txs DROP COLUMN no_replace;
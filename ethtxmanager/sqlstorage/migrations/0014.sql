@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE monitored_txs ADD COLUMN send_attempts INTEGER DEFAULT 0 NOT NULL;
+
+-- +migrate Down
+ALTER TABLE monitored_txs DROP COLUMN send_attempts;
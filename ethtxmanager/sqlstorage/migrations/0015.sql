@@ -0,0 +1,9 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS monitored_txs_checkpoint (
+    id INTEGER PRIMARY KEY,
+    last_cycle_at TEXT NOT NULL,     -- RFC3339 with nanoseconds
+    last_finalized_block BIGINT NOT NULL
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS monitored_txs_checkpoint;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE monitored_txs ADD COLUMN last_tx_hash CHAR(66);
+
+-- +migrate Down
+ALTER TABLE monitored_txs DROP COLUMN last_tx_hash;
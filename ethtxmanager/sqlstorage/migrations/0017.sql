@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE monitored_txs ADD COLUMN sent_at TIMESTAMP;
+ALTER TABLE monitored_txs ADD COLUMN mined_at TIMESTAMP;
+ALTER TABLE monitored_txs ADD COLUMN finalized_at TIMESTAMP;
+
+-- +migrate Down
+ALTER TABLE monitored_txs DROP COLUMN sent_at;
+ALTER TABLE monitored_txs DROP COLUMN mined_at;
+ALTER TABLE monitored_txs DROP COLUMN finalized_at;
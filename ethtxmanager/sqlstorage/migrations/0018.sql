@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE monitored_txs ADD COLUMN reason TEXT DEFAULT '' NOT NULL;
+
+-- +migrate Down
+ALTER TABLE monitored_txs DROP COLUMN reason;
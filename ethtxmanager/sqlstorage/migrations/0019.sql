@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE monitored_txs ADD COLUMN tx_type_converted INTEGER DEFAULT 0 NOT NULL; -- 0 = FALSE, 1 = TRUE
+
+-- +migrate Down
+ALTER TABLE monitored_txs DROP COLUMN tx_type_converted;
@@ -6,9 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	localCommon "github.com/0xPolygon/zkevm-ethtx-manager/common"
+	"github.com/0xPolygon/zkevm-ethtx-manager/log"
 	"github.com/0xPolygon/zkevm-ethtx-manager/types"
 	"github.com/ethereum/go-ethereum/common"
 	sqlite "github.com/mattn/go-sqlite3"
@@ -17,30 +19,77 @@ import (
 )
 
 const (
-	// monitoredTxsTable is table name for persisting MonitoredTx objects
+	// monitoredTxsTable is the default table name for persisting MonitoredTx objects
 	monitoredTxsTable = "monitored_txs"
-)
 
-var (
-	errNoRowsInResultSet = errors.New("sql: no rows in result set")
+	// checkpointTableSuffix is appended to the monitored txs table name to get the table keeping its
+	// checkpoint, the migrations create it as monitored_txs_checkpoint
+	checkpointTableSuffix = "_checkpoint"
+
+	// checkpointID is the id of the single row of the checkpoint table
+	checkpointID = 1
 )
 
+var errNoRowsInResultSet = errors.New("sql: no rows in result set")
+
+// nonTerminalStatuses returns the statuses a monitored tx can still move from
+func nonTerminalStatuses() []types.MonitoredTxStatus {
+	statuses := make([]types.MonitoredTxStatus, 0)
+	for _, status := range types.AllStatuses() {
+		if !status.IsTerminal() {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
 var _ types.StorageInterface = (*SqlStorage)(nil)
 
+// dbExecutor runs the queries of the storage, either directly on the database or inside a transaction
+type dbExecutor interface {
+	meddler.DB
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
 // SqlStorage encapsulates logic for MonitoredTx CRUD operations.
 type SqlStorage struct {
-	db *sql.DB
+	db         *sql.DB
+	driverName string
+	tableName  string
+	// exec runs the queries, it's the db unless the storage is bound to a transaction by WithTx
+	exec dbExecutor
+	// inTx tells the storage is bound to a transaction
+	inTx bool
+	// replicas are the read replicas of the database, the storages returned by ReadReplica are spread
+	// among them round robin
+	replicas    []*sql.DB
+	nextReplica atomic.Uint64
+	// replica runs the reads of monitored txs of a storage returned by ReadReplica, nil for the primary
+	replica dbExecutor
 }
 
 // NewStorage creates and returns a new instance of SqlStorage with the given database path.
 // It first opens a connection to the SQLite database and then runs the necessary migrations.
 // If any error occurs during the database connection or migration process, it returns an error.
 func NewStorage(driverName, dbPath string) (*SqlStorage, error) {
+	return NewStorageWithConfig(driverName, dbPath, Config{})
+}
+
+// NewStorageWithConfig creates and returns a new instance of SqlStorage with the given database path,
+// applying the busy timeout and the connection pool settings of the provided configuration.
+func NewStorageWithConfig(driverName, dbPath string, cfg Config) (*SqlStorage, error) {
+	tableName := tableNameOrDefault(cfg.TableName)
+	if err := validateTableName(tableName); err != nil {
+		return nil, err
+	}
+
 	if dbPath == ":memory:" {
 		dbPath = "file::memory:?cache=shared"
 	}
 
-	db, err := sql.Open(driverName, dbPath)
+	db, err := openDB(driverName, dbPath, cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -52,49 +101,126 @@ func NewStorage(driverName, dbPath string) (*SqlStorage, error) {
 		pragma journal_size_limit  = 6144000;
 	`)
 	if err != nil {
+		closeDBs(db)
 		return nil, err
 	}
 
-	if err := RunMigrations(driverName, db, migrate.Up); err != nil {
+	if cfg.RepairMigrations {
+		if _, err := RepairTableMigrations(driverName, db, tableName); err != nil {
+			closeDBs(db)
+			return nil, err
+		}
+	} else if err := RunTableMigrations(driverName, db, migrate.Up, tableName); err != nil {
+		closeDBs(db)
 		return nil, err
 	}
 
+	replicas := make([]*sql.DB, 0, len(cfg.ReadReplicas))
+	for i, replicaDSN := range cfg.ReadReplicas {
+		replica, err := openDB(driverName, replicaDSN, cfg)
+		if err == nil {
+			if err = replica.Ping(); err != nil {
+				closeDBs(replica)
+			}
+		}
+		if err != nil {
+			// the connections opened so far are not handed to any storage
+			closeDBs(append(replicas, db)...)
+			return nil, fmt.Errorf("failed to open read replica %d: %w", i, err)
+		}
+		replicas = append(replicas, replica)
+	}
+
 	initMeddler()
 
-	return &SqlStorage{db: db}, nil
+	return &SqlStorage{db: db, driverName: driverName, tableName: tableName, exec: db, replicas: replicas}, nil
 }
 
-// Add persist a monitored transaction into the SQL database.
-func (s *SqlStorage) Add(_ context.Context, mTx types.MonitoredTx) error {
-	mTx.CreatedAt = time.Now()
-	mTx.UpdatedAt = mTx.CreatedAt
+// openDB opens a connection pool to the database applying the busy timeout and the pool settings
+func openDB(driverName, dsn string, cfg Config) (*sql.DB, error) {
+	// the busy timeout is set through the DSN so it applies to every connection of the pool
+	if cfg.BusyTimeout.Duration > 0 && driverName == localCommon.SQLLiteDriverName {
+		separator := "?"
+		if strings.Contains(dsn, "?") {
+			separator = "&"
+		}
+		dsn = fmt.Sprintf("%s%s_busy_timeout=%d", dsn, separator, cfg.BusyTimeout.Milliseconds())
+	}
 
-	err := meddler.Insert(s.db, monitoredTxsTable, &mTx)
+	db, err := sql.Open(driverName, dsn)
 	if err != nil {
-		sqlErr, success := unwrapSQLiteErr(err)
-		if !success {
-			return err
-		}
+		return nil, err
+	}
+
+	if cfg.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+
+	return db, nil
+}
 
-		if sqlErr.Code == sqlite.ErrConstraint {
-			return types.ErrAlreadyExists
+// closeDBs closes the connection pools opened by a storage that failed to be created
+func closeDBs(dbs ...*sql.DB) {
+	for _, db := range dbs {
+		if err := db.Close(); err != nil {
+			log.Warnf("failed to close the database: %v", err)
 		}
 	}
+}
+
+// ReadReplica returns a storage whose reads of monitored txs (Get, Query and the ones built on it) go to a
+// read replica chosen round robin, while its writes and the rest of its reads go to the primary. It's meant
+// for the reads that tolerate the replication lag and are not written back, such as reporting results.
+// The storage itself is returned when no replicas are configured or it's bound to a transaction
+func (s *SqlStorage) ReadReplica() types.StorageInterface {
+	if s.inTx || len(s.replicas) == 0 {
+		return s
+	}
+	replica := s.replicas[s.nextReplica.Add(1)%uint64(len(s.replicas))]
+	return &SqlStorage{db: s.db, driverName: s.driverName, tableName: s.tableName, exec: s.exec, replica: replica}
+}
+
+// reader returns the executor of the reads of monitored txs: the read replica of the storages returned
+// by ReadReplica, otherwise the primary
+func (s *SqlStorage) reader() dbExecutor {
+	if s.replica != nil {
+		return s.replica
+	}
+	return s.exec
+}
+
+// Add persist a monitored transaction into the SQL database.
+// The timestamps are set to the current time unless they are already provided (e.g. when importing txs).
+func (s *SqlStorage) Add(_ context.Context, mTx types.MonitoredTx) error {
+	if mTx.CreatedAt.IsZero() {
+		mTx.CreatedAt = time.Now()
+	}
+	if mTx.UpdatedAt.IsZero() {
+		mTx.UpdatedAt = mTx.CreatedAt
+	}
+
+	err := meddler.Insert(s.exec, s.tableName, &mTx)
+	if err != nil {
+		return classifyInsertErr(err)
+	}
 
-	return err
+	return nil
 }
 
 // Remove deletes a monitored transaction from the database by its ID.
 // If the transaction does not exist, it returns an ErrNotFound error.
 func (s *SqlStorage) Remove(ctx context.Context, id common.Hash) error {
-	baseDeleteStmt := buildBaseDeleteStatement(monitoredTxsTable)
+	baseDeleteStmt := buildBaseDeleteStatement(s.tableName)
 
 	var queryBuilder strings.Builder
 	queryBuilder.WriteString(baseDeleteStmt + " WHERE id = $1")
 
-	result, err := s.db.ExecContext(ctx, queryBuilder.String(), id.Hex())
+	result, err := s.exec.ExecContext(ctx, queryBuilder.String(), id.Hex())
 	if err != nil {
-		return err
+		return classifySQLiteErr(err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -110,11 +236,40 @@ func (s *SqlStorage) Remove(ctx context.Context, id common.Hash) error {
 	return nil
 }
 
+// RemoveByStatus deletes the monitored transactions with any of the provided statuses,
+// returning the number of removed transactions. At least one status is required.
+func (s *SqlStorage) RemoveByStatus(ctx context.Context, statuses []types.MonitoredTxStatus) (int, error) {
+	if len(statuses) == 0 {
+		return 0, errors.New("at least one status is required to remove monitored transactions")
+	}
+
+	args := make([]interface{}, len(statuses))
+	placeholders := make([]string, len(statuses))
+	for i, status := range statuses {
+		args[i] = string(status)
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+
+	query := buildBaseDeleteStatement(s.tableName) + " WHERE status IN (" + strings.Join(placeholders, ", ") + ")"
+	result, err := s.exec.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove monitored transactions by status: %w", classifySQLiteErr(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
 // Get retrieves a monitored transaction from the database by its ID.
 // If the transaction is not found, it returns an ErrNotFound error.
+// It's read from the read replicas when they are configured.
 func (s *SqlStorage) Get(_ context.Context, id common.Hash) (types.MonitoredTx, error) {
 	var tx *types.MonitoredTx
-	baseQuery, err := buildBaseSelectQuery(tx, monitoredTxsTable)
+	baseQuery, err := buildBaseSelectQuery(tx, s.tableName)
 	if err != nil {
 		return types.MonitoredTx{}, err
 	}
@@ -123,91 +278,183 @@ func (s *SqlStorage) Get(_ context.Context, id common.Hash) (types.MonitoredTx,
 
 	// Execute the query to retrieve the transaction data.
 	var mTx types.MonitoredTx
-	err = meddler.QueryRow(s.db, &mTx, query, id.Hex())
+	err = meddler.QueryRow(s.reader(), &mTx, query, id.Hex())
 	if err != nil {
 		if err.Error() == errNoRowsInResultSet.Error() {
 			return types.MonitoredTx{}, types.ErrNotFound
 		}
 
-		return types.MonitoredTx{}, err
+		return types.MonitoredTx{}, classifySQLiteErr(err)
 	}
 
 	return mTx, nil
 }
 
-// GetByStatus retrieves monitored transactions from the database that match the provided statuses.
-// If no statuses are provided, it returns all transactions.
+// Query retrieves the monitored transactions from the database that match all the criteria of the filter.
 // The transactions are ordered by their creation date (oldest first).
-func (s *SqlStorage) GetByStatus(_ context.Context, statuses []types.MonitoredTxStatus) ([]types.MonitoredTx, error) {
+// They are read from the read replicas when they are configured.
+func (s *SqlStorage) Query(ctx context.Context, filter types.MonitoredTxFilter) ([]types.MonitoredTx, error) {
 	var tx *types.MonitoredTx
-	baseQuery, err := buildBaseSelectQuery(tx, monitoredTxsTable)
+	baseQuery, err := buildBaseSelectQuery(tx, s.tableName)
 	if err != nil {
 		return nil, err
 	}
 
-	query := baseQuery
-	args := make([]interface{}, 0, len(statuses))
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
 
-	if len(statuses) > 0 {
-		placeholders := make([]string, len(statuses))
-		// Build the WHERE clause for status filtering
-		for i, status := range statuses {
-			placeholders[i] = fmt.Sprintf("$%d", i+1)
+	if len(filter.Statuses) > 0 {
+		placeholders := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
 			args = append(args, string(status))
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
 		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if filter.From != nil {
+		addCondition("from_address = $%d", filter.From.Hex())
+	}
+	if filter.FromBlock != nil {
+		addCondition("block_number >= $%d", *filter.FromBlock)
+	}
+	if filter.ToBlock != nil {
+		addCondition("block_number <= $%d", *filter.ToBlock)
+	}
+	// dates are stored in RFC3339 format with the time zone offset, so they are normalized before comparing them
+	if filter.CreatedAfter != nil {
+		addCondition("datetime(created_at) >= datetime($%d)", filter.CreatedAfter.Format(time.RFC3339))
+	}
+	if filter.CreatedBefore != nil {
+		addCondition("datetime(created_at) < datetime($%d)", filter.CreatedBefore.Format(time.RFC3339))
+	}
+	if filter.UpdatedAfter != nil {
+		addCondition("datetime(updated_at) >= datetime($%d)", filter.UpdatedAfter.Format(time.RFC3339))
+	}
+	if filter.UpdatedBefore != nil {
+		addCondition("datetime(updated_at) < datetime($%d)", filter.UpdatedBefore.Format(time.RFC3339))
+	}
 
-		// Build the WHERE clause with the joined placeholders
-		query += " WHERE status IN (" + strings.Join(placeholders, ", ") + ")"
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString(baseQuery)
+	if len(conditions) > 0 {
+		queryBuilder.WriteString(" WHERE " + strings.Join(conditions, " AND "))
 	}
 
-	// Add ordering by creation date (oldest first)
-	query += " ORDER BY created_at ASC"
+	// Add ordering by creation date (oldest first), the insertion sequence breaks the ties
+	// since the creation dates are truncated when they are stored
+	queryBuilder.WriteString(" ORDER BY created_at ASC, seq ASC")
+
+	if filter.Limit > 0 || filter.Offset > 0 {
+		// a negative limit means no limit, but it's required to use an offset
+		limit := int64(-1)
+		if filter.Limit > 0 {
+			limit = int64(filter.Limit) //nolint:gosec
+		}
+		args = append(args, limit, filter.Offset)
+		queryBuilder.WriteString(fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args)))
+	}
 
 	// Use meddler.QueryAll to retrieve the monitored transactions
 	var transactions []*types.MonitoredTx
-	if err := meddler.QueryAll(s.db, &transactions, query, args...); err != nil {
-		return nil, fmt.Errorf("failed to query monitored transactions by status: %w", err)
+	if err := meddler.QueryAll(s.reader(), &transactions, queryBuilder.String(), args...); err != nil {
+		return nil, fmt.Errorf("failed to query monitored transactions: %w", classifySQLiteErr(err))
 	}
 
 	return localCommon.SlicePtrsToSlice(transactions), nil
 }
 
-// GetByBlock loads all monitored transactions that have the blockNumber between fromBlock and toBlock.
-func (s *SqlStorage) GetByBlock(ctx context.Context, fromBlock, toBlock *uint64) ([]types.MonitoredTx, error) {
-	var tx *types.MonitoredTx
-	baseQuery, err := buildBaseSelectQuery(tx, monitoredTxsTable)
+// GetByStatus retrieves monitored transactions from the database that match the provided statuses.
+// If no statuses are provided, it returns all transactions.
+// The transactions are ordered by their creation date (oldest first).
+func (s *SqlStorage) GetByStatus(ctx context.Context, statuses []types.MonitoredTxStatus) ([]types.MonitoredTx, error) {
+	mTxs, err := s.Query(ctx, types.MonitoredTxFilter{Statuses: statuses})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to query monitored transactions by status: %w", err)
+	}
+
+	return mTxs, nil
+}
+
+// GetStale retrieves the monitored transactions from the database that match the provided statuses
+// and were not updated since olderThan. If no statuses are provided, the non-terminal statuses are used.
+// The transactions are ordered by their creation date (oldest first).
+func (s *SqlStorage) GetStale(ctx context.Context, statuses []types.MonitoredTxStatus,
+	olderThan time.Time) ([]types.MonitoredTx, error) {
+	if len(statuses) == 0 {
+		statuses = nonTerminalStatuses()
 	}
 
-	query := baseQuery
-	const maxArgs = 2
+	mTxs, err := s.Query(ctx, types.MonitoredTxFilter{Statuses: statuses, UpdatedBefore: &olderThan})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stale monitored transactions: %w", err)
+	}
+
+	return mTxs, nil
+}
 
-	args := make([]interface{}, 0, maxArgs)
-	argsCounter := 1
-	if fromBlock != nil {
-		query += fmt.Sprintf(" WHERE block_number >= $%d", argsCounter)
-		args = append(args, *fromBlock)
-		argsCounter++
+// CountByStatus counts the monitored transactions grouped by their status.
+func (s *SqlStorage) CountByStatus(ctx context.Context) (map[types.MonitoredTxStatus]int, error) {
+	rows, err := s.exec.QueryContext(ctx,
+		fmt.Sprintf("SELECT status, COUNT(*) FROM %s GROUP BY status", s.tableName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count monitored transactions: %w", classifySQLiteErr(err))
 	}
-	if toBlock != nil {
-		if argsCounter > 1 {
-			query += fmt.Sprintf(" AND block_number <= $%d", argsCounter)
-		} else {
-			query += fmt.Sprintf(" WHERE block_number <= $%d", argsCounter)
+	defer rows.Close()
+
+	counts := make(map[types.MonitoredTxStatus]int)
+	for rows.Next() {
+		var (
+			status string
+			count  int
+		)
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan monitored transactions count: %w", err)
 		}
+		counts[types.MonitoredTxStatus(status)] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to count monitored transactions: %w", classifySQLiteErr(err))
+	}
+
+	return counts, nil
+}
 
-		args = append(args, *toBlock)
+// DistinctSenders returns the senders of the monitored transactions, each of them once and ordered by address.
+func (s *SqlStorage) DistinctSenders(ctx context.Context) ([]common.Address, error) {
+	rows, err := s.exec.QueryContext(ctx,
+		fmt.Sprintf("SELECT DISTINCT from_address FROM %s ORDER BY from_address", s.tableName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the senders of the monitored transactions: %w", classifySQLiteErr(err))
+	}
+	defer rows.Close()
+
+	senders := make([]common.Address, 0)
+	for rows.Next() {
+		var sender string
+		if err := rows.Scan(&sender); err != nil {
+			return nil, fmt.Errorf("failed to scan monitored transactions sender: %w", err)
+		}
+		senders = append(senders, common.HexToAddress(sender))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get the senders of the monitored transactions: %w", classifySQLiteErr(err))
 	}
 
-	// Use meddler.QueryAll to execute the query and scan into the result slice.
-	var monitoredTxs []*types.MonitoredTx
-	err = meddler.QueryAll(s.db, &monitoredTxs, query, args...)
+	return senders, nil
+}
+
+// GetByBlock loads all monitored transactions that have the blockNumber between fromBlock and toBlock.
+func (s *SqlStorage) GetByBlock(ctx context.Context, fromBlock, toBlock *uint64) ([]types.MonitoredTx, error) {
+	mTxs, err := s.Query(ctx, types.MonitoredTxFilter{FromBlock: fromBlock, ToBlock: toBlock})
 	if err != nil {
 		return nil, fmt.Errorf("failed to query monitored transactions by block: %w", err)
 	}
 
-	return localCommon.SlicePtrsToSlice(monitoredTxs), nil
+	return mTxs, nil
 }
 
 // Update a persisted monitored tx
@@ -228,7 +475,7 @@ func (s *SqlStorage) Update(ctx context.Context, mTx types.MonitoredTx) error {
 
 	// Use strings.Builder for efficient query building
 	var queryBuilder strings.Builder
-	queryBuilder.WriteString("UPDATE " + monitoredTxsTable + " SET ")
+	queryBuilder.WriteString("UPDATE " + s.tableName + " SET ")
 
 	// Build the SET clause (skip the first column)
 	setClauses := make([]string, len(columns)-1)
@@ -250,9 +497,9 @@ func (s *SqlStorage) Update(ctx context.Context, mTx types.MonitoredTx) error {
 	args = append(args[1:], mTx.ID.Hex())
 
 	// Execute the query with the arguments
-	result, err := s.db.ExecContext(ctx, queryBuilder.String(), args...)
+	result, err := s.exec.ExecContext(ctx, queryBuilder.String(), args...)
 	if err != nil {
-		return fmt.Errorf("failed to update monitored transaction: %w", err)
+		return fmt.Errorf("failed to update monitored transaction: %w", classifySQLiteErr(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -267,11 +514,101 @@ func (s *SqlStorage) Update(ctx context.Context, mTx types.MonitoredTx) error {
 	return nil
 }
 
-// Empty clears all the records from the monitored_txs table.
+// GetCheckpoint retrieves the checkpoint stored along the monitored txs table.
+// If no checkpoint was stored yet, it returns an ErrNotFound error.
+func (s *SqlStorage) GetCheckpoint(ctx context.Context) (types.Checkpoint, error) {
+	var (
+		lastCycleAt        string
+		lastFinalizedBlock uint64
+	)
+	err := s.exec.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT last_cycle_at, last_finalized_block FROM %s WHERE id = $1", s.checkpointTable()),
+		checkpointID).Scan(&lastCycleAt, &lastFinalizedBlock)
+	if errors.Is(err, sql.ErrNoRows) {
+		return types.Checkpoint{}, types.ErrNotFound
+	}
+	if err != nil {
+		return types.Checkpoint{}, fmt.Errorf("failed to get the checkpoint: %w", classifySQLiteErr(err))
+	}
+
+	cycleAt, err := time.Parse(time.RFC3339Nano, lastCycleAt)
+	if err != nil {
+		return types.Checkpoint{}, fmt.Errorf("failed to parse the checkpoint cycle time %q: %w", lastCycleAt, err)
+	}
+
+	return types.Checkpoint{LastCycleAt: cycleAt, LastFinalizedBlock: lastFinalizedBlock}, nil
+}
+
+// SetCheckpoint stores the checkpoint along the monitored txs table, replacing the previous one.
+func (s *SqlStorage) SetCheckpoint(ctx context.Context, checkpoint types.Checkpoint) error {
+	_, err := s.exec.ExecContext(ctx,
+		fmt.Sprintf(`INSERT INTO %s (id, last_cycle_at, last_finalized_block) VALUES ($1, $2, $3)
+			ON CONFLICT (id) DO UPDATE SET last_cycle_at = excluded.last_cycle_at,
+			last_finalized_block = excluded.last_finalized_block`, s.checkpointTable()),
+		checkpointID, checkpoint.LastCycleAt.Format(time.RFC3339Nano), checkpoint.LastFinalizedBlock)
+	if err != nil {
+		return fmt.Errorf("failed to set the checkpoint: %w", classifySQLiteErr(err))
+	}
+
+	return nil
+}
+
+// checkpointTable returns the name of the table keeping the checkpoint of the monitored txs table
+func (s *SqlStorage) checkpointTable() string {
+	return s.tableName + checkpointTableSuffix
+}
+
+// Empty clears all the records from the monitored txs table.
 func (s *SqlStorage) Empty(ctx context.Context) error {
-	_, err := s.db.ExecContext(ctx, buildBaseDeleteStatement(monitoredTxsTable))
+	_, err := s.exec.ExecContext(ctx, buildBaseDeleteStatement(s.tableName))
+	if err != nil {
+		return fmt.Errorf("failed to empty %s table: %w", s.tableName, classifySQLiteErr(err))
+	}
+
+	return nil
+}
+
+// WithTx runs fn with a storage bound to a database transaction, so all the changes done through it
+// are committed together when fn succeeds or rolled back when it fails. When the storage is already
+// bound to a transaction, fn joins it
+func (s *SqlStorage) WithTx(ctx context.Context, fn func(types.StorageInterface) error) error {
+	if s.inTx {
+		return fn(s)
+	}
+
+	dbTx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to empty monitored_txs table: %w", err)
+		return fmt.Errorf("failed to begin the storage transaction: %w", classifySQLiteErr(err))
+	}
+
+	txStorage := &SqlStorage{db: s.db, driverName: s.driverName, tableName: s.tableName, exec: dbTx, inTx: true}
+	if err := fn(txStorage); err != nil {
+		if rollbackErr := dbTx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rollbackErr)
+		}
+		return err
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit the storage transaction: %w", classifySQLiteErr(err))
+	}
+
+	return nil
+}
+
+// Maintenance checkpoints and truncates the WAL file and rebuilds the database file
+// to reclaim the space left by removed records. It does nothing if the driver is not sqlite.
+func (s *SqlStorage) Maintenance(ctx context.Context) error {
+	if s.driverName != localCommon.SQLLiteDriverName {
+		return nil
+	}
+
+	if _, err := s.db.ExecContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return fmt.Errorf("failed to checkpoint the WAL file: %w", classifySQLiteErr(err))
+	}
+
+	if _, err := s.db.ExecContext(ctx, "VACUUM"); err != nil {
+		return fmt.Errorf("failed to vacuum the database: %w", classifySQLiteErr(err))
 	}
 
 	return nil
@@ -317,3 +654,32 @@ func unwrapSQLiteErr(err error) (*sqlite.Error, bool) {
 
 	return sqliteErr, false
 }
+
+// classifyInsertErr maps the errors of an insert, a UNIQUE or PRIMARY KEY violation means the row
+// already exists (ErrAlreadyExists), while the rest are classified by classifySQLiteErr
+func classifyInsertErr(err error) error {
+	sqlErr, ok := unwrapSQLiteErr(err)
+	if ok && (sqlErr.ExtendedCode == sqlite.ErrConstraintUnique || sqlErr.ExtendedCode == sqlite.ErrConstraintPrimaryKey) {
+		return types.ErrAlreadyExists
+	}
+	return classifySQLiteErr(err)
+}
+
+// classifySQLiteErr maps the sqlite error codes to the storage errors, so the callers can tell
+// the transient errors (ErrStorageUnavailable) that are worth retrying later from the rest.
+// Errors not coming from sqlite, or not transient, are returned as they are. The constraint
+// violations are kept as they are too, only the inserts map them with classifyInsertErr
+func classifySQLiteErr(err error) error {
+	sqlErr, ok := unwrapSQLiteErr(err)
+	if !ok {
+		return err
+	}
+
+	switch sqlErr.Code {
+	case sqlite.ErrBusy, sqlite.ErrLocked, sqlite.ErrIoErr, sqlite.ErrFull, sqlite.ErrCantOpen,
+		sqlite.ErrReadonly, sqlite.ErrProtocol, sqlite.ErrNomem:
+		return fmt.Errorf("%w: %w", types.ErrStorageUnavailable, err)
+	default:
+		return err
+	}
+}
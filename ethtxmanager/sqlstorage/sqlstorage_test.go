@@ -2,18 +2,21 @@ package sqlstorage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"path"
 	"sync"
 	"testing"
 	"time"
 
 	localCommon "github.com/0xPolygon/zkevm-ethtx-manager/common"
+	configTypes "github.com/0xPolygon/zkevm-ethtx-manager/config/types"
 	"github.com/0xPolygon/zkevm-ethtx-manager/types"
 	"github.com/ethereum/go-ethereum/common"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
-	_ "github.com/mattn/go-sqlite3"
+	sqlite "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/require"
 )
 
@@ -106,6 +109,40 @@ func TestSqlStorage_Remove(t *testing.T) {
 	}
 }
 
+func TestSqlStorage_RemoveByStatus(t *testing.T) {
+	ctx := context.Background()
+
+	storage, err := NewStorage(localCommon.SQLLiteDriverName, path.Join(t.TempDir(), "txmanager.sqlite"))
+	require.NoError(t, err)
+	defer storage.db.Close()
+
+	for i, status := range []types.MonitoredTxStatus{
+		types.MonitoredTxStatusFailed, types.MonitoredTxStatusFailed, types.MonitoredTxStatusEvicted,
+		types.MonitoredTxStatusSent, types.MonitoredTxStatusFinalized,
+	} {
+		mTx := newMonitoredTx(fmt.Sprintf("0x%x", i+1), "0xSender1", "0xReceiver1", uint64(i), status, 10)
+		require.NoError(t, storage.Add(ctx, mTx))
+	}
+
+	_, err = storage.RemoveByStatus(ctx, nil)
+	require.Error(t, err)
+
+	removed, err := storage.RemoveByStatus(ctx, []types.MonitoredTxStatus{types.MonitoredTxStatusFailed})
+	require.NoError(t, err)
+	require.Equal(t, 2, removed)
+
+	removed, err = storage.RemoveByStatus(ctx, []types.MonitoredTxStatus{
+		types.MonitoredTxStatusFailed, types.MonitoredTxStatusEvicted, types.MonitoredTxStatusFinalized,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, removed)
+
+	mTxs, err := storage.GetByStatus(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, mTxs, 1)
+	require.Equal(t, types.MonitoredTxStatusSent, mTxs[0].Status)
+}
+
 func TestSqlStorage_Get(t *testing.T) {
 	ctx := context.Background()
 
@@ -202,6 +239,167 @@ func TestSqlStorage_GetByStatus(t *testing.T) {
 	}
 }
 
+func TestSqlStorage_GetByStatusInsertionOrder(t *testing.T) {
+	ctx := context.Background()
+
+	storage, err := NewStorage(localCommon.SQLLiteDriverName, path.Join(t.TempDir(), "txmanager.sqlite"))
+	require.NoError(t, err)
+	defer storage.db.Close()
+
+	// all the txs are created at the same time, so the insertion order breaks the ties
+	createdAt := time.Now()
+	ids := []string{"0x5", "0x1", "0x9", "0x3", "0x7", "0x2"}
+	for i, id := range ids {
+		tx := newMonitoredTx(id, "0xSender1", "0xReceiver1", uint64(i), types.MonitoredTxStatusCreated, 100)
+		tx.CreatedAt = createdAt
+		tx.UpdatedAt = createdAt
+		require.NoError(t, storage.Add(ctx, tx))
+	}
+
+	// updating the txs doesn't change their order
+	for _, id := range []string{"0x9", "0x5"} {
+		tx, err := storage.Get(ctx, common.HexToHash(id))
+		require.NoError(t, err)
+		require.NoError(t, storage.Update(ctx, tx))
+	}
+
+	for i := 0; i < 3; i++ {
+		txs, err := storage.GetByStatus(ctx, []types.MonitoredTxStatus{types.MonitoredTxStatusCreated})
+		require.NoError(t, err)
+		require.Len(t, txs, len(ids))
+		for j, tx := range txs {
+			require.Equal(t, common.HexToHash(ids[j]), tx.ID)
+		}
+	}
+}
+
+func TestSqlStorage_CountByStatus(t *testing.T) {
+	ctx := context.Background()
+
+	storage, err := NewStorage(localCommon.SQLLiteDriverName, path.Join(t.TempDir(), "txmanager.sqlite"))
+	require.NoError(t, err)
+	defer storage.db.Close()
+
+	counts, err := storage.CountByStatus(ctx)
+	require.NoError(t, err)
+	require.Empty(t, counts)
+
+	txs := []types.MonitoredTx{
+		newMonitoredTx("0x1", "0xSender1", "0xReceiver1", 1, types.MonitoredTxStatusCreated, 100),
+		newMonitoredTx("0x2", "0xSender1", "0xReceiver1", 2, types.MonitoredTxStatusSent, 101),
+		newMonitoredTx("0x3", "0xSender1", "0xReceiver1", 3, types.MonitoredTxStatusSent, 102),
+		newMonitoredTx("0x4", "0xSender2", "0xReceiver2", 4, types.MonitoredTxStatusMined, 103),
+		newMonitoredTx("0x5", "0xSender2", "0xReceiver2", 5, types.MonitoredTxStatusSent, 104),
+		newMonitoredTx("0x6", "0xSender2", "0xReceiver2", 6, types.MonitoredTxStatusFailed, 105),
+	}
+	for _, tx := range txs {
+		require.NoError(t, storage.Add(ctx, tx))
+	}
+
+	counts, err = storage.CountByStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[types.MonitoredTxStatus]int{
+		types.MonitoredTxStatusCreated: 1,
+		types.MonitoredTxStatusSent:    3,
+		types.MonitoredTxStatusMined:   1,
+		types.MonitoredTxStatusFailed:  1,
+	}, counts)
+}
+
+func TestSqlStorage_DistinctSenders(t *testing.T) {
+	ctx := context.Background()
+
+	storage, err := NewStorage(localCommon.SQLLiteDriverName, path.Join(t.TempDir(), "txmanager.sqlite"))
+	require.NoError(t, err)
+	defer storage.db.Close()
+
+	senders, err := storage.DistinctSenders(ctx)
+	require.NoError(t, err)
+	require.Empty(t, senders)
+
+	txs := []types.MonitoredTx{
+		newMonitoredTx("0x1", "0xa3", "0xb1", 1, types.MonitoredTxStatusCreated, 100),
+		newMonitoredTx("0x2", "0xa1", "0xb1", 1, types.MonitoredTxStatusSent, 101),
+		newMonitoredTx("0x3", "0xa1", "0xb2", 2, types.MonitoredTxStatusMined, 102),
+		newMonitoredTx("0x4", "0xa2", "0xb2", 1, types.MonitoredTxStatusFailed, 103),
+		newMonitoredTx("0x5", "0xa3", "0xb2", 2, types.MonitoredTxStatusSent, 104),
+	}
+	for _, tx := range txs {
+		require.NoError(t, storage.Add(ctx, tx))
+	}
+
+	senders, err = storage.DistinctSenders(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []common.Address{
+		common.HexToAddress("0xa1"),
+		common.HexToAddress("0xa2"),
+		common.HexToAddress("0xa3"),
+	}, senders)
+}
+
+func TestSqlStorage_GetStale(t *testing.T) {
+	ctx := context.Background()
+
+	storage, err := NewStorage(localCommon.SQLLiteDriverName, path.Join(t.TempDir(), "txmanager.sqlite"))
+	require.NoError(t, err)
+	defer storage.db.Close()
+
+	now := time.Now()
+	staleAt := now.Add(-2 * time.Hour)
+	threshold := now.Add(-time.Hour)
+
+	stale := func(mTx types.MonitoredTx) types.MonitoredTx {
+		mTx.CreatedAt = staleAt
+		mTx.UpdatedAt = staleAt
+		return mTx
+	}
+	txs := []types.MonitoredTx{
+		stale(newMonitoredTx("0x1", "0xSender1", "0xReceiver1", 1, types.MonitoredTxStatusCreated, 100)),
+		stale(newMonitoredTx("0x2", "0xSender1", "0xReceiver1", 2, types.MonitoredTxStatusSent, 101)),
+		newMonitoredTx("0x3", "0xSender1", "0xReceiver1", 3, types.MonitoredTxStatusSent, 102),
+		stale(newMonitoredTx("0x4", "0xSender2", "0xReceiver2", 4, types.MonitoredTxStatusMined, 103)),
+		stale(newMonitoredTx("0x5", "0xSender2", "0xReceiver2", 5, types.MonitoredTxStatusFinalized, 104)),
+		stale(newMonitoredTx("0x6", "0xSender2", "0xReceiver2", 6, types.MonitoredTxStatusFailed, 105)),
+	}
+	for _, tx := range txs {
+		require.NoError(t, storage.Add(ctx, tx))
+	}
+
+	ids := func(mTxs []types.MonitoredTx) []common.Hash {
+		result := make([]common.Hash, 0, len(mTxs))
+		for _, mTx := range mTxs {
+			result = append(result, mTx.ID)
+		}
+		return result
+	}
+
+	// the terminal statuses are ignored by default
+	staleTxs, err := storage.GetStale(ctx, nil, threshold)
+	require.NoError(t, err)
+	require.ElementsMatch(t,
+		[]common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2"), common.HexToHash("0x4")}, ids(staleTxs))
+
+	staleTxs, err = storage.GetStale(ctx, []types.MonitoredTxStatus{types.MonitoredTxStatusSent}, threshold)
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{common.HexToHash("0x2")}, ids(staleTxs))
+
+	// updating a stale tx makes it fresh again
+	staleTx, err := storage.Get(ctx, common.HexToHash("0x2"))
+	require.NoError(t, err)
+	require.NoError(t, storage.Update(ctx, staleTx))
+
+	staleTxs, err = storage.GetStale(ctx, []types.MonitoredTxStatus{types.MonitoredTxStatusSent}, threshold)
+	require.NoError(t, err)
+	require.Empty(t, staleTxs)
+
+	// the index over the last update date exists
+	var indexName string
+	err = storage.db.QueryRow(
+		`SELECT name FROM sqlite_master WHERE type='index' AND name='idx_monitored_txs_updated_at';`,
+	).Scan(&indexName)
+	require.NoError(t, err)
+}
+
 func TestSqlStorage_GetByBlock(t *testing.T) {
 	ctx := context.Background()
 
@@ -259,6 +457,113 @@ func TestSqlStorage_GetByBlock(t *testing.T) {
 	}
 }
 
+func TestSqlStorage_Query(t *testing.T) {
+	ctx := context.Background()
+
+	storage, err := NewStorage(localCommon.SQLLiteDriverName, path.Join(t.TempDir(), "txmanager.sqlite"))
+	require.NoError(t, err)
+	defer storage.db.Close()
+
+	sender1 := common.HexToAddress("0xA1")
+	sender2 := common.HexToAddress("0xA2")
+	createdAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	// Add some transactions with different statuses, senders, block numbers and creation dates
+	tx1 := newMonitoredTx("0x1", sender1.Hex(), "0xB1", 1, types.MonitoredTxStatusMined, 100)
+	tx2 := newMonitoredTx("0x2", sender1.Hex(), "0xB1", 2, types.MonitoredTxStatusMined, 101)
+	tx3 := newMonitoredTx("0x3", sender2.Hex(), "0xB1", 1, types.MonitoredTxStatusMined, 102)
+	tx4 := newMonitoredTx("0x4", sender1.Hex(), "0xB1", 3, types.MonitoredTxStatusSent, 103)
+	for i, tx := range []types.MonitoredTx{tx1, tx2, tx3, tx4} {
+		tx.CreatedAt = createdAt.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, storage.Add(ctx, tx))
+	}
+
+	secondCreatedAt := createdAt.Add(time.Minute)
+	fourthCreatedAt := createdAt.Add(3 * time.Minute)
+	fourthCreatedAtUTC := fourthCreatedAt.UTC()
+
+	tests := []struct {
+		name        string
+		filter      types.MonitoredTxFilter
+		expectedIDs []common.Hash
+	}{
+		{
+			name:        "No criteria",
+			filter:      types.MonitoredTxFilter{},
+			expectedIDs: []common.Hash{tx1.ID, tx2.ID, tx3.ID, tx4.ID},
+		},
+		{
+			name: "Status and sender",
+			filter: types.MonitoredTxFilter{
+				Statuses: []types.MonitoredTxStatus{types.MonitoredTxStatusMined},
+				From:     &sender1,
+			},
+			expectedIDs: []common.Hash{tx1.ID, tx2.ID},
+		},
+		{
+			name: "Sender and block range",
+			filter: types.MonitoredTxFilter{
+				From:      &sender1,
+				FromBlock: localCommon.ToUint64Ptr(101),
+				ToBlock:   localCommon.ToUint64Ptr(103),
+			},
+			expectedIDs: []common.Hash{tx2.ID, tx4.ID},
+		},
+		{
+			name: "Status and creation date",
+			filter: types.MonitoredTxFilter{
+				Statuses:      []types.MonitoredTxStatus{types.MonitoredTxStatusMined},
+				CreatedAfter:  &secondCreatedAt,
+				CreatedBefore: &fourthCreatedAt,
+			},
+			expectedIDs: []common.Hash{tx2.ID, tx3.ID},
+		},
+		{
+			name: "Creation date in UTC",
+			filter: types.MonitoredTxFilter{
+				CreatedAfter: &fourthCreatedAtUTC,
+			},
+			expectedIDs: []common.Hash{tx4.ID},
+		},
+		{
+			name:        "Limit",
+			filter:      types.MonitoredTxFilter{Limit: 2},
+			expectedIDs: []common.Hash{tx1.ID, tx2.ID},
+		},
+		{
+			name:        "Offset",
+			filter:      types.MonitoredTxFilter{Offset: 3},
+			expectedIDs: []common.Hash{tx4.ID},
+		},
+		{
+			name: "All criteria",
+			filter: types.MonitoredTxFilter{
+				Statuses:     []types.MonitoredTxStatus{types.MonitoredTxStatusMined, types.MonitoredTxStatusSent},
+				From:         &sender1,
+				FromBlock:    localCommon.ToUint64Ptr(100),
+				CreatedAfter: &createdAt,
+				Limit:        1,
+				Offset:       1,
+			},
+			expectedIDs: []common.Hash{tx2.ID},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := storage.Query(ctx, test.filter)
+			require.NoError(t, err)
+
+			resultIDs := make([]common.Hash, 0, len(result))
+			for _, tx := range result {
+				resultIDs = append(resultIDs, tx.ID)
+			}
+
+			require.Equal(t, test.expectedIDs, resultIDs)
+		})
+	}
+}
+
 func TestSqlStorage_Update(t *testing.T) {
 	ctx := context.Background()
 
@@ -408,6 +713,56 @@ func TestSingleReaderMultipleWriters(t *testing.T) {
 	}
 }
 
+func TestConcurrentWritersWithStorageConfig(t *testing.T) {
+	cfg := Config{
+		BusyTimeout:  configTypes.NewDuration(5 * time.Second),
+		MaxOpenConns: 1,
+		MaxIdleConns: 1,
+	}
+	storage, err := NewStorageWithConfig(localCommon.SQLLiteDriverName, path.Join(t.TempDir(), "txmanager.sqlite"), cfg)
+	require.NoError(t, err)
+	defer storage.db.Close()
+
+	var busyTimeout int64
+	require.NoError(t, storage.db.QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout))
+	require.Equal(t, cfg.BusyTimeout.Milliseconds(), busyTimeout)
+
+	ctx := context.Background()
+	numWriterGoroutines := 20
+	numRecordsPerGoroutine := 25
+
+	var wg sync.WaitGroup
+	errs := make(chan error, numWriterGoroutines*numRecordsPerGoroutine*2)
+	for i := 0; i < numWriterGoroutines; i++ {
+		wg.Add(1)
+		go func(start int) {
+			defer wg.Done()
+			for j := start; j < start+numRecordsPerGoroutine; j++ {
+				mTx := newMonitoredTx(fmt.Sprintf("0x%x", j), "0xSender", "0xReceiver",
+					uint64(j), types.MonitoredTxStatusCreated, 10)
+				if err := storage.Add(ctx, mTx); err != nil {
+					errs <- err
+					continue
+				}
+				mTx.Status = types.MonitoredTxStatusSent
+				if err := storage.Update(ctx, mTx); err != nil {
+					errs <- err
+				}
+			}
+		}(i * numRecordsPerGoroutine)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	counts, err := storage.CountByStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, numWriterGoroutines*numRecordsPerGoroutine, counts[types.MonitoredTxStatusSent])
+}
+
 func TestSqlStorage_MonitoredTxTableExists(t *testing.T) {
 	storage, err := NewStorage(localCommon.SQLLiteDriverName, ":memory:")
 	require.NoError(t, err)
@@ -421,6 +776,385 @@ func TestSqlStorage_MonitoredTxTableExists(t *testing.T) {
 	require.Equal(t, "monitored_txs", tableName)
 }
 
+func TestSqlStorage_Maintenance(t *testing.T) {
+	ctx := context.Background()
+
+	storage, err := NewStorage(localCommon.SQLLiteDriverName, path.Join(t.TempDir(), "txmanager.sqlite"))
+	require.NoError(t, err)
+	defer storage.db.Close()
+
+	// populate the database and remove part of the records to leave free pages behind
+	for i := 0; i < 50; i++ {
+		mTx := newMonitoredTx(fmt.Sprintf("0x%x", i), "0xSender1", "0xReceiver1", uint64(i), types.MonitoredTxStatusCreated, 10)
+		require.NoError(t, storage.Add(ctx, mTx))
+	}
+	for i := 0; i < 25; i++ {
+		require.NoError(t, storage.Remove(ctx, common.HexToHash(fmt.Sprintf("0x%x", i))))
+	}
+
+	require.NoError(t, storage.Maintenance(ctx))
+
+	// remaining records are still available after the maintenance
+	mTxs, err := storage.GetByStatus(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, mTxs, 25)
+}
+
+func TestRepairMigrations(t *testing.T) {
+	ctx := context.Background()
+	dbPath := path.Join(t.TempDir(), "txmanager.sqlite")
+
+	storage, err := NewStorage(localCommon.SQLLiteDriverName, dbPath)
+	require.NoError(t, err)
+	require.NoError(t, storage.Add(ctx, newMonitoredTx("0x1", "0xSender1", "0xReceiver1", 1, types.MonitoredTxStatusCreated, 10)))
+
+	// simulate the process killed in the middle of the last migrations: part of their changes
+	// are in the schema but they are not recorded as applied
+	_, err = storage.db.Exec(`DROP TRIGGER monitored_txs_seq;
+		DELETE FROM gorp_migrations WHERE id IN ('0007.sql', '0008.sql');`)
+	require.NoError(t, err)
+	require.NoError(t, storage.db.Close())
+
+	_, err = NewStorage(localCommon.SQLLiteDriverName, dbPath)
+	require.ErrorIs(t, err, ErrInconsistentMigrations)
+
+	storage, err = NewStorageWithConfig(localCommon.SQLLiteDriverName, dbPath, Config{RepairMigrations: true})
+	require.NoError(t, err)
+
+	// the missing changes were applied and the migrations recorded
+	var triggers int
+	err = storage.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'trigger' AND name = 'monitored_txs_seq'`).
+		Scan(&triggers)
+	require.NoError(t, err)
+	require.Equal(t, 1, triggers)
+	require.NoError(t, storage.Add(ctx, newMonitoredTx("0x2", "0xSender1", "0xReceiver1", 2, types.MonitoredTxStatusCreated, 10)))
+	mTxs, err := storage.GetByStatus(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, mTxs, 2)
+
+	// nothing is left to repair
+	repaired, err := RepairMigrations(localCommon.SQLLiteDriverName, storage.db)
+	require.NoError(t, err)
+	require.Zero(t, repaired)
+	require.NoError(t, storage.db.Close())
+
+	storage, err = NewStorage(localCommon.SQLLiteDriverName, dbPath)
+	require.NoError(t, err)
+
+	// a migration unknown by this version can't be repaired
+	_, err = storage.db.Exec(`INSERT INTO gorp_migrations (id, applied_at) VALUES ('9999.sql', CURRENT_TIMESTAMP)`)
+	require.NoError(t, err)
+	require.NoError(t, storage.db.Close())
+
+	_, err = NewStorageWithConfig(localCommon.SQLLiteDriverName, dbPath, Config{RepairMigrations: true})
+	require.ErrorIs(t, err, ErrInconsistentMigrations)
+}
+
+func TestSqlStorage_TableName(t *testing.T) {
+	ctx := context.Background()
+	dbPath := path.Join(t.TempDir(), "txmanager.sqlite")
+
+	_, err := NewStorageWithConfig(localCommon.SQLLiteDriverName, dbPath, Config{TableName: "txs; DROP TABLE x"})
+	require.ErrorIs(t, err, ErrInvalidTableName)
+
+	storageA, err := NewStorageWithConfig(localCommon.SQLLiteDriverName, dbPath, Config{TableName: "tenant_a_txs"})
+	require.NoError(t, err)
+	storageB, err := NewStorageWithConfig(localCommon.SQLLiteDriverName, dbPath, Config{TableName: "tenant_b_txs"})
+	require.NoError(t, err)
+	storageDefault, err := NewStorage(localCommon.SQLLiteDriverName, dbPath)
+	require.NoError(t, err)
+
+	// the same id can be stored in every table
+	require.NoError(t, storageA.Add(ctx, newMonitoredTx("0x1", "0xSender1", "0xReceiver1", 1, types.MonitoredTxStatusCreated, 10)))
+	require.NoError(t, storageB.Add(ctx, newMonitoredTx("0x1", "0xSender2", "0xReceiver2", 1, types.MonitoredTxStatusSent, 10)))
+	require.NoError(t, storageB.Add(ctx, newMonitoredTx("0x2", "0xSender2", "0xReceiver2", 2, types.MonitoredTxStatusSent, 10)))
+
+	mTxsA, err := storageA.GetByStatus(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, mTxsA, 1)
+	require.Equal(t, common.HexToAddress("0xSender1"), mTxsA[0].From)
+
+	mTxsB, err := storageB.GetByStatus(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, mTxsB, 2)
+
+	mTxsDefault, err := storageDefault.GetByStatus(ctx, nil)
+	require.NoError(t, err)
+	require.Empty(t, mTxsDefault)
+
+	// changes to a table don't affect the others
+	mTx := mTxsB[0]
+	mTx.Status = types.MonitoredTxStatusMined
+	require.NoError(t, storageB.Update(ctx, mTx))
+	require.NoError(t, storageB.Remove(ctx, common.HexToHash("0x2")))
+	require.NoError(t, storageDefault.Empty(ctx))
+
+	mTxA, err := storageA.Get(ctx, common.HexToHash("0x1"))
+	require.NoError(t, err)
+	require.Equal(t, types.MonitoredTxStatusCreated, mTxA.Status)
+
+	mTxB, err := storageB.Get(ctx, common.HexToHash("0x1"))
+	require.NoError(t, err)
+	require.Equal(t, types.MonitoredTxStatusMined, mTxB.Status)
+
+	// every table has its own triggers and migration records
+	var triggers int
+	err = storageA.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'trigger' AND name IN
+		('tenant_a_txs_seq', 'tenant_b_txs_seq', 'monitored_txs_seq')`).Scan(&triggers)
+	require.NoError(t, err)
+	require.Equal(t, 3, triggers)
+
+	var defaultRecords int
+	require.NoError(t, storageA.db.QueryRow("SELECT COUNT(*) FROM gorp_migrations").Scan(&defaultRecords))
+	require.Positive(t, defaultRecords)
+	for _, recordsTable := range []string{"tenant_a_txs_migrations", "tenant_b_txs_migrations"} {
+		var records int
+		require.NoError(t, storageA.db.QueryRow("SELECT COUNT(*) FROM "+recordsTable).Scan(&records))
+		require.Equal(t, defaultRecords, records, recordsTable)
+	}
+
+	// reopening a table doesn't run its migrations again
+	require.NoError(t, storageA.db.Close())
+	storageA, err = NewStorageWithConfig(localCommon.SQLLiteDriverName, dbPath, Config{TableName: "tenant_a_txs"})
+	require.NoError(t, err)
+	_, err = storageA.Get(ctx, common.HexToHash("0x1"))
+	require.NoError(t, err)
+}
+
+func TestSqlStorage_WithTx(t *testing.T) {
+	ctx := context.Background()
+	storage, err := NewStorage(localCommon.SQLLiteDriverName, path.Join(t.TempDir(), "txmanager.sqlite"))
+	require.NoError(t, err)
+	defer storage.db.Close()
+
+	mTx1 := newMonitoredTx("0x1", "0xSender", "0xReceiver", 1, types.MonitoredTxStatusCreated, 10)
+	mTx2 := newMonitoredTx("0x2", "0xSender", "0xReceiver", 2, types.MonitoredTxStatusCreated, 10)
+	require.NoError(t, storage.Add(ctx, mTx1))
+	require.NoError(t, storage.Add(ctx, mTx2))
+
+	setNonces := func(nonce1, nonce2 uint64) func(types.StorageInterface) error {
+		return func(txStorage types.StorageInterface) error {
+			mTx1.Nonce = nonce1
+			if err := txStorage.Update(ctx, mTx1); err != nil {
+				return err
+			}
+			mTx2.Nonce = nonce2
+			return txStorage.Update(ctx, mTx2)
+		}
+	}
+	requireNonces := func(t *testing.T, nonce1, nonce2 uint64) {
+		t.Helper()
+		mTx, err := storage.Get(ctx, mTx1.ID)
+		require.NoError(t, err)
+		require.Equal(t, nonce1, mTx.Nonce)
+		mTx, err = storage.Get(ctx, mTx2.ID)
+		require.NoError(t, err)
+		require.Equal(t, nonce2, mTx.Nonce)
+	}
+
+	t.Run("commits all the changes", func(t *testing.T) {
+		require.NoError(t, storage.WithTx(ctx, setNonces(5, 6)))
+		requireNonces(t, 5, 6)
+	})
+
+	t.Run("rolls back all the changes on a mid-batch failure", func(t *testing.T) {
+		batchErr := errors.New("simulated failure")
+		err := storage.WithTx(ctx, func(txStorage types.StorageInterface) error {
+			if err := setNonces(7, 8)(txStorage); err != nil {
+				return err
+			}
+			return batchErr
+		})
+		require.ErrorIs(t, err, batchErr)
+		requireNonces(t, 5, 6)
+
+		// a failing update in the middle of the batch discards the previous ones
+		err = storage.WithTx(ctx, func(txStorage types.StorageInterface) error {
+			mTx1.Nonce = 9
+			if err := txStorage.Update(ctx, mTx1); err != nil {
+				return err
+			}
+			return txStorage.Update(ctx, newMonitoredTx("0x3", "0xSender", "0xReceiver", 3, types.MonitoredTxStatusCreated, 10))
+		})
+		require.ErrorIs(t, err, types.ErrNotFound)
+		requireNonces(t, 5, 6)
+	})
+
+	t.Run("nested transactions join the outer one", func(t *testing.T) {
+		batchErr := errors.New("simulated failure")
+		err := storage.WithTx(ctx, func(txStorage types.StorageInterface) error {
+			if err := txStorage.WithTx(ctx, setNonces(10, 11)); err != nil {
+				return err
+			}
+			return batchErr
+		})
+		require.ErrorIs(t, err, batchErr)
+		requireNonces(t, 5, 6)
+	})
+}
+
+func TestSqlStorage_Checkpoint(t *testing.T) {
+	ctx := context.Background()
+	dbPath := path.Join(t.TempDir(), "txmanager.sqlite")
+
+	storage, err := NewStorage(localCommon.SQLLiteDriverName, dbPath)
+	require.NoError(t, err)
+	defer storage.db.Close()
+	tenantStorage, err := NewStorageWithConfig(localCommon.SQLLiteDriverName, dbPath, Config{TableName: "tenant_txs"})
+	require.NoError(t, err)
+	defer tenantStorage.db.Close()
+
+	_, err = storage.GetCheckpoint(ctx)
+	require.ErrorIs(t, err, types.ErrNotFound)
+
+	checkpoint := types.Checkpoint{LastCycleAt: time.Now().Add(-time.Minute), LastFinalizedBlock: 100}
+	require.NoError(t, storage.SetCheckpoint(ctx, checkpoint))
+	stored, err := storage.GetCheckpoint(ctx)
+	require.NoError(t, err)
+	require.True(t, checkpoint.LastCycleAt.Equal(stored.LastCycleAt))
+	require.Equal(t, checkpoint.LastFinalizedBlock, stored.LastFinalizedBlock)
+
+	// the checkpoint is replaced
+	checkpoint = types.Checkpoint{LastCycleAt: time.Now(), LastFinalizedBlock: 110}
+	require.NoError(t, storage.SetCheckpoint(ctx, checkpoint))
+	stored, err = storage.GetCheckpoint(ctx)
+	require.NoError(t, err)
+	require.True(t, checkpoint.LastCycleAt.Equal(stored.LastCycleAt))
+	require.Equal(t, checkpoint.LastFinalizedBlock, stored.LastFinalizedBlock)
+
+	// each table keeps its own checkpoint
+	_, err = tenantStorage.GetCheckpoint(ctx)
+	require.ErrorIs(t, err, types.ErrNotFound)
+}
+
+func TestSqlStorage_ReadReplicas(t *testing.T) {
+	ctx := context.Background()
+	primaryPath := path.Join(t.TempDir(), "primary.sqlite")
+	replicaPath := path.Join(t.TempDir(), "replica.sqlite")
+
+	// the replica gets the schema and the data from the primary, here they are written directly
+	replica, err := NewStorage(localCommon.SQLLiteDriverName, replicaPath)
+	require.NoError(t, err)
+	defer replica.db.Close()
+	replicatedTx := newMonitoredTx("0x1", "0xa1", "0xb1", 1, types.MonitoredTxStatusMined, 100)
+	require.NoError(t, replica.Add(ctx, replicatedTx))
+
+	_, err = NewStorageWithConfig(localCommon.SQLLiteDriverName, primaryPath,
+		Config{ReadReplicas: []string{replicaPath, path.Join(t.TempDir(), "missing", "replica.sqlite")}})
+	require.Error(t, err)
+
+	storage, err := NewStorageWithConfig(localCommon.SQLLiteDriverName, primaryPath,
+		Config{ReadReplicas: []string{replicaPath}})
+	require.NoError(t, err)
+	defer storage.db.Close()
+
+	// the reads of the storage hit the primary, so the reads followed by a write are never stale
+	_, err = storage.Get(ctx, replicatedTx.ID)
+	require.ErrorIs(t, err, types.ErrNotFound)
+	mTxs, err := storage.GetByStatus(ctx, []types.MonitoredTxStatus{types.MonitoredTxStatusMined})
+	require.NoError(t, err)
+	require.Empty(t, mTxs)
+
+	// the reads of the replica storage hit the replica
+	replicaStorage := storage.ReadReplica()
+	mTx, err := replicaStorage.Get(ctx, replicatedTx.ID)
+	require.NoError(t, err)
+	require.Equal(t, replicatedTx.ID, mTx.ID)
+	mTxs, err = replicaStorage.GetByStatus(ctx, []types.MonitoredTxStatus{types.MonitoredTxStatusMined})
+	require.NoError(t, err)
+	require.Len(t, mTxs, 1)
+	mTxs, err = replicaStorage.GetByBlock(ctx, localCommon.ToUint64Ptr(100), localCommon.ToUint64Ptr(100))
+	require.NoError(t, err)
+	require.Len(t, mTxs, 1)
+
+	// the writes hit the primary
+	primaryTx := newMonitoredTx("0x2", "0xa1", "0xb1", 2, types.MonitoredTxStatusCreated, 0)
+	require.NoError(t, replicaStorage.Add(ctx, primaryTx))
+	_, err = storage.Get(ctx, primaryTx.ID)
+	require.NoError(t, err)
+	_, err = replicaStorage.Get(ctx, primaryTx.ID)
+	require.ErrorIs(t, err, types.ErrNotFound)
+
+	// the storage bound to a transaction reads from the primary
+	require.NoError(t, storage.WithTx(ctx, func(txStorage types.StorageInterface) error {
+		replicaReader, ok := txStorage.(*SqlStorage)
+		require.True(t, ok)
+		mTx, err := replicaReader.ReadReplica().Get(ctx, primaryTx.ID)
+		require.NoError(t, err)
+		require.Equal(t, types.MonitoredTxStatusCreated, mTx.Status)
+		return nil
+	}))
+
+	// without replicas it's the storage itself
+	require.Same(t, replica, replica.ReadReplica())
+}
+
+func TestClassifySQLiteErr(t *testing.T) {
+	testCases := []struct {
+		name        string
+		err         error
+		expectedErr error
+	}{
+		{"busy", sqlite.Error{Code: sqlite.ErrBusy}, types.ErrStorageUnavailable},
+		{"locked", sqlite.Error{Code: sqlite.ErrLocked}, types.ErrStorageUnavailable},
+		{"disk I/O", sqlite.Error{Code: sqlite.ErrIoErr}, types.ErrStorageUnavailable},
+		{"full", sqlite.Error{Code: sqlite.ErrFull}, types.ErrStorageUnavailable},
+		{"can't open", sqlite.Error{Code: sqlite.ErrCantOpen}, types.ErrStorageUnavailable},
+		{"readonly", sqlite.Error{Code: sqlite.ErrReadonly}, types.ErrStorageUnavailable},
+		{"wrapped busy", fmt.Errorf("query: %w", sqlite.Error{Code: sqlite.ErrBusy}), types.ErrStorageUnavailable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.ErrorIs(t, classifySQLiteErr(tc.err), tc.expectedErr)
+		})
+	}
+
+	t.Run("constraint errors are kept as they are", func(t *testing.T) {
+		err := sqlite.Error{Code: sqlite.ErrConstraint, ExtendedCode: sqlite.ErrConstraintUnique}
+		classified := classifySQLiteErr(err)
+		require.Equal(t, err, classified)
+		require.NotErrorIs(t, classified, types.ErrAlreadyExists)
+	})
+
+	t.Run("other sqlite errors are kept as they are", func(t *testing.T) {
+		err := sqlite.Error{Code: sqlite.ErrError}
+		classified := classifySQLiteErr(err)
+		require.Equal(t, err, classified)
+		require.NotErrorIs(t, classified, types.ErrStorageUnavailable)
+	})
+
+	t.Run("non sqlite errors are kept as they are", func(t *testing.T) {
+		err := errors.New("some error")
+		require.Equal(t, err, classifySQLiteErr(err))
+	})
+}
+
+func TestClassifyInsertErr(t *testing.T) {
+	testCases := []struct {
+		name          string
+		err           error
+		alreadyExists bool
+	}{
+		{"unique", sqlite.Error{Code: sqlite.ErrConstraint, ExtendedCode: sqlite.ErrConstraintUnique}, true},
+		{"primary key", sqlite.Error{Code: sqlite.ErrConstraint, ExtendedCode: sqlite.ErrConstraintPrimaryKey}, true},
+		{"not null", sqlite.Error{Code: sqlite.ErrConstraint, ExtendedCode: sqlite.ErrConstraintNotNull}, false},
+		{"check", sqlite.Error{Code: sqlite.ErrConstraint, ExtendedCode: sqlite.ErrConstraintCheck}, false},
+		{"busy", sqlite.Error{Code: sqlite.ErrBusy}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			classified := classifyInsertErr(tc.err)
+			if tc.alreadyExists {
+				require.ErrorIs(t, classified, types.ErrAlreadyExists)
+			} else {
+				require.NotErrorIs(t, classified, types.ErrAlreadyExists)
+			}
+		})
+	}
+}
+
 // Helper function to create a MonitoredTx for testing
 func newMonitoredTx(idHex string, fromHex string, toHex string, nonce uint64, status types.MonitoredTxStatus, blockNumber int64) types.MonitoredTx {
 	return types.MonitoredTx{
@@ -0,0 +1,79 @@
+package ethtxmanager
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/zkevm-ethtx-manager/log"
+	"github.com/0xPolygon/zkevm-ethtx-manager/types"
+)
+
+// defaultStatusHookTimeout is the time a status hook can take when StatusHookTimeout is not configured
+const defaultStatusHookTimeout = 5 * time.Second
+
+// StatusHook is a callback invoked when a monitored tx enters a status
+type StatusHook func(ctx context.Context, mTx types.MonitoredTx)
+
+// statusHooks keeps the hooks registered per status
+type statusHooks struct {
+	mu    sync.RWMutex
+	hooks map[types.MonitoredTxStatus][]StatusHook
+}
+
+func (h *statusHooks) add(status types.MonitoredTxStatus, fn StatusHook) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.hooks == nil {
+		h.hooks = make(map[types.MonitoredTxStatus][]StatusHook)
+	}
+	h.hooks[status] = append(h.hooks[status], fn)
+}
+
+func (h *statusHooks) get(status types.MonitoredTxStatus) []StatusHook {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.hooks[status]
+}
+
+// OnStatus registers a hook invoked every time a monitored tx enters the provided status.
+// The hooks are invoked synchronously in the monitoring goroutine once the new status is stored,
+// so they must return quickly: the context provided to the hook is canceled after StatusHookTimeout
+// and the monitoring goroutine stops waiting for it. A panicking hook is recovered and logged.
+func (c *Client) OnStatus(status types.MonitoredTxStatus, fn StatusHook) {
+	c.statusHooks.add(status, fn)
+}
+
+// notifyStatus invokes the hooks registered for the current status of the monitored tx
+func (c *Client) notifyStatus(ctx context.Context, mTx types.MonitoredTx) {
+	for _, fn := range c.statusHooks.get(mTx.Status) {
+		c.runStatusHook(ctx, fn, mTx)
+	}
+}
+
+// runStatusHook invokes the hook recovering from panics and waits for it up to the configured timeout
+func (c *Client) runStatusHook(ctx context.Context, fn StatusHook, mTx types.MonitoredTx) {
+	timeout := c.cfg.StatusHookTimeout.Duration
+	if timeout <= 0 {
+		timeout = defaultStatusHookTimeout
+	}
+	hookCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			if r := recover(); r != nil {
+				log.Errorf("status hook for monitored tx %s (%s) panicked: %v", mTx.ID.String(), mTx.Status, r)
+			}
+		}()
+		fn(hookCtx, mTx)
+	}()
+
+	select {
+	case <-done:
+	case <-hookCtx.Done():
+		log.Warnf("status hook for monitored tx %s (%s) didn't finish in %v", mTx.ID.String(), mTx.Status, timeout)
+	}
+}
@@ -8,6 +8,8 @@ import (
 
 	common "github.com/ethereum/go-ethereum/common"
 
+	gethclient "github.com/ethereum/go-ethereum/ethclient/gethclient"
+
 	mock "github.com/stretchr/testify/mock"
 
 	time "time"
@@ -28,6 +30,127 @@ func (_m *EthermanInterface) EXPECT() *EthermanInterface_Expecter {
 	return &EthermanInterface_Expecter{mock: &_m.Mock}
 }
 
+// BalanceAt provides a mock function with given fields: ctx, account
+func (_m *EthermanInterface) BalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	ret := _m.Called(ctx, account)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BalanceAt")
+	}
+
+	var r0 *big.Int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) (*big.Int, error)); ok {
+		return rf(ctx, account)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) *big.Int); ok {
+		r0 = rf(ctx, account)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address) error); ok {
+		r1 = rf(ctx, account)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EthermanInterface_BalanceAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BalanceAt'
+type EthermanInterface_BalanceAt_Call struct {
+	*mock.Call
+}
+
+// BalanceAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - account common.Address
+func (_e *EthermanInterface_Expecter) BalanceAt(ctx interface{}, account interface{}) *EthermanInterface_BalanceAt_Call {
+	return &EthermanInterface_BalanceAt_Call{Call: _e.mock.On("BalanceAt", ctx, account)}
+}
+
+func (_c *EthermanInterface_BalanceAt_Call) Run(run func(ctx context.Context, account common.Address)) *EthermanInterface_BalanceAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Address))
+	})
+	return _c
+}
+
+func (_c *EthermanInterface_BalanceAt_Call) Return(_a0 *big.Int, _a1 error) *EthermanInterface_BalanceAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EthermanInterface_BalanceAt_Call) RunAndReturn(run func(context.Context, common.Address) (*big.Int, error)) *EthermanInterface_BalanceAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CallContract provides a mock function with given fields: ctx, from, to, value, data
+func (_m *EthermanInterface) CallContract(ctx context.Context, from common.Address, to *common.Address, value *big.Int, data []byte) ([]byte, error) {
+	ret := _m.Called(ctx, from, to, value, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CallContract")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, *common.Address, *big.Int, []byte) ([]byte, error)); ok {
+		return rf(ctx, from, to, value, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, *common.Address, *big.Int, []byte) []byte); ok {
+		r0 = rf(ctx, from, to, value, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, *common.Address, *big.Int, []byte) error); ok {
+		r1 = rf(ctx, from, to, value, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EthermanInterface_CallContract_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CallContract'
+type EthermanInterface_CallContract_Call struct {
+	*mock.Call
+}
+
+// CallContract is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from common.Address
+//   - to *common.Address
+//   - value *big.Int
+//   - data []byte
+func (_e *EthermanInterface_Expecter) CallContract(ctx interface{}, from interface{}, to interface{}, value interface{}, data interface{}) *EthermanInterface_CallContract_Call {
+	return &EthermanInterface_CallContract_Call{Call: _e.mock.On("CallContract", ctx, from, to, value, data)}
+}
+
+func (_c *EthermanInterface_CallContract_Call) Run(run func(ctx context.Context, from common.Address, to *common.Address, value *big.Int, data []byte)) *EthermanInterface_CallContract_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Address), args[2].(*common.Address), args[3].(*big.Int), args[4].([]byte))
+	})
+	return _c
+}
+
+func (_c *EthermanInterface_CallContract_Call) Return(_a0 []byte, _a1 error) *EthermanInterface_CallContract_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EthermanInterface_CallContract_Call) RunAndReturn(run func(context.Context, common.Address, *common.Address, *big.Int, []byte) ([]byte, error)) *EthermanInterface_CallContract_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CheckTxWasMined provides a mock function with given fields: ctx, txHash
 func (_m *EthermanInterface) CheckTxWasMined(ctx context.Context, txHash common.Hash) (bool, *types.Receipt, error) {
 	ret := _m.Called(ctx, txHash)
@@ -273,6 +396,123 @@ func (_c *EthermanInterface_EstimateGasBlobTx_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// EstimateGasWithStateOverrides provides a mock function with given fields: ctx, from, to, value, data, overrides
+func (_m *EthermanInterface) EstimateGasWithStateOverrides(ctx context.Context, from common.Address, to *common.Address, value *big.Int, data []byte, overrides map[common.Address]gethclient.OverrideAccount) (uint64, error) {
+	ret := _m.Called(ctx, from, to, value, data, overrides)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateGasWithStateOverrides")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, *common.Address, *big.Int, []byte, map[common.Address]gethclient.OverrideAccount) (uint64, error)); ok {
+		return rf(ctx, from, to, value, data, overrides)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, *common.Address, *big.Int, []byte, map[common.Address]gethclient.OverrideAccount) uint64); ok {
+		r0 = rf(ctx, from, to, value, data, overrides)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, *common.Address, *big.Int, []byte, map[common.Address]gethclient.OverrideAccount) error); ok {
+		r1 = rf(ctx, from, to, value, data, overrides)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EthermanInterface_EstimateGasWithStateOverrides_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EstimateGasWithStateOverrides'
+type EthermanInterface_EstimateGasWithStateOverrides_Call struct {
+	*mock.Call
+}
+
+// EstimateGasWithStateOverrides is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from common.Address
+//   - to *common.Address
+//   - value *big.Int
+//   - data []byte
+//   - overrides map[common.Address]gethclient.OverrideAccount
+func (_e *EthermanInterface_Expecter) EstimateGasWithStateOverrides(ctx interface{}, from interface{}, to interface{}, value interface{}, data interface{}, overrides interface{}) *EthermanInterface_EstimateGasWithStateOverrides_Call {
+	return &EthermanInterface_EstimateGasWithStateOverrides_Call{Call: _e.mock.On("EstimateGasWithStateOverrides", ctx, from, to, value, data, overrides)}
+}
+
+func (_c *EthermanInterface_EstimateGasWithStateOverrides_Call) Run(run func(ctx context.Context, from common.Address, to *common.Address, value *big.Int, data []byte, overrides map[common.Address]gethclient.OverrideAccount)) *EthermanInterface_EstimateGasWithStateOverrides_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Address), args[2].(*common.Address), args[3].(*big.Int), args[4].([]byte), args[5].(map[common.Address]gethclient.OverrideAccount))
+	})
+	return _c
+}
+
+func (_c *EthermanInterface_EstimateGasWithStateOverrides_Call) Return(_a0 uint64, _a1 error) *EthermanInterface_EstimateGasWithStateOverrides_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EthermanInterface_EstimateGasWithStateOverrides_Call) RunAndReturn(run func(context.Context, common.Address, *common.Address, *big.Int, []byte, map[common.Address]gethclient.OverrideAccount) (uint64, error)) *EthermanInterface_EstimateGasWithStateOverrides_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChainID provides a mock function with given fields: ctx
+func (_m *EthermanInterface) GetChainID(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainID")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EthermanInterface_GetChainID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainID'
+type EthermanInterface_GetChainID_Call struct {
+	*mock.Call
+}
+
+// GetChainID is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *EthermanInterface_Expecter) GetChainID(ctx interface{}) *EthermanInterface_GetChainID_Call {
+	return &EthermanInterface_GetChainID_Call{Call: _e.mock.On("GetChainID", ctx)}
+}
+
+func (_c *EthermanInterface_GetChainID_Call) Run(run func(ctx context.Context)) *EthermanInterface_GetChainID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *EthermanInterface_GetChainID_Call) Return(_a0 uint64, _a1 error) *EthermanInterface_GetChainID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EthermanInterface_GetChainID_Call) RunAndReturn(run func(context.Context) (uint64, error)) *EthermanInterface_GetChainID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetHeaderByNumber provides a mock function with given fields: ctx, number
 func (_m *EthermanInterface) GetHeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
 	ret := _m.Called(ctx, number)
@@ -628,6 +868,74 @@ func (_c *EthermanInterface_GetTxReceipt_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// GetTxsAndReceipts provides a mock function with given fields: ctx, txHashes
+func (_m *EthermanInterface) GetTxsAndReceipts(ctx context.Context, txHashes []common.Hash) ([]*types.Transaction, []*types.Receipt, error) {
+	ret := _m.Called(ctx, txHashes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTxsAndReceipts")
+	}
+
+	var r0 []*types.Transaction
+	var r1 []*types.Receipt
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash) ([]*types.Transaction, []*types.Receipt, error)); ok {
+		return rf(ctx, txHashes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash) []*types.Transaction); ok {
+		r0 = rf(ctx, txHashes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []common.Hash) []*types.Receipt); ok {
+		r1 = rf(ctx, txHashes)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*types.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []common.Hash) error); ok {
+		r2 = rf(ctx, txHashes)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// EthermanInterface_GetTxsAndReceipts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTxsAndReceipts'
+type EthermanInterface_GetTxsAndReceipts_Call struct {
+	*mock.Call
+}
+
+// GetTxsAndReceipts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - txHashes []common.Hash
+func (_e *EthermanInterface_Expecter) GetTxsAndReceipts(ctx interface{}, txHashes interface{}) *EthermanInterface_GetTxsAndReceipts_Call {
+	return &EthermanInterface_GetTxsAndReceipts_Call{Call: _e.mock.On("GetTxsAndReceipts", ctx, txHashes)}
+}
+
+func (_c *EthermanInterface_GetTxsAndReceipts_Call) Run(run func(ctx context.Context, txHashes []common.Hash)) *EthermanInterface_GetTxsAndReceipts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]common.Hash))
+	})
+	return _c
+}
+
+func (_c *EthermanInterface_GetTxsAndReceipts_Call) Return(_a0 []*types.Transaction, _a1 []*types.Receipt, _a2 error) *EthermanInterface_GetTxsAndReceipts_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *EthermanInterface_GetTxsAndReceipts_Call) RunAndReturn(run func(context.Context, []common.Hash) ([]*types.Transaction, []*types.Receipt, error)) *EthermanInterface_GetTxsAndReceipts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // HeaderByNumber provides a mock function with given fields: ctx, number
 func (_m *EthermanInterface) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
 	ret := _m.Called(ctx, number)
@@ -848,6 +1156,53 @@ func (_c *EthermanInterface_SendTx_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// SendTxIdempotent provides a mock function with given fields: ctx, tx
+func (_m *EthermanInterface) SendTxIdempotent(ctx context.Context, tx *types.Transaction) error {
+	ret := _m.Called(ctx, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendTxIdempotent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.Transaction) error); ok {
+		r0 = rf(ctx, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EthermanInterface_SendTxIdempotent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendTxIdempotent'
+type EthermanInterface_SendTxIdempotent_Call struct {
+	*mock.Call
+}
+
+// SendTxIdempotent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx *types.Transaction
+func (_e *EthermanInterface_Expecter) SendTxIdempotent(ctx interface{}, tx interface{}) *EthermanInterface_SendTxIdempotent_Call {
+	return &EthermanInterface_SendTxIdempotent_Call{Call: _e.mock.On("SendTxIdempotent", ctx, tx)}
+}
+
+func (_c *EthermanInterface_SendTxIdempotent_Call) Run(run func(ctx context.Context, tx *types.Transaction)) *EthermanInterface_SendTxIdempotent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.Transaction))
+	})
+	return _c
+}
+
+func (_c *EthermanInterface_SendTxIdempotent_Call) Return(_a0 error) *EthermanInterface_SendTxIdempotent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EthermanInterface_SendTxIdempotent_Call) RunAndReturn(run func(context.Context, *types.Transaction) error) *EthermanInterface_SendTxIdempotent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SignTx provides a mock function with given fields: ctx, sender, tx
 func (_m *EthermanInterface) SignTx(ctx context.Context, sender common.Address, tx *types.Transaction) (*types.Transaction, error) {
 	ret := _m.Called(ctx, sender, tx)
@@ -908,6 +1263,66 @@ func (_c *EthermanInterface_SignTx_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// SuggestTipCapFromHistory provides a mock function with given fields: ctx, blocks, percentile
+func (_m *EthermanInterface) SuggestTipCapFromHistory(ctx context.Context, blocks uint64, percentile float64) (*big.Int, error) {
+	ret := _m.Called(ctx, blocks, percentile)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SuggestTipCapFromHistory")
+	}
+
+	var r0 *big.Int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, float64) (*big.Int, error)); ok {
+		return rf(ctx, blocks, percentile)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, float64) *big.Int); ok {
+		r0 = rf(ctx, blocks, percentile)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, float64) error); ok {
+		r1 = rf(ctx, blocks, percentile)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EthermanInterface_SuggestTipCapFromHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SuggestTipCapFromHistory'
+type EthermanInterface_SuggestTipCapFromHistory_Call struct {
+	*mock.Call
+}
+
+// SuggestTipCapFromHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blocks uint64
+//   - percentile float64
+func (_e *EthermanInterface_Expecter) SuggestTipCapFromHistory(ctx interface{}, blocks interface{}, percentile interface{}) *EthermanInterface_SuggestTipCapFromHistory_Call {
+	return &EthermanInterface_SuggestTipCapFromHistory_Call{Call: _e.mock.On("SuggestTipCapFromHistory", ctx, blocks, percentile)}
+}
+
+func (_c *EthermanInterface_SuggestTipCapFromHistory_Call) Run(run func(ctx context.Context, blocks uint64, percentile float64)) *EthermanInterface_SuggestTipCapFromHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(float64))
+	})
+	return _c
+}
+
+func (_c *EthermanInterface_SuggestTipCapFromHistory_Call) Return(_a0 *big.Int, _a1 error) *EthermanInterface_SuggestTipCapFromHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EthermanInterface_SuggestTipCapFromHistory_Call) RunAndReturn(run func(context.Context, uint64, float64) (*big.Int, error)) *EthermanInterface_SuggestTipCapFromHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SuggestedGasPrice provides a mock function with given fields: ctx
 func (_m *EthermanInterface) SuggestedGasPrice(ctx context.Context) (*big.Int, error) {
 	ret := _m.Called(ctx)
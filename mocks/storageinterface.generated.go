@@ -9,6 +9,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	types "github.com/0xPolygon/zkevm-ethtx-manager/types"
 )
 
@@ -72,6 +74,122 @@ func (_c *StorageInterface_Add_Call) RunAndReturn(run func(context.Context, type
 	return _c
 }
 
+// CountByStatus provides a mock function with given fields: ctx
+func (_m *StorageInterface) CountByStatus(ctx context.Context) (map[types.MonitoredTxStatus]int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByStatus")
+	}
+
+	var r0 map[types.MonitoredTxStatus]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[types.MonitoredTxStatus]int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[types.MonitoredTxStatus]int); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[types.MonitoredTxStatus]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageInterface_CountByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByStatus'
+type StorageInterface_CountByStatus_Call struct {
+	*mock.Call
+}
+
+// CountByStatus is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *StorageInterface_Expecter) CountByStatus(ctx interface{}) *StorageInterface_CountByStatus_Call {
+	return &StorageInterface_CountByStatus_Call{Call: _e.mock.On("CountByStatus", ctx)}
+}
+
+func (_c *StorageInterface_CountByStatus_Call) Run(run func(ctx context.Context)) *StorageInterface_CountByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *StorageInterface_CountByStatus_Call) Return(_a0 map[types.MonitoredTxStatus]int, _a1 error) *StorageInterface_CountByStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageInterface_CountByStatus_Call) RunAndReturn(run func(context.Context) (map[types.MonitoredTxStatus]int, error)) *StorageInterface_CountByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DistinctSenders provides a mock function with given fields: ctx
+func (_m *StorageInterface) DistinctSenders(ctx context.Context) ([]common.Address, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DistinctSenders")
+	}
+
+	var r0 []common.Address
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]common.Address, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []common.Address); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.Address)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageInterface_DistinctSenders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DistinctSenders'
+type StorageInterface_DistinctSenders_Call struct {
+	*mock.Call
+}
+
+// DistinctSenders is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *StorageInterface_Expecter) DistinctSenders(ctx interface{}) *StorageInterface_DistinctSenders_Call {
+	return &StorageInterface_DistinctSenders_Call{Call: _e.mock.On("DistinctSenders", ctx)}
+}
+
+func (_c *StorageInterface_DistinctSenders_Call) Run(run func(ctx context.Context)) *StorageInterface_DistinctSenders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *StorageInterface_DistinctSenders_Call) Return(_a0 []common.Address, _a1 error) *StorageInterface_DistinctSenders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageInterface_DistinctSenders_Call) RunAndReturn(run func(context.Context) ([]common.Address, error)) *StorageInterface_DistinctSenders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Empty provides a mock function with given fields: ctx
 func (_m *StorageInterface) Empty(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -294,6 +412,181 @@ func (_c *StorageInterface_GetByStatus_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// GetCheckpoint provides a mock function with given fields: ctx
+func (_m *StorageInterface) GetCheckpoint(ctx context.Context) (types.Checkpoint, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCheckpoint")
+	}
+
+	var r0 types.Checkpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (types.Checkpoint, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) types.Checkpoint); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.Checkpoint)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageInterface_GetCheckpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCheckpoint'
+type StorageInterface_GetCheckpoint_Call struct {
+	*mock.Call
+}
+
+// GetCheckpoint is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *StorageInterface_Expecter) GetCheckpoint(ctx interface{}) *StorageInterface_GetCheckpoint_Call {
+	return &StorageInterface_GetCheckpoint_Call{Call: _e.mock.On("GetCheckpoint", ctx)}
+}
+
+func (_c *StorageInterface_GetCheckpoint_Call) Run(run func(ctx context.Context)) *StorageInterface_GetCheckpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *StorageInterface_GetCheckpoint_Call) Return(_a0 types.Checkpoint, _a1 error) *StorageInterface_GetCheckpoint_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageInterface_GetCheckpoint_Call) RunAndReturn(run func(context.Context) (types.Checkpoint, error)) *StorageInterface_GetCheckpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStale provides a mock function with given fields: ctx, statuses, olderThan
+func (_m *StorageInterface) GetStale(ctx context.Context, statuses []types.MonitoredTxStatus, olderThan time.Time) ([]types.MonitoredTx, error) {
+	ret := _m.Called(ctx, statuses, olderThan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStale")
+	}
+
+	var r0 []types.MonitoredTx
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.MonitoredTxStatus, time.Time) ([]types.MonitoredTx, error)); ok {
+		return rf(ctx, statuses, olderThan)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []types.MonitoredTxStatus, time.Time) []types.MonitoredTx); ok {
+		r0 = rf(ctx, statuses, olderThan)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.MonitoredTx)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []types.MonitoredTxStatus, time.Time) error); ok {
+		r1 = rf(ctx, statuses, olderThan)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageInterface_GetStale_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStale'
+type StorageInterface_GetStale_Call struct {
+	*mock.Call
+}
+
+// GetStale is a helper method to define mock.On call
+//   - ctx context.Context
+//   - statuses []types.MonitoredTxStatus
+//   - olderThan time.Time
+func (_e *StorageInterface_Expecter) GetStale(ctx interface{}, statuses interface{}, olderThan interface{}) *StorageInterface_GetStale_Call {
+	return &StorageInterface_GetStale_Call{Call: _e.mock.On("GetStale", ctx, statuses, olderThan)}
+}
+
+func (_c *StorageInterface_GetStale_Call) Run(run func(ctx context.Context, statuses []types.MonitoredTxStatus, olderThan time.Time)) *StorageInterface_GetStale_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]types.MonitoredTxStatus), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *StorageInterface_GetStale_Call) Return(_a0 []types.MonitoredTx, _a1 error) *StorageInterface_GetStale_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageInterface_GetStale_Call) RunAndReturn(run func(context.Context, []types.MonitoredTxStatus, time.Time) ([]types.MonitoredTx, error)) *StorageInterface_GetStale_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Query provides a mock function with given fields: ctx, filter
+func (_m *StorageInterface) Query(ctx context.Context, filter types.MonitoredTxFilter) ([]types.MonitoredTx, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Query")
+	}
+
+	var r0 []types.MonitoredTx
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.MonitoredTxFilter) ([]types.MonitoredTx, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.MonitoredTxFilter) []types.MonitoredTx); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.MonitoredTx)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.MonitoredTxFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageInterface_Query_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Query'
+type StorageInterface_Query_Call struct {
+	*mock.Call
+}
+
+// Query is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter types.MonitoredTxFilter
+func (_e *StorageInterface_Expecter) Query(ctx interface{}, filter interface{}) *StorageInterface_Query_Call {
+	return &StorageInterface_Query_Call{Call: _e.mock.On("Query", ctx, filter)}
+}
+
+func (_c *StorageInterface_Query_Call) Run(run func(ctx context.Context, filter types.MonitoredTxFilter)) *StorageInterface_Query_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.MonitoredTxFilter))
+	})
+	return _c
+}
+
+func (_c *StorageInterface_Query_Call) Return(_a0 []types.MonitoredTx, _a1 error) *StorageInterface_Query_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageInterface_Query_Call) RunAndReturn(run func(context.Context, types.MonitoredTxFilter) ([]types.MonitoredTx, error)) *StorageInterface_Query_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Remove provides a mock function with given fields: ctx, id
 func (_m *StorageInterface) Remove(ctx context.Context, id common.Hash) error {
 	ret := _m.Called(ctx, id)
@@ -341,6 +634,110 @@ func (_c *StorageInterface_Remove_Call) RunAndReturn(run func(context.Context, c
 	return _c
 }
 
+// RemoveByStatus provides a mock function with given fields: ctx, statuses
+func (_m *StorageInterface) RemoveByStatus(ctx context.Context, statuses []types.MonitoredTxStatus) (int, error) {
+	ret := _m.Called(ctx, statuses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveByStatus")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.MonitoredTxStatus) (int, error)); ok {
+		return rf(ctx, statuses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []types.MonitoredTxStatus) int); ok {
+		r0 = rf(ctx, statuses)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []types.MonitoredTxStatus) error); ok {
+		r1 = rf(ctx, statuses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageInterface_RemoveByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveByStatus'
+type StorageInterface_RemoveByStatus_Call struct {
+	*mock.Call
+}
+
+// RemoveByStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - statuses []types.MonitoredTxStatus
+func (_e *StorageInterface_Expecter) RemoveByStatus(ctx interface{}, statuses interface{}) *StorageInterface_RemoveByStatus_Call {
+	return &StorageInterface_RemoveByStatus_Call{Call: _e.mock.On("RemoveByStatus", ctx, statuses)}
+}
+
+func (_c *StorageInterface_RemoveByStatus_Call) Run(run func(ctx context.Context, statuses []types.MonitoredTxStatus)) *StorageInterface_RemoveByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]types.MonitoredTxStatus))
+	})
+	return _c
+}
+
+func (_c *StorageInterface_RemoveByStatus_Call) Return(_a0 int, _a1 error) *StorageInterface_RemoveByStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageInterface_RemoveByStatus_Call) RunAndReturn(run func(context.Context, []types.MonitoredTxStatus) (int, error)) *StorageInterface_RemoveByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetCheckpoint provides a mock function with given fields: ctx, checkpoint
+func (_m *StorageInterface) SetCheckpoint(ctx context.Context, checkpoint types.Checkpoint) error {
+	ret := _m.Called(ctx, checkpoint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCheckpoint")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.Checkpoint) error); ok {
+		r0 = rf(ctx, checkpoint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageInterface_SetCheckpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCheckpoint'
+type StorageInterface_SetCheckpoint_Call struct {
+	*mock.Call
+}
+
+// SetCheckpoint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - checkpoint types.Checkpoint
+func (_e *StorageInterface_Expecter) SetCheckpoint(ctx interface{}, checkpoint interface{}) *StorageInterface_SetCheckpoint_Call {
+	return &StorageInterface_SetCheckpoint_Call{Call: _e.mock.On("SetCheckpoint", ctx, checkpoint)}
+}
+
+func (_c *StorageInterface_SetCheckpoint_Call) Run(run func(ctx context.Context, checkpoint types.Checkpoint)) *StorageInterface_SetCheckpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.Checkpoint))
+	})
+	return _c
+}
+
+func (_c *StorageInterface_SetCheckpoint_Call) Return(_a0 error) *StorageInterface_SetCheckpoint_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageInterface_SetCheckpoint_Call) RunAndReturn(run func(context.Context, types.Checkpoint) error) *StorageInterface_SetCheckpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, mTx
 func (_m *StorageInterface) Update(ctx context.Context, mTx types.MonitoredTx) error {
 	ret := _m.Called(ctx, mTx)
@@ -388,6 +785,53 @@ func (_c *StorageInterface_Update_Call) RunAndReturn(run func(context.Context, t
 	return _c
 }
 
+// WithTx provides a mock function with given fields: ctx, fn
+func (_m *StorageInterface) WithTx(ctx context.Context, fn func(types.StorageInterface) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(types.StorageInterface) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageInterface_WithTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTx'
+type StorageInterface_WithTx_Call struct {
+	*mock.Call
+}
+
+// WithTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(types.StorageInterface) error
+func (_e *StorageInterface_Expecter) WithTx(ctx interface{}, fn interface{}) *StorageInterface_WithTx_Call {
+	return &StorageInterface_WithTx_Call{Call: _e.mock.On("WithTx", ctx, fn)}
+}
+
+func (_c *StorageInterface_WithTx_Call) Run(run func(ctx context.Context, fn func(types.StorageInterface) error)) *StorageInterface_WithTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(types.StorageInterface) error))
+	})
+	return _c
+}
+
+func (_c *StorageInterface_WithTx_Call) Return(_a0 error) *StorageInterface_WithTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageInterface_WithTx_Call) RunAndReturn(run func(context.Context, func(types.StorageInterface) error) error) *StorageInterface_WithTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewStorageInterface creates a new instance of StorageInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewStorageInterface(t interface {
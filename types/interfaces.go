@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
 )
 
 var (
@@ -15,6 +16,11 @@ var (
 	ErrNotFound = errors.New("not found")
 	// ErrAlreadyExists when the object already exists
 	ErrAlreadyExists = errors.New("already exists")
+	// ErrStorageUnavailable when the storage can't be used for now (locked, busy, full, I/O failure...),
+	// the operation is worth retrying later
+	ErrStorageUnavailable = errors.New("storage unavailable")
+	// ErrReverted when the execution of a mined tx was reverted
+	ErrReverted = errors.New("execution reverted")
 )
 
 // EthermanInterface defines a set of methods for interacting with the Ethereum blockchain,
@@ -36,6 +42,11 @@ type EthermanInterface interface {
 	// Returns an error if the transaction cannot be sent.
 	SendTx(ctx context.Context, tx *types.Transaction) error
 
+	// SendTxIdempotent broadcasts a signed transaction to the Ethereum network, tolerating
+	// the transaction being already known by the node or already mined.
+	// Returns an error if the transaction cannot be sent.
+	SendTxIdempotent(ctx context.Context, tx *types.Transaction) error
+
 	// CurrentNonce retrieves the current nonce of a specific account
 	// from the latest block (used for non-pending transactions).
 	// Returns the nonce and an error if the nonce cannot be retrieved.
@@ -45,6 +56,10 @@ type EthermanInterface interface {
 	// Returns the nonce and an error if the nonce cannot be retrieved.
 	PendingNonce(ctx context.Context, account common.Address) (uint64, error)
 
+	// BalanceAt retrieves the balance in wei of a specific account at the latest block.
+	// Returns the balance and an error if the balance cannot be retrieved.
+	BalanceAt(ctx context.Context, account common.Address) (*big.Int, error)
+
 	// SuggestedGasPrice retrieves the currently suggested gas price from the Ethereum network.
 	// Returns the suggested gas price in wei and an error if the gas price cannot be retrieved.
 	SuggestedGasPrice(ctx context.Context) (*big.Int, error)
@@ -68,6 +83,23 @@ type EthermanInterface interface {
 		data []byte,
 	) (uint64, error)
 
+	// EstimateGasWithStateOverrides estimates the amount of gas required to execute a transaction over the
+	// latest state modified by the provided state overrides (balance, nonce, code or storage of the accounts).
+	// Returns the estimated gas and an error if the estimation fails or the node doesn't support the overrides.
+	EstimateGasWithStateOverrides(
+		ctx context.Context,
+		from common.Address,
+		to *common.Address,
+		value *big.Int,
+		data []byte,
+		overrides map[common.Address]gethclient.OverrideAccount,
+	) (uint64, error)
+
+	// CallContract executes a message call (eth_call) between 'from' and 'to' against the latest state
+	// without creating a transaction. Returns the output of the call and an error if the call fails,
+	// including when its execution is reverted.
+	CallContract(ctx context.Context, from common.Address, to *common.Address, value *big.Int, data []byte) ([]byte, error)
+
 	// CheckTxWasMined checks whether a transaction with the given hash was mined.
 	// Returns true if the transaction was mined, along with the receipt and an error if any.
 	CheckTxWasMined(ctx context.Context, txHash common.Hash) (bool, *types.Receipt, error)
@@ -80,10 +112,18 @@ type EthermanInterface interface {
 	// Returns the revert message string and an error if the revert reason cannot be retrieved.
 	GetRevertMessage(ctx context.Context, tx *types.Transaction) (string, error)
 
+	// GetTxsAndReceipts retrieves the txs and the receipts of the provided hashes in a single batch call.
+	// Returns them in the same order as the hashes, nil when not found, and an error if they can't be retrieved.
+	GetTxsAndReceipts(ctx context.Context, txHashes []common.Hash) ([]*types.Transaction, []*types.Receipt, error)
+
 	// GetLatestBlockNumber retrieves the number of the latest block in the blockchain.
 	// Returns the block number and an error if it cannot be retrieved.
 	GetLatestBlockNumber(ctx context.Context) (uint64, error)
 
+	// GetChainID retrieves the chain ID of the network the node is connected to.
+	// Returns the chain ID and an error if it cannot be retrieved.
+	GetChainID(ctx context.Context) (uint64, error)
+
 	// GetHeaderByNumber retrieves the block header for a specific block number.
 	// If the block number is nil, it retrieves the latest block header.
 	// Returns the block header and an error if it cannot be retrieved.
@@ -93,6 +133,11 @@ type EthermanInterface interface {
 	// Returns the gas tip cap and an error if it cannot be retrieved.
 	GetSuggestGasTipCap(ctx context.Context) (*big.Int, error)
 
+	// SuggestTipCapFromHistory suggests a gas tip cap from the tips paid at the given percentile
+	// in the latest blocks according to eth_feeHistory.
+	// Returns the gas tip cap and an error if it cannot be retrieved.
+	SuggestTipCapFromHistory(ctx context.Context, blocks uint64, percentile float64) (*big.Int, error)
+
 	// HeaderByNumber is an alias for GetHeaderByNumber. It retrieves the block header for a specific block number.
 	// Returns the block header and an error if it cannot be retrieved.
 	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
@@ -101,6 +146,20 @@ type EthermanInterface interface {
 	PublicAddress() ([]common.Address, error)
 }
 
+// TxBroadcaster defines how the signed transactions are delivered to the network
+type TxBroadcaster interface {
+	// Broadcast delivers a signed transaction to the network.
+	// Returns an error if the transaction cannot be delivered.
+	Broadcast(ctx context.Context, tx *types.Transaction) error
+}
+
+// NonceProvider defines how the nonces of the senders are obtained when new nonces are assigned
+type NonceProvider interface {
+	// Nonce returns the next nonce to be used by the sender.
+	// Returns an error if the nonce cannot be obtained.
+	Nonce(ctx context.Context, sender common.Address) (uint64, error)
+}
+
 // StorageInterface defines the methods required to interact with
 // the storage layer for managing MonitoredTx entities.
 type StorageInterface interface {
@@ -113,15 +172,37 @@ type StorageInterface interface {
 	// Returns an error if the transaction cannot be found or removed.
 	Remove(ctx context.Context, id common.Hash) error
 
+	// RemoveByStatus deletes all the MonitoredTx entities with a matching status.
+	// At least one status must be provided. Returns the number of removed transactions.
+	RemoveByStatus(ctx context.Context, statuses []MonitoredTxStatus) (int, error)
+
 	// Get retrieves a MonitoredTx from the storage by its ID.
 	// Returns the MonitoredTx if found, or an error if it doesn't exist.
 	Get(ctx context.Context, id common.Hash) (MonitoredTx, error)
 
+	// Query retrieves all MonitoredTx entities matching all the criteria of the filter.
+	// The transactions are ordered by their creation date (oldest first).
+	// Returns a slice of MonitoredTx and an error if any occurs during retrieval.
+	Query(ctx context.Context, filter MonitoredTxFilter) ([]MonitoredTx, error)
+
 	// GetByStatus retrieves all MonitoredTx entities with a matching status.
 	// Takes a list of MonitoredTxStatus to filter the transactions.
 	// Returns a slice of MonitoredTx and an error if any occurs during retrieval.
 	GetByStatus(ctx context.Context, statuses []MonitoredTxStatus) ([]MonitoredTx, error)
 
+	// GetStale retrieves the MonitoredTx entities with a matching status that were not updated since olderThan.
+	// If no statuses are provided, all the non-terminal statuses (created, sent, mined and safe) are considered.
+	// The transactions are ordered by their creation date (oldest first).
+	GetStale(ctx context.Context, statuses []MonitoredTxStatus, olderThan time.Time) ([]MonitoredTx, error)
+
+	// CountByStatus counts the MonitoredTx entities grouped by their status without loading them.
+	// The statuses without transactions are not included in the result.
+	CountByStatus(ctx context.Context) (map[MonitoredTxStatus]int, error)
+
+	// DistinctSenders retrieves the senders of the MonitoredTx entities without loading them,
+	// each sender is returned once.
+	DistinctSenders(ctx context.Context) ([]common.Address, error)
+
 	// GetByBlock retrieves MonitoredTx transactions that have a block number
 	// between the specified fromBlock and toBlock.
 	// If either block number is nil, it will be ignored in the query.
@@ -133,6 +214,19 @@ type StorageInterface interface {
 	// Returns an error if the transaction cannot be updated.
 	Update(ctx context.Context, mTx MonitoredTx) error
 
+	// WithTx runs fn with a storage whose changes are applied atomically: all of them are persisted
+	// when fn returns nil and none of them when it returns an error, which is returned by WithTx.
+	// The storage passed to fn must not be used once fn returns.
+	WithTx(ctx context.Context, fn func(StorageInterface) error) error
+
+	// GetCheckpoint retrieves the last checkpoint persisted by the tx manager.
+	// Returns ErrNotFound if no checkpoint was persisted yet.
+	GetCheckpoint(ctx context.Context) (Checkpoint, error)
+
+	// SetCheckpoint persists the checkpoint of the tx manager, replacing the previous one.
+	// Returns an error if the checkpoint cannot be stored.
+	SetCheckpoint(ctx context.Context, checkpoint Checkpoint) error
+
 	// Empty removes all MonitoredTx entities from the storage.
 	// This is typically used for clearing all data or resetting the state.
 	// Returns an error if the operation fails.
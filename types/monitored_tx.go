@@ -2,6 +2,7 @@ package types
 
 import (
 	"database/sql"
+	"fmt"
 	"math/big"
 	"time"
 
@@ -45,81 +46,255 @@ func (s MonitoredTxStatus) String() string {
 	return string(s)
 }
 
+// IsTerminal checks if a monitored tx with the status can't move to any other status,
+// so it's not monitored anymore
+func (s MonitoredTxStatus) IsTerminal() bool {
+	switch s {
+	case MonitoredTxStatusFinalized, MonitoredTxStatusFailed, MonitoredTxStatusEvicted:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllStatuses returns all the statuses of a monitored tx, the non terminal ones first
+// in the order a monitored tx goes through them
+func AllStatuses() []MonitoredTxStatus {
+	return []MonitoredTxStatus{
+		MonitoredTxStatusCreated,
+		MonitoredTxStatusSent,
+		MonitoredTxStatusMined,
+		MonitoredTxStatusSafe,
+		MonitoredTxStatusFinalized,
+		MonitoredTxStatusFailed,
+		MonitoredTxStatusEvicted,
+	}
+}
+
 // MonitoredTx represents a set of information used to build tx
 // plus information to monitor if the transactions was sent successfully
 type MonitoredTx struct {
 	// ID is the tx identifier controlled by the caller
-	ID common.Hash `mapstructure:"id" meddler:"id,hash"`
+	ID common.Hash `mapstructure:"id" json:"id" meddler:"id,hash"`
 
 	// From is the sender of the tx, used to identify which private key should be used to sign the tx
-	From common.Address `mapstructure:"from" meddler:"from_address,address"`
+	From common.Address `mapstructure:"from" json:"from" meddler:"from_address,address"`
 
 	// To is the receiver of the tx
-	To *common.Address `mapstructure:"to" meddler:"to_address,address"`
+	To *common.Address `mapstructure:"to" json:"to" meddler:"to_address,address"`
 
 	// Nonce is used to create the tx
-	Nonce uint64 `mapstructure:"nonce" meddler:"nonce"`
+	Nonce uint64 `mapstructure:"nonce" json:"nonce" meddler:"nonce"`
 
 	// Value is the transaction value
-	Value *big.Int `mapstructure:"value" meddler:"value,bigInt"`
+	Value *big.Int `mapstructure:"value" json:"value" meddler:"value,bigInt"`
 
 	// Data represents the transaction data
-	Data []byte `mapstructure:"data" meddler:"tx_data"`
+	Data []byte `mapstructure:"data" json:"data" meddler:"tx_data"`
 
-	// Gas is the amount of gas for the transaction
-	Gas uint64 `mapstructure:"gas" meddler:"gas"`
+	// Gas is the amount of gas for the transaction without the GasOffset, it's the gas estimation (or the
+	// gas provided by the caller) and the re-estimations done while reviewing the tx replace it
+	Gas uint64 `mapstructure:"gas" json:"gas" meddler:"gas"`
 
-	// GasOffset is the offset applied to the gas amount
-	GasOffset uint64 `mapstructure:"gasOffset" meddler:"gas_offset"`
+	// GasOffset is the offset applied to the gas amount, it's added to Gas when the tx is built so it's
+	// applied exactly once on top of every estimation, see GasLimit
+	GasOffset uint64 `mapstructure:"gasOffset" json:"gasOffset" meddler:"gas_offset"`
 
 	// GasPrice is the price per gas unit for the transaction
-	GasPrice *big.Int `mapstructure:"gasPrice" meddler:"gas_price,bigInt"`
+	GasPrice *big.Int `mapstructure:"gasPrice" json:"gasPrice" meddler:"gas_price,bigInt"`
 
 	// BlobSidecar holds sidecar data for blob transactions
-	BlobSidecar *types.BlobTxSidecar `mapstructure:"blobSidecar" meddler:"blob_sidecar,json"`
+	BlobSidecar *types.BlobTxSidecar `mapstructure:"blobSidecar" json:"blobSidecar" meddler:"blob_sidecar,json"`
 
 	// BlobGas is the gas amount for the blob transaction
-	BlobGas uint64 `mapstructure:"blobGas" meddler:"blob_gas"`
+	BlobGas uint64 `mapstructure:"blobGas" json:"blobGas" meddler:"blob_gas"`
 
 	// BlobGasPrice is the gas price for blob transactions
-	BlobGasPrice *big.Int `mapstructure:"blobGasPrice" meddler:"blob_gas_price,bigInt"`
+	BlobGasPrice *big.Int `mapstructure:"blobGasPrice" json:"blobGasPrice" meddler:"blob_gas_price,bigInt"`
 
 	// GasTipCap is the tip cap for the gas fee
-	GasTipCap *big.Int `mapstructure:"gasTipCap" meddler:"gas_tip_cap,bigInt"`
+	GasTipCap *big.Int `mapstructure:"gasTipCap" json:"gasTipCap" meddler:"gas_tip_cap,bigInt"`
 
 	// Status represents the status of this monitored transaction
-	Status MonitoredTxStatus `mapstructure:"status" meddler:"status"`
+	Status MonitoredTxStatus `mapstructure:"status" json:"status" meddler:"status"`
 
 	// BlockNumber represents the block where the transaction was identified to be mined
 	// This is used to control reorged monitored txs.
-	BlockNumber *big.Int `mapstructure:"blockNumber" meddler:"block_number,bigInt"`
+	BlockNumber *big.Int `mapstructure:"blockNumber" json:"blockNumber" meddler:"block_number,bigInt"`
+
+	// BlockHash is the hash of the block where the transaction was identified to be mined, it detects the
+	// reorgs replacing that block with another one at the same height. nil when unknown
+	BlockHash *common.Hash `mapstructure:"blockHash" json:"blockHash" meddler:"block_hash,hash"`
 
 	// History represents all transaction hashes created using this struct and sent to the network
-	History map[common.Hash]bool `mapstructure:"history" meddler:"history,json"`
+	History map[common.Hash]bool `mapstructure:"history" json:"history" meddler:"history,json"`
+
+	// LastTxHash is the hash of the last signed tx added to the history, the one built from the current
+	// fields that is expected on-chain. nil until the tx is signed
+	LastTxHash *common.Hash `mapstructure:"lastTxHash" json:"lastTxHash" meddler:"last_tx_hash,hash"`
 
 	// CreatedAt is the timestamp for when the transaction was created
-	CreatedAt time.Time `mapstructure:"createdAt" meddler:"created_at,timeRFC3339"`
+	CreatedAt time.Time `mapstructure:"createdAt" json:"createdAt" meddler:"created_at,timeRFC3339"`
 
 	// UpdatedAt is the timestamp for when the transaction was last updated
-	UpdatedAt time.Time `mapstructure:"updatedAt" meddler:"updated_at,timeRFC3339"`
+	UpdatedAt time.Time `mapstructure:"updatedAt" json:"updatedAt" meddler:"updated_at,timeRFC3339"`
+
+	// SentAt is the timestamp for when the transaction was first sent to the network, zero until it's sent
+	SentAt time.Time `mapstructure:"sentAt" json:"sentAt" meddler:"sent_at,timeRFC3339"`
+
+	// MinedAt is the timestamp for when the transaction was identified to be mined, zero until it's mined
+	// and reset when its block is reorged
+	MinedAt time.Time `mapstructure:"minedAt" json:"minedAt" meddler:"mined_at,timeRFC3339"`
+
+	// FinalizedAt is the timestamp for when the transaction was identified to be finalized, zero until
+	// it's finalized
+	FinalizedAt time.Time `mapstructure:"finalizedAt" json:"finalizedAt" meddler:"finalized_at,timeRFC3339"`
 
 	// EstimateGas indicates whether gas should be estimated or the last value should be reused
-	EstimateGas bool `mapstructure:"estimateGas" meddler:"estimate_gas"`
+	EstimateGas bool `mapstructure:"estimateGas" json:"estimateGas" meddler:"estimate_gas"`
 
 	// RetryCount tracks the number of times this transaction has been retried
-	RetryCount uint64 `mapstructure:"retryCount" meddler:"retry_count"`
+	RetryCount uint64 `mapstructure:"retryCount" json:"retryCount" meddler:"retry_count"`
+
+	// SendAttempts tracks the number of times this transaction was broadcast by the monitoring
+	SendAttempts uint64 `mapstructure:"sendAttempts" json:"sendAttempts" meddler:"send_attempts"`
+
+	// FixedFees indicates the fee cap (GasPrice) and the GasTipCap were pinned by the caller
+	// and must not be updated when reviewing the tx
+	FixedFees bool `mapstructure:"fixedFees" json:"fixedFees" meddler:"fixed_fees"`
+
+	// PrivateRelay indicates the tx must be broadcast through the private relay instead of the public mempool
+	PrivateRelay bool `mapstructure:"privateRelay" json:"privateRelay" meddler:"private_relay"`
+
+	// FixedNonce indicates the nonce was provided by the caller and must never be reassigned
+	FixedNonce bool `mapstructure:"fixedNonce" json:"fixedNonce" meddler:"fixed_nonce"`
+
+	// ValidUntilBlock is the last block the tx is worth being mined in, once the chain passes it
+	// the tx is evicted if it's not mined yet. 0 means the tx has no deadline
+	ValidUntilBlock uint64 `mapstructure:"validUntilBlock" json:"validUntilBlock" meddler:"valid_until_block"`
+
+	// ResendCount tracks the number of times the fees of the sent tx were reviewed to send it again
+	ResendCount uint64 `mapstructure:"resendCount" json:"resendCount" meddler:"resend_count"`
+
+	// Priority of the tx over the other txs in the monitoring cycles, the txs with a higher priority
+	// are processed and get their nonces assigned first. 0 is the default priority
+	Priority int `mapstructure:"priority" json:"priority" meddler:"priority"`
+
+	// NoReplace indicates the tx is sent once and never replaced by another tx with bumped fees or a new
+	// nonce, e.g. when a replacement could execute twice if both land. It's evicted if it isn't mined in time
+	NoReplace bool `mapstructure:"noReplace" json:"noReplace" meddler:"no_replace"`
+
+	// FeeHistory records every change of the fees done while reviewing the tx, oldest first, to explain
+	// what the tx cost. The fees the tx was added with are its current fees until the first change
+	FeeHistory []FeeBumpRecord `mapstructure:"feeHistory" json:"feeHistory" meddler:"fee_history,json"`
+
+	// Reason explains why the tx is held back or why it reached its status when it wasn't mined, e.g. the
+	// sender can't afford it or it was evicted. Empty when there is nothing to explain
+	Reason string `mapstructure:"reason" json:"reason" meddler:"reason"`
+
+	// TxTypeConverted indicates the tx type was converted by the legacy fallback or the legacy upgrade,
+	// so the other one doesn't convert it back
+	TxTypeConverted bool `mapstructure:"txTypeConverted" json:"txTypeConverted" meddler:"tx_type_converted"`
+}
+
+// FeeBumpRecord is a change of the fees of a monitored tx, with the fees it was changed to
+type FeeBumpRecord struct {
+	// At is when the fees were changed
+	At time.Time `json:"at"`
+
+	// GasPrice is the gas price of the legacy txs or the fee cap of the dynamic fee and blob txs
+	GasPrice *big.Int `json:"gasPrice"`
+
+	// GasTipCap is the tip cap of the dynamic fee and blob txs, nil for the legacy txs
+	GasTipCap *big.Int `json:"gasTipCap,omitempty"`
+
+	// BlobGasPrice is the blob fee cap of the blob txs, nil for the other txs
+	BlobGasPrice *big.Int `json:"blobGasPrice,omitempty"`
+}
+
+// RecordFees appends the current fees of the monitored tx to its FeeHistory when they differ
+// from the provided previous fees, returning whether they were recorded
+func (mTx *MonitoredTx) RecordFees(at time.Time, previous FeeBumpRecord) bool {
+	if equalBigInts(mTx.GasPrice, previous.GasPrice) && equalBigInts(mTx.GasTipCap, previous.GasTipCap) &&
+		equalBigInts(mTx.BlobGasPrice, previous.BlobGasPrice) {
+		return false
+	}
+	mTx.FeeHistory = append(mTx.FeeHistory, mTx.Fees(at))
+	return true
+}
+
+// Fees returns a record of the current fees of the monitored tx
+func (mTx *MonitoredTx) Fees(at time.Time) FeeBumpRecord {
+	return FeeBumpRecord{
+		At:           at,
+		GasPrice:     copyBigInt(mTx.GasPrice),
+		GasTipCap:    copyBigInt(mTx.GasTipCap),
+		BlobGasPrice: copyBigInt(mTx.BlobGasPrice),
+	}
+}
+
+// equalBigInts tells whether both values are nil or equal
+func equalBigInts(a, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
+
+// copyBigInt returns a copy of the value, nil if it's nil
+func copyBigInt(value *big.Int) *big.Int {
+	if value == nil {
+		return nil
+	}
+	return new(big.Int).Set(value)
+}
+
+// GasLimit returns the gas limit of the tx, which is the Gas plus the GasOffset
+func (mTx *MonitoredTx) GasLimit() uint64 {
+	return mTx.Gas + mTx.GasOffset
+}
+
+// TimeToMine returns the time from the creation of the tx until it was mined, false if it's not mined
+// or its timestamps are unknown
+func (mTx *MonitoredTx) TimeToMine() (time.Duration, bool) {
+	return elapsed(mTx.CreatedAt, mTx.MinedAt)
+}
+
+// TimeToFinalize returns the time from the creation of the tx until it was finalized, false if it's not
+// finalized or its timestamps are unknown
+func (mTx *MonitoredTx) TimeToFinalize() (time.Duration, bool) {
+	return elapsed(mTx.CreatedAt, mTx.FinalizedAt)
+}
+
+// elapsed returns the time between both timestamps, false if any of them is unknown
+func elapsed(from, to time.Time) (time.Duration, bool) {
+	if from.IsZero() || to.IsZero() {
+		return 0, false
+	}
+	return to.Sub(from), true
 }
 
-// Tx uses the current information to build a tx
+// Tx uses the current information to build a tx.
+// Non blob txs with a GasTipCap are built as dynamic fee txs using GasPrice as fee cap.
 func (mTx *MonitoredTx) Tx() *types.Transaction {
 	var tx *types.Transaction
-	if mTx.BlobSidecar == nil {
+	if mTx.BlobSidecar == nil && mTx.GasTipCap != nil {
+		tx = types.NewTx(&types.DynamicFeeTx{
+			To:        mTx.To,
+			Nonce:     mTx.Nonce,
+			Value:     mTx.Value,
+			Data:      mTx.Data,
+			Gas:       mTx.GasLimit(),
+			GasFeeCap: mTx.GasPrice,
+			GasTipCap: mTx.GasTipCap,
+		})
+	} else if mTx.BlobSidecar == nil {
 		tx = types.NewTx(&types.LegacyTx{
 			To:       mTx.To,
 			Nonce:    mTx.Nonce,
 			Value:    mTx.Value,
 			Data:     mTx.Data,
-			Gas:      mTx.Gas + mTx.GasOffset,
+			Gas:      mTx.GasLimit(),
 			GasPrice: mTx.GasPrice,
 		})
 	} else {
@@ -130,7 +305,7 @@ func (mTx *MonitoredTx) Tx() *types.Transaction {
 			Data:       mTx.Data,
 			GasFeeCap:  uint256.MustFromBig(mTx.GasPrice),
 			GasTipCap:  uint256.MustFromBig(mTx.GasTipCap),
-			Gas:        mTx.Gas + mTx.GasOffset,
+			Gas:        mTx.GasLimit(),
 			BlobFeeCap: uint256.MustFromBig(mTx.BlobGasPrice),
 			BlobHashes: mTx.BlobSidecar.BlobHashes(),
 			Sidecar:    mTx.BlobSidecar,
@@ -140,12 +315,14 @@ func (mTx *MonitoredTx) Tx() *types.Transaction {
 	return tx
 }
 
-// AddHistory adds a transaction to the monitoring history
+// AddHistory adds a transaction to the monitoring history and sets it as the last tx
 func (mTx *MonitoredTx) AddHistory(tx *types.Transaction) (bool, error) {
-	if _, found := mTx.History[tx.Hash()]; found {
+	txHash := tx.Hash()
+	mTx.LastTxHash = &txHash
+	if _, found := mTx.History[txHash]; found {
 		return true, ErrAlreadyExists
 	}
-	mTx.History[tx.Hash()] = true
+	mTx.History[txHash] = true
 	return false, nil
 }
 
@@ -187,6 +364,12 @@ func (mTx *MonitoredTx) PopulateNullableStrings(toAddress, blockNumber, value, g
 	}
 }
 
+// MonitoredTxResultOrError is an item of a results stream, either a result or the error that ended the stream
+type MonitoredTxResultOrError struct {
+	Result MonitoredTxResult
+	Err    error
+}
+
 // MonitoredTxResult represents the result of a execution of a monitored tx
 type MonitoredTxResult struct {
 	ID                 common.Hash
@@ -197,6 +380,121 @@ type MonitoredTxResult struct {
 	MinedAtBlockNumber *big.Int
 	Status             MonitoredTxStatus
 	Txs                map[common.Hash]TxResult
+	// Confirmations is the number of blocks since the tx was mined including its block, 0 if not mined.
+	// It's only set when the confirmations are requested in the tx manager configuration
+	Confirmations uint64
+	// FeeHistory are the changes of the fees of the monitored tx, oldest first
+	FeeHistory []FeeBumpRecord
+	// LastTxHash is the hash of the last signed tx of the monitored tx, the one expected on-chain,
+	// nil if it wasn't signed yet
+	LastTxHash *common.Hash
+	// SentAt, MinedAt and FinalizedAt are the timestamps the monitored tx reached each status, zero if
+	// it didn't reach it
+	SentAt      time.Time
+	MinedAt     time.Time
+	FinalizedAt time.Time
+	// Reason explains why the monitored tx is held back or why it reached its status, see MonitoredTx.Reason
+	Reason string
+}
+
+// TotalGasCost returns the fees paid by all the mined txs in the monitored tx history,
+// including the blob fees of the blob txs
+func (r MonitoredTxResult) TotalGasCost() *big.Int {
+	total := big.NewInt(0)
+	for _, txResult := range r.Txs {
+		receipt := txResult.Receipt
+		if receipt == nil {
+			continue
+		}
+
+		if receipt.EffectiveGasPrice != nil {
+			total.Add(total, new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice))
+		}
+		if receipt.BlobGasPrice != nil {
+			total.Add(total, new(big.Int).Mul(new(big.Int).SetUint64(receipt.BlobGasUsed), receipt.BlobGasPrice))
+		}
+	}
+	return total
+}
+
+// RevertReason returns the decoded revert reason of a failed monitored tx, taken from the
+// latest reverted tx of its history that revealed it
+func (r MonitoredTxResult) RevertReason() (string, bool) {
+	txHash, found := r.revertedTx()
+	if !found || r.Txs[txHash].RevertMessage == "" {
+		return "", false
+	}
+	return r.Txs[txHash].RevertMessage, true
+}
+
+// Err returns a RevertedError when the monitored tx failed because its execution was reverted, nil otherwise
+func (r MonitoredTxResult) Err() error {
+	txHash, found := r.revertedTx()
+	if !found {
+		return nil
+	}
+	return &RevertedError{ID: r.ID, TxHash: txHash, Reason: r.Txs[txHash].RevertMessage}
+}
+
+// revertedTx returns the hash of the latest reverted tx of the history of a failed monitored tx,
+// preferring the ones that revealed the revert reason
+func (r MonitoredTxResult) revertedTx() (common.Hash, bool) {
+	if r.Status != MonitoredTxStatusFailed {
+		return common.Hash{}, false
+	}
+
+	var (
+		found    bool
+		selected common.Hash
+	)
+	for txHash, txResult := range r.Txs {
+		receipt := txResult.Receipt
+		if receipt == nil || receipt.Status != types.ReceiptStatusFailed {
+			continue
+		}
+		if !found || r.isBetterRevertedTx(txHash, selected) {
+			selected = txHash
+			found = true
+		}
+	}
+	return selected, found
+}
+
+// isBetterRevertedTx reports whether the reverted tx a is preferred over b: the ones with a revert
+// reason first, then the ones mined later, the hash breaks the ties to keep the result deterministic
+func (r MonitoredTxResult) isBetterRevertedTx(a, b common.Hash) bool {
+	txA, txB := r.Txs[a], r.Txs[b]
+	if (txA.RevertMessage != "") != (txB.RevertMessage != "") {
+		return txA.RevertMessage != ""
+	}
+	blockA, blockB := txA.Receipt.BlockNumber, txB.Receipt.BlockNumber
+	if blockA != nil && blockB != nil && blockA.Cmp(blockB) != 0 {
+		return blockA.Cmp(blockB) == 1
+	}
+	return a.Cmp(b) == 1
+}
+
+// RevertedError represents a monitored tx that failed because its execution was reverted
+type RevertedError struct {
+	// ID is the identifier of the monitored tx
+	ID common.Hash
+	// TxHash is the hash of the reverted tx
+	TxHash common.Hash
+	// Reason is the decoded revert reason, empty if the node didn't reveal it
+	Reason string
+}
+
+// Error returns the error message including the revert reason when available
+func (e *RevertedError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("monitored tx %s: %s", e.ID.String(), ErrReverted.Error())
+	}
+	return fmt.Sprintf("monitored tx %s: %s: %s", e.ID.String(), ErrReverted.Error(), e.Reason)
+}
+
+// Unwrap allows to match the error with ErrReverted
+func (e *RevertedError) Unwrap() error {
+	return ErrReverted
 }
 
 // TxResult represents the result of a execution of a ethereum transaction in the block chain
@@ -204,4 +502,65 @@ type TxResult struct {
 	Tx            *types.Transaction
 	Receipt       *types.Receipt
 	RevertMessage string
+	// RawTx is the hex encoded signed tx, ready to be sent with eth_sendRawTransaction.
+	// It's empty when the tx is not found
+	RawTx string
+}
+
+// PendingInfo reports whether the last tx sent for a monitored tx is known by the network,
+// telling apart a tx queued in the pool of the node from a tx dropped from it
+type PendingInfo struct {
+	// ID of the monitored tx
+	ID common.Hash
+	// TxHash is the hash of the last tx sent for the monitored tx, zero if no tx was sent yet
+	TxHash common.Hash
+	// Found is true if the node knows the tx, either pending in its pool or already mined
+	Found bool
+	// IsPending is true if the tx is in the pool of the node waiting to be mined
+	IsPending bool
+}
+
+// MonitoredTxFilter represents the criteria used to query monitored txs,
+// the criteria not provided are ignored
+type MonitoredTxFilter struct {
+	// Statuses of the monitored txs, all the statuses if empty
+	Statuses []MonitoredTxStatus
+
+	// From is the sender of the monitored txs
+	From *common.Address
+
+	// FromBlock is the minimum block number (inclusive) the monitored txs were mined at
+	FromBlock *uint64
+
+	// ToBlock is the maximum block number (inclusive) the monitored txs were mined at
+	ToBlock *uint64
+
+	// CreatedAfter is the minimum creation date (inclusive) of the monitored txs
+	CreatedAfter *time.Time
+
+	// CreatedBefore is the maximum creation date (exclusive) of the monitored txs
+	CreatedBefore *time.Time
+
+	// UpdatedAfter is the minimum last update date (inclusive) of the monitored txs
+	UpdatedAfter *time.Time
+
+	// UpdatedBefore is the maximum last update date (exclusive) of the monitored txs
+	UpdatedBefore *time.Time
+
+	// Limit is the maximum number of monitored txs returned, no limit if 0
+	Limit uint64
+
+	// Offset is the number of monitored txs skipped before returning the results
+	Offset uint64
+}
+
+// Checkpoint is the progress of the monitoring persisted by the tx manager, so a restarted instance
+// knows where the previous one stopped
+type Checkpoint struct {
+	// LastCycleAt is the time the last successful cycle started promoting the safe txs to finalized
+	LastCycleAt time.Time
+
+	// LastFinalizedBlock is the finalized block number processed by the last successful cycle,
+	// the safe txs mined up to it were already promoted to finalized
+	LastFinalizedBlock uint64
 }
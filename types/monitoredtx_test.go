@@ -3,6 +3,7 @@ package types
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -57,3 +58,141 @@ func TestAddHistory(t *testing.T) {
 	historySlice := mTx.HistoryHashSlice()
 	assert.Len(t, historySlice, 1)
 }
+
+func TestTxDynamicFee(t *testing.T) {
+	to := common.HexToAddress("0x2")
+	gasFeeCap := big.NewInt(5)
+	gasTipCap := big.NewInt(1)
+
+	mTx := MonitoredTx{
+		To:        &to,
+		Nonce:     1,
+		Value:     big.NewInt(2),
+		Data:      []byte("data"),
+		Gas:       3,
+		GasOffset: 4,
+		GasPrice:  gasFeeCap,
+		GasTipCap: gasTipCap,
+	}
+
+	tx := mTx.Tx()
+
+	assert.Equal(t, uint8(types.DynamicFeeTxType), tx.Type())
+	assert.Equal(t, uint64(7), tx.Gas())
+	assert.Equal(t, gasFeeCap, tx.GasFeeCap())
+	assert.Equal(t, gasTipCap, tx.GasTipCap())
+}
+
+func TestTotalGasCost(t *testing.T) {
+	result := MonitoredTxResult{
+		Txs: map[common.Hash]TxResult{
+			common.HexToHash("0x1"): {
+				Receipt: &types.Receipt{GasUsed: 21000, EffectiveGasPrice: big.NewInt(10)},
+			},
+			common.HexToHash("0x2"): {
+				Receipt: &types.Receipt{
+					GasUsed: 30000, EffectiveGasPrice: big.NewInt(20),
+					BlobGasUsed: 131072, BlobGasPrice: big.NewInt(2),
+				},
+			},
+			// not mined
+			common.HexToHash("0x3"): {},
+		},
+	}
+
+	// 21000*10 + 30000*20 + 131072*2
+	assert.Equal(t, big.NewInt(1072144), result.TotalGasCost())
+}
+
+func TestRevertReason(t *testing.T) {
+	failed := func(blockNumber int64) *types.Receipt {
+		return &types.Receipt{Status: types.ReceiptStatusFailed, BlockNumber: big.NewInt(blockNumber)}
+	}
+
+	t.Run("not failed", func(t *testing.T) {
+		result := MonitoredTxResult{
+			Status: MonitoredTxStatusMined,
+			Txs: map[common.Hash]TxResult{
+				common.HexToHash("0x1"): {Receipt: failed(1), RevertMessage: "reverted"},
+			},
+		}
+		_, found := result.RevertReason()
+		assert.False(t, found)
+		assert.NoError(t, result.Err())
+	})
+
+	t.Run("reason not revealed", func(t *testing.T) {
+		result := MonitoredTxResult{
+			Status: MonitoredTxStatusFailed,
+			Txs: map[common.Hash]TxResult{
+				common.HexToHash("0x1"): {Receipt: failed(1)},
+			},
+		}
+		_, found := result.RevertReason()
+		assert.False(t, found)
+		assert.ErrorIs(t, result.Err(), ErrReverted)
+	})
+
+	t.Run("latest reason across history", func(t *testing.T) {
+		result := MonitoredTxResult{
+			Status: MonitoredTxStatusFailed,
+			Txs: map[common.Hash]TxResult{
+				common.HexToHash("0x1"): {Receipt: failed(1), RevertMessage: "old reason"},
+				common.HexToHash("0x2"): {Receipt: failed(2), RevertMessage: "new reason"},
+				common.HexToHash("0x3"): {Receipt: failed(3)},
+				common.HexToHash("0x4"): {},
+			},
+		}
+		reason, found := result.RevertReason()
+		assert.True(t, found)
+		assert.Equal(t, "new reason", reason)
+
+		var revertedErr *RevertedError
+		assert.ErrorAs(t, result.Err(), &revertedErr)
+		assert.Equal(t, common.HexToHash("0x2"), revertedErr.TxHash)
+		assert.Contains(t, revertedErr.Error(), "new reason")
+	})
+}
+
+func TestIsTerminal(t *testing.T) {
+	terminal := map[MonitoredTxStatus]bool{
+		MonitoredTxStatusCreated:   false,
+		MonitoredTxStatusSent:      false,
+		MonitoredTxStatusMined:     false,
+		MonitoredTxStatusSafe:      false,
+		MonitoredTxStatusFinalized: true,
+		MonitoredTxStatusFailed:    true,
+		MonitoredTxStatusEvicted:   true,
+	}
+
+	statuses := AllStatuses()
+	assert.Len(t, statuses, len(terminal))
+	for _, status := range statuses {
+		expected, found := terminal[status]
+		assert.True(t, found, "unexpected status %s", status)
+		assert.Equal(t, expected, status.IsTerminal(), "status %s", status)
+	}
+	assert.False(t, MonitoredTxStatus("unknown").IsTerminal())
+}
+
+func TestTimeToMine(t *testing.T) {
+	createdAt := time.Now().Add(-time.Hour)
+	mTx := MonitoredTx{CreatedAt: createdAt}
+
+	_, ok := mTx.TimeToMine()
+	assert.False(t, ok)
+
+	mTx.MinedAt = createdAt.Add(time.Minute)
+	mTx.FinalizedAt = createdAt.Add(15 * time.Minute)
+	timeToMine, ok := mTx.TimeToMine()
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, timeToMine)
+	timeToFinalize, ok := mTx.TimeToFinalize()
+	assert.True(t, ok)
+	assert.Equal(t, 15*time.Minute, timeToFinalize)
+
+	// the txs added before the timestamps were recorded have no latencies
+	mTx.CreatedAt = time.Time{}
+	_, ok = mTx.TimeToFinalize()
+	assert.False(t, ok)
+}